@@ -0,0 +1,115 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ActivityCounts are the numbers of clients of vault which were active
+// over some period. Clients is the sum of EntityClients, which are
+// identity entities, and NonEntityClients, which are tokens without an
+// entity. DistinctEntities and NonEntityTokens are the same counts, as
+// reported by older versions of vault.
+type ActivityCounts struct {
+	Clients          int `json:"clients"`
+	EntityClients    int `json:"entity_clients"`
+	NonEntityClients int `json:"non_entity_clients"`
+	DistinctEntities int `json:"distinct_entities"`
+	NonEntityTokens  int `json:"non_entity_tokens"`
+}
+
+// ActivityNamespace is the activity of the clients of a namespace,
+// along with the activity broken down by the auth mount of the clients.
+type ActivityNamespace struct {
+	NamespaceID   string          `json:"namespace_id"`
+	NamespacePath string          `json:"namespace_path"`
+	Counts        ActivityCounts  `json:"counts"`
+	Mounts        []ActivityMount `json:"mounts"`
+}
+
+// ActivityMount is the activity of the clients of an auth mount.
+type ActivityMount struct {
+	MountPath string         `json:"mount_path"`
+	Counts    ActivityCounts `json:"counts"`
+}
+
+// ActivityMonth is the activity of a month, starting at Timestamp. The
+// NewClients are those which were not active in any earlier month of
+// the period.
+type ActivityMonth struct {
+	Timestamp  string              `json:"timestamp"`
+	Counts     ActivityCounts      `json:"counts"`
+	Namespaces []ActivityNamespace `json:"namespaces"`
+	NewClients struct {
+		Counts     ActivityCounts      `json:"counts"`
+		Namespaces []ActivityNamespace `json:"namespaces"`
+	} `json:"new_clients"`
+}
+
+// Activity is the activity of the clients of vault between StartTime
+// and EndTime, which is used to determine the client count of vault
+// for licensing.
+//
+// More information about client counts can be found here:
+// https://www.vaultproject.io/docs/concepts/client-count
+type Activity struct {
+	StartTime   string              `json:"start_time"`
+	EndTime     string              `json:"end_time"`
+	Total       ActivityCounts      `json:"total"`
+	ByNamespace []ActivityNamespace `json:"by_namespace"`
+	Months      []ActivityMonth     `json:"months"`
+}
+
+type activityWrapper struct {
+	Data Activity `json:"data"`
+}
+
+// Activity returns the activity of clients between start and end,
+// which are rounded to whole months by vault. If start or end are
+// zero, the default reporting period of vault is used instead.
+func (c *client) Activity(start, end time.Time) (Activity, error) {
+	var wrapper activityWrapper
+	requestPath := fixup("/v1/sys/internal/counters", "/activity", activityPeriod(start, end)...)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return Activity{}, errors.Wrap(err, "failed to read activity")
+	}
+	return wrapper.Data, nil
+}
+
+// MonthlyActivity returns the activity of clients during the
+// current month so far.
+func (c *client) MonthlyActivity() (Activity, error) {
+	var wrapper activityWrapper
+	if err := c.get("/v1/sys/internal/counters/activity/monthly", &wrapper); err != nil {
+		return Activity{}, errors.Wrap(err, "failed to read monthly activity")
+	}
+	return wrapper.Data, nil
+}
+
+// ExportActivity exports a record of each client active between start
+// and end, in the given format, which is either "json" (the default,
+// one record per line) or "csv".
+func (c *client) ExportActivity(start, end time.Time, format string) ([]byte, error) {
+	params := append(activityPeriod(start, end), [2]string{"format", format})
+
+	var export []byte
+	requestPath := fixup("/v1/sys/internal/counters", "/activity/export", params...)
+	if err := c.get(requestPath, &export); err != nil {
+		return nil, errors.Wrap(err, "failed to export activity")
+	}
+	return export, nil
+}
+
+func activityPeriod(start, end time.Time) [][2]string {
+	var params [][2]string
+	if !start.IsZero() {
+		params = append(params, [2]string{"start_time", start.UTC().Format(time.RFC3339)})
+	}
+	if !end.IsZero() {
+		params = append(params, [2]string{"end_time", end.UTC().Format(time.RFC3339)})
+	}
+	return params
+}
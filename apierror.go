@@ -0,0 +1,151 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxErrorBody limits how much of the body of a failed response is read
+const maxErrorBody = 64 * 1024
+
+var (
+	// ErrNotFound indicates the requested path did not exist. It is
+	// the same error as ErrPathNotFound.
+	ErrNotFound = ErrPathNotFound
+
+	// ErrPermissionDenied indicates that the token of the request
+	// is not permitted to perform the request, or is invalid.
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrSealed indicates that the vault server is sealed.
+	ErrSealed = errors.New("vault is sealed")
+
+	// ErrRateLimited indicates that the request exceeded a
+	// rate limit quota of vault.
+	ErrRateLimited = errors.New("rate limit quota exceeded")
+)
+
+// An APIError is returned when vault responds to a request with an
+// error status code. Use errors.Is with ErrNotFound, ErrPermissionDenied,
+// ErrSealed, and ErrRateLimited to check for common failures, or
+// errors.As to inspect the APIError itself. For compatibility, the
+// errors.Cause of a 404 is still ErrPathNotFound.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Method and Path are the HTTP method and the path of the
+	// request, e.g. "GET" and "/v1/secret/foo".
+	Method string
+	Path   string
+
+	// URL is the full URL of the request, including the server.
+	URL string
+
+	// Errors and Warnings are the errors and warnings of the
+	// response body, if any.
+	Errors   []string
+	Warnings []string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("bad status code: %d, url: %s", e.StatusCode, e.URL)
+	if len(e.Errors) > 0 {
+		msg += ": " + strings.Join(e.Errors, "; ")
+	}
+	return msg
+}
+
+// Is returns whether e is described by target, which is one of
+// ErrNotFound, ErrPermissionDenied, ErrSealed, or ErrRateLimited.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrPermissionDenied:
+		return e.StatusCode == http.StatusForbidden
+	case ErrSealed:
+		return e.StatusCode == http.StatusServiceUnavailable && e.mentions("sealed")
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+func (e *APIError) mentions(s string) bool {
+	for _, msg := range e.Errors {
+		if strings.Contains(strings.ToLower(msg), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// notFoundError is the APIError of a 404, whose Cause is ErrPathNotFound.
+// Other APIErrors have no Cause, as errors.Cause follows causes until
+// there are none, and so they are their own cause.
+type notFoundError struct {
+	*APIError
+}
+
+func (e notFoundError) Cause() error {
+	return ErrPathNotFound
+}
+
+func (e notFoundError) Unwrap() error {
+	return e.APIError
+}
+
+// newAPIError creates the APIError of a failed response, reading
+// the errors and warnings from its body.
+func newAPIError(request *http.Request, response *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		Method:     request.Method,
+		Path:       request.URL.Path,
+		URL:        request.URL.String(),
+	}
+
+	var body struct {
+		Errors   []string `json:"errors"`
+		Warnings []string `json:"warnings"`
+	}
+	bs, err := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+	if err == nil && json.Unmarshal(bs, &body) == nil {
+		apiErr.Errors = body.Errors
+		apiErr.Warnings = body.Warnings
+	}
+
+	if apiErr.StatusCode == http.StatusNotFound {
+		return notFoundError{apiErr}
+	}
+	return apiErr
+}
+
+// clientError returns whether err is an APIError which is caused by
+// the request itself, and would fail the same way on every server.
+func clientError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	code := apiErr.StatusCode
+	return code >= 400 && code < 500 && code != http.StatusTooManyRequests
+}
+
+// allFailed returns the error of a request which failed on all of the
+// servers, the last failing with err.
+func allFailed(method string, servers []string, err error) error {
+	if err == nil {
+		return errors.Errorf("all attempts for %s request failed to: %v", method, servers)
+	}
+	return errors.Wrapf(err, "all attempts for %s request failed to: %v", method, servers)
+}
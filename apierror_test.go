@@ -0,0 +1,68 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_client_APIError(t *testing.T) {
+	var hosts []string
+	opts := devOpts()
+	opts.Servers = []string{"http://vault-1:8200", "http://vault-2:8200"}
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		hosts = append(hosts, request.URL.Host)
+		code, body := http.StatusForbidden, `{"errors":["permission denied"]}`
+		switch request.URL.Path {
+		case "/v1/secret/sealed":
+			code, body = http.StatusServiceUnavailable, `{"errors":["Vault is sealed"]}`
+		case "/v1/secret/missing":
+			code, body = http.StatusNotFound, `{"errors":[]}`
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	// client errors are not tried on the next server
+	_, err = client.Get("/foo")
+	require.True(t, errors.Is(err, ErrPermissionDenied))
+	require.False(t, errors.Is(err, ErrSealed))
+	require.Equal(t, []string{"vault-1:8200"}, hosts)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	require.Equal(t, http.MethodGet, apiErr.Method)
+	require.Equal(t, "/v1/secret/foo", apiErr.Path)
+	require.Equal(t, []string{"permission denied"}, apiErr.Errors)
+	require.Contains(t, err.Error(), "permission denied")
+
+	// server errors are tried on every server
+	hosts = nil
+	err = client.Put("/sealed", "abc123")
+	require.True(t, errors.Is(err, ErrSealed))
+	require.Equal(t, []string{"vault-1:8200", "vault-2:8200"}, hosts)
+
+	// missing paths are not tried on the next server
+	hosts = nil
+	_, err = client.Get("/missing")
+	require.True(t, errors.Is(err, ErrNotFound))
+	require.True(t, errors.Is(err, ErrPathNotFound))
+	require.Equal(t, ErrPathNotFound, errors.Cause(err))
+	require.False(t, errors.Is(err, ErrPermissionDenied))
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	require.Equal(t, "/v1/secret/missing", apiErr.Path)
+	require.Equal(t, []string{"vault-1:8200"}, hosts)
+}
@@ -0,0 +1,195 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// AppRoleAuth provides a way to authenticate machines
+// and services with vault by using the AppRole auth
+// backend, and to manage the roles which define what
+// those machines and services are allowed to do.
+//
+// The approle backend must be enabled at the default
+// path of auth/approle for this API to work.
+//
+// More information about the AppRole auth backend
+// can be found here:
+// https://www.vaultproject.io/docs/auth/approle.html
+type AppRoleAuth interface {
+	LoginAppRole(roleID, secretID string) (CreatedToken, error)
+	CreateAppRole(opts AppRoleOptions) error
+	LookupAppRole(name string) (LookedUpAppRole, error)
+	ListAppRoles() ([]string, error)
+	DeleteAppRole(name string) error
+	LookupAppRoleID(name string) (string, error)
+	GenerateAppRoleSecretID(name string, opts SecretIDOptions) (GeneratedSecretID, error)
+}
+
+type appRoleLogin struct {
+	RoleID   string `json:"role_id"`
+	SecretID string `json:"secret_id,omitempty"`
+}
+
+func (c *client) LoginAppRole(roleID, secretID string) (CreatedToken, error) {
+	return c.login("approle", "/v1/auth/approle/login", appRoleLogin{
+		RoleID:   roleID,
+		SecretID: secretID,
+	})
+}
+
+// AppRoleOptions are used to define properties of an
+// AppRole being created or updated. Durations are
+// expressed as strings that vault understands, such
+// as "10m" or "24h". BindSecretID is left to the
+// default of vault, which is true, unless set with Bool.
+type AppRoleOptions struct {
+	Name            string   `json:"-"`
+	BindSecretID    *bool    `json:"bind_secret_id,omitempty"`
+	BoundCIDRs      []string `json:"bound_cidr_list,omitempty"`
+	Policies        []string `json:"policies,omitempty"`
+	SecretIDNumUses int      `json:"secret_id_num_uses,omitempty"`
+	SecretIDTTL     string   `json:"secret_id_ttl,omitempty"`
+	TokenNumUses    int      `json:"token_num_uses,omitempty"`
+	TokenTTL        string   `json:"token_ttl,omitempty"`
+	TokenMaxTTL     string   `json:"token_max_ttl,omitempty"`
+	Period          string   `json:"period,omitempty"`
+}
+
+func (c *client) CreateAppRole(opts AppRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling approle data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/approle/role/%s", opts.Name)
+	c.logRequest("approle-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating approle at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpAppRoleWrapper struct {
+	Data LookedUpAppRole `json:"data"`
+}
+
+// A LookedUpAppRole represents information returned from
+// vault after making a request for information about a
+// particular AppRole.
+type LookedUpAppRole struct {
+	BindSecretID    bool     `json:"bind_secret_id"`
+	BoundCIDRs      []string `json:"bound_cidr_list"`
+	Policies        []string `json:"policies"`
+	SecretIDNumUses int      `json:"secret_id_num_uses"`
+	SecretIDTTL     int      `json:"secret_id_ttl"`
+	TokenNumUses    int      `json:"token_num_uses"`
+	TokenTTL        int      `json:"token_ttl"`
+	TokenMaxTTL     int      `json:"token_max_ttl"`
+	Period          int      `json:"period"`
+}
+
+func (c *client) LookupAppRole(name string) (LookedUpAppRole, error) {
+	var wrapper lookedUpAppRoleWrapper
+	requestPath := fmt.Sprintf("/v1/auth/approle/role/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpAppRole{}, errors.Wrapf(err, "failed to look up approle %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListAppRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/auth/approle/role"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list approles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteAppRole(name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/approle/role/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete approle %q", name)
+	}
+	return nil
+}
+
+type appRoleIDWrapper struct {
+	Data struct {
+		RoleID string `json:"role_id"`
+	} `json:"data"`
+}
+
+func (c *client) LookupAppRoleID(name string) (string, error) {
+	var wrapper appRoleIDWrapper
+	requestPath := fmt.Sprintf("/v1/auth/approle/role/%s/role-id", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return "", errors.Wrapf(err, "failed to look up role id of approle %q", name)
+	}
+	return wrapper.Data.RoleID, nil
+}
+
+// SecretIDOptions are used to define properties of a
+// secret ID being generated for an AppRole. The Metadata
+// is attached to tokens issued by logging in with the
+// generated secret ID, and shows up in audit logs.
+type SecretIDOptions struct {
+	Metadata map[string]string
+	CIDRs    []string
+}
+
+type secretIDRequest struct {
+	Metadata string   `json:"metadata,omitempty"`
+	CIDRs    []string `json:"cidr_list,omitempty"`
+}
+
+type generatedSecretIDWrapper struct {
+	Data GeneratedSecretID `json:"data"`
+}
+
+// A GeneratedSecretID represents information returned from
+// vault after generating a new secret ID for an AppRole. The
+// ID attribute is the secret ID itself, which is used along
+// with the role ID to login.
+type GeneratedSecretID struct {
+	ID       string `json:"secret_id"`
+	Accessor string `json:"secret_id_accessor"`
+}
+
+func (c *client) GenerateAppRoleSecretID(name string, opts SecretIDOptions) (GeneratedSecretID, error) {
+	request := secretIDRequest{CIDRs: opts.CIDRs}
+
+	if len(opts.Metadata) > 0 {
+		// vault expects the metadata to be a JSON encoded string
+		meta, err := json.Marshal(opts.Metadata)
+		if err != nil {
+			return GeneratedSecretID{}, errors.Wrap(err, "marshalling secret id metadata")
+		}
+		request.Metadata = string(meta)
+	}
+
+	bs, err := json.Marshal(request)
+	if err != nil {
+		return GeneratedSecretID{}, err
+	}
+
+	var wrapper generatedSecretIDWrapper
+	requestPath := fmt.Sprintf("/v1/auth/approle/role/%s/secret-id", name)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return GeneratedSecretID{}, errors.Wrapf(err, "failed to generate secret id for approle %q", name)
+	}
+
+	if wrapper.Data.ID == "" {
+		return GeneratedSecretID{}, errors.Errorf("generate secret id returned empty id")
+	}
+
+	return wrapper.Data, nil
+}
@@ -0,0 +1,62 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AppRole(t *testing.T) {
+	client := getClient(t, rootTokener)
+	opts := AppRoleOptions{
+		Name:         "test-approle1",
+		BindSecretID: Bool(true),
+		BoundCIDRs:   []string{"127.0.0.0/8"},
+		Policies:     []string{"default"},
+		TokenTTL:     "10m",
+		TokenMaxTTL:  "30m",
+	}
+
+	// Create the approle
+	require.NoError(t, client.CreateAppRole(opts))
+	defer func() {
+		require.NoError(t, client.DeleteAppRole(opts.Name))
+		_, err := client.LookupAppRole(opts.Name)
+		require.Equal(t, ErrPathNotFound, errors.Cause(err))
+	}()
+
+	roles, err := client.ListAppRoles()
+	require.NoError(t, err)
+	require.Contains(t, roles, opts.Name)
+
+	role, err := client.LookupAppRole(opts.Name)
+	require.NoError(t, err)
+	require.True(t, role.BindSecretID)
+	require.Equal(t, []string{"127.0.0.0/8"}, role.BoundCIDRs)
+	require.Equal(t, 600, role.TokenTTL)
+	require.Equal(t, 1800, role.TokenMaxTTL)
+
+	roleID, err := client.LookupAppRoleID(opts.Name)
+	require.NoError(t, err)
+	require.NotEmpty(t, roleID)
+
+	secretID, err := client.GenerateAppRoleSecretID(opts.Name, SecretIDOptions{
+		Metadata: map[string]string{"owner": "test"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, secretID.Accessor)
+
+	// Login with the role id and secret id
+	token, err := client.LoginAppRole(roleID, secretID.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, token.ID)
+	require.Equal(t, "test", token.Metadata["owner"])
+	t.Log("approle login policies:", token.Policies)
+
+	// Login with a bad secret id must fail
+	_, err = client.LoginAppRole(roleID, "bad-secret-id")
+	require.Error(t, err)
+}
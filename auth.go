@@ -26,6 +26,7 @@ import (
 // https://www.vaultproject.io/docs/auth/token.html
 type Auth interface {
 	CreateToken(opts TokenOptions) (CreatedToken, error)
+	CreateOrphanToken(opts TokenOptions) (CreatedToken, error)
 	LookupToken(id string) (LookedUpToken, error)
 	LookupSelfToken() (LookedUpToken, error)
 	RenewToken(id string, increment time.Duration) (RenewedToken, error)
@@ -41,16 +42,59 @@ type Auth interface {
 // about the different options can be found in
 // the token documentation at:
 // https://www.vaultproject.io/docs/concepts/tokens.html
+//
+// Durations are sent to vault as duration strings,
+// such as "768h". The Metadata is attached to the token
+// and shows up in the audit logs. The Type may be either
+// "service" (the default) or "batch".
 type TokenOptions struct {
-	Policies        []string      `json:"policies,omitempty"`
-	NoDefaultPolicy bool          `json:"no_default_policy,omitempty"`
-	Orphan          bool          `json:"no_parent,omitempty"`
-	Renewable       bool          `json:"renewable,omitempty"`
-	DisplayName     string        `json:"display_name,omitempty"`
-	MaxUses         int           `json:"num_uses,omitempty"`
-	TTL             time.Duration `json:"ttl,omitempty"`
-	MaxTTL          time.Duration `json:"explicit_max_ttl,omitempty"`
-	Period          time.Duration `json:"period,omitmempty"`
+	ID              string            `json:"id,omitempty"`
+	Policies        []string          `json:"policies,omitempty"`
+	Metadata        map[string]string `json:"meta,omitempty"`
+	NoDefaultPolicy bool              `json:"no_default_policy,omitempty"`
+	Orphan          bool              `json:"no_parent,omitempty"`
+	Renewable       bool              `json:"renewable,omitempty"`
+	DisplayName     string            `json:"display_name,omitempty"`
+	EntityAlias     string            `json:"entity_alias,omitempty"`
+	Type            string            `json:"type,omitempty"`
+	MaxUses         int               `json:"num_uses,omitempty"`
+	TTL             time.Duration     `json:"-"`
+	MaxTTL          time.Duration     `json:"-"`
+	Period          time.Duration     `json:"-"`
+}
+
+// MarshalJSON encodes opts the way vault expects, with
+// durations encoded as duration strings.
+func (opts TokenOptions) MarshalJSON() ([]byte, error) {
+	// the alias type has no methods, avoiding recursion
+	type tokenOptions TokenOptions
+	return json.Marshal(struct {
+		tokenOptions
+		TTL    string `json:"ttl,omitempty"`
+		MaxTTL string `json:"explicit_max_ttl,omitempty"`
+		Period string `json:"period,omitempty"`
+	}{
+		tokenOptions: tokenOptions(opts),
+		TTL:          durationString(opts.TTL),
+		MaxTTL:       durationString(opts.MaxTTL),
+		Period:       durationString(opts.Period),
+	})
+}
+
+// durationString formats d as a duration string that vault
+// understands, using the largest whole unit (e.g. "768h").
+// Vault only has a granularity of seconds.
+func durationString(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return ""
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
 }
 
 type createdToken struct {
@@ -61,24 +105,39 @@ type createdToken struct {
 // vault after creating a token. The ID attribute is
 // the token itself; this is the value used to authenticate
 // with vault later on.
+//
+// If a login requires MFA which was not provided with the login,
+// the ID is empty and the MFARequirement describes the MFA methods
+// to be passed with ValidateMFA to obtain the token.
 type CreatedToken struct {
-	ID            string            `json:"client_token"`
-	Policies      []string          `json:"policies"`
-	Metadata      map[string]string `json:"metadata"`
-	LeaseDuration int               `json:"lease_duration"`
-	Renewable     bool              `json:"renewable"`
+	ID             string            `json:"client_token"`
+	Policies       []string          `json:"policies"`
+	Metadata       map[string]string `json:"metadata"`
+	LeaseDuration  int               `json:"lease_duration"`
+	Renewable      bool              `json:"renewable"`
+	MFARequirement *MFARequirement   `json:"mfa_requirement"`
 }
 
 func (c *client) CreateToken(opts TokenOptions) (CreatedToken, error) {
+	return c.createToken("/v1/auth/token/create", opts)
+}
+
+// CreateOrphanToken creates a token with no parent. Unlike setting
+// the Orphan option with CreateToken, which requires a root token,
+// this only requires sudo capability on auth/token/create-orphan.
+func (c *client) CreateOrphanToken(opts TokenOptions) (CreatedToken, error) {
+	return c.createToken("/v1/auth/token/create-orphan", opts)
+}
+
+func (c *client) createToken(requestPath string, opts TokenOptions) (CreatedToken, error) {
 	bs, err := json.Marshal(opts)
 	if err != nil {
 		return CreatedToken{}, err
 	}
-	tokenRequest := string(bs)
-	c.opts.Logger.Printf("token create request: %v", tokenRequest)
+	// do not log the request, which may contain the ID of the token
 
 	var ct createdToken
-	if err := c.post("/v1/auth/token/create", string(bs), &ct); err != nil {
+	if err := c.post(requestPath, string(bs), &ct); err != nil {
 		return CreatedToken{}, err
 	}
 
@@ -90,6 +149,28 @@ func (c *client) CreateToken(opts TokenOptions) (CreatedToken, error) {
 	return ct.Data, nil
 }
 
+// login is used by each of the auth backends to exchange
+// the credentials in body for a new token. The body is never
+// logged, as it will contain secret credentials.
+func (c *client) login(backend, requestPath string, body interface{}) (CreatedToken, error) {
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return CreatedToken{}, err
+	}
+
+	var ct createdToken
+	if err := c.post(requestPath, string(bs), &ct); err != nil {
+		return CreatedToken{}, errors.Wrapf(err, "failed to login with %s", backend)
+	}
+
+	// a login which requires mfa has no token until it is validated
+	if ct.Data.ID == "" && ct.Data.MFARequirement == nil {
+		return CreatedToken{}, errors.Errorf("%s login returned empty token id", backend)
+	}
+
+	return ct.Data, nil
+}
+
 // A LookedUpToken represents information returned from
 // vault after making a request for information about
 // a particular token.
@@ -219,9 +300,9 @@ func (c *client) CreateTokenRole(roleData TokenRoleOptions) error {
 	if err != nil {
 		return errors.Wrap(err, "marshalling role data to JSON request body")
 	}
-	c.opts.Logger.Printf("role-create request: %v", string(bs))
 
 	requestPath := fmt.Sprintf("/v1/auth/token/roles/%s", roleData.Name)
+	c.logRequest("role-create", requestPath, bs)
 	if err := c.post(requestPath, string(bs), nil); err != nil {
 		return errors.Wrapf(err, "creating role at %q", requestPath)
 	}
@@ -3,6 +3,7 @@
 package vaultapi
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -23,7 +24,7 @@ func Test_AuthToken(t *testing.T) {
 	}
 	token, err := client.CreateToken(opts)
 	require.NoError(t, err)
-	require.Equal(t, 36, len(token.ID))
+	require.NotEmpty(t, token.ID)
 	t.Log("created token:", token.ID)
 	t.Log("policies:", token.Policies)
 
@@ -36,6 +37,45 @@ func Test_AuthToken(t *testing.T) {
 	t.Log("self token lookup:", selfLookedUp)
 }
 
+func Test_TokenOptions_MarshalJSON(t *testing.T) {
+	opts := TokenOptions{
+		Policies:    []string{"p1"},
+		Metadata:    map[string]string{"owner": "ops"},
+		DisplayName: "test-token2",
+		Type:        "service",
+		TTL:         768 * time.Hour,
+		MaxTTL:      90 * time.Minute,
+		Period:      30 * time.Second,
+	}
+	bs, err := json.Marshal(opts)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"policies": ["p1"],
+		"meta": {"owner": "ops"},
+		"display_name": "test-token2",
+		"type": "service",
+		"ttl": "768h",
+		"explicit_max_ttl": "90m",
+		"period": "30s"
+	}`, string(bs))
+}
+
+func Test_AuthOrphanToken(t *testing.T) {
+	client := getClient(t, rootTokener)
+	opts := TokenOptions{
+		Policies:    []string{"default"},
+		DisplayName: "test-orphan1",
+		MaxTTL:      1 * time.Hour,
+	}
+	token, err := client.CreateOrphanToken(opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, token.ID)
+
+	lookedUp, err := client.LookupToken(token.ID)
+	require.NoError(t, err)
+	require.True(t, lookedUp.Orphan)
+}
+
 func Test_Renew_NonRenewable(t *testing.T) {
 	client := getClient(t, nonRenewableTokener)
 	token, err := nonRenewableTokener().Token()
@@ -147,3 +187,28 @@ func Test_TokenRole(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []string{"my_role1"}, roles)
 }
+
+func Test_client_login_mfaRequirement(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/auth/userpass/login/alice": `{"auth":{"client_token":"","mfa_requirement":{
+			"mfa_request_id": "r1",
+			"mfa_constraints": {"ops": {"any": [{"type": "totp", "id": "m1", "name": "totp", "uses_passcode": true}]}}
+		}}}`,
+		"POST /v1/auth/userpass/login/bob": `{"auth":{"client_token":""}}`,
+	})
+
+	// the token is only created once the mfa is validated
+	token, err := vault.LoginUserpass("alice", "password")
+	require.NoError(t, err)
+	require.Empty(t, token.ID)
+	require.Equal(t, &MFARequirement{
+		RequestID: "r1",
+		Constraints: map[string]MFAConstraint{
+			"ops": {Any: []MFAConstraintMethod{{Type: "totp", ID: "m1", Name: "totp", UsesPasscode: true}}},
+		},
+	}, token.MFARequirement)
+
+	// without a requirement, an empty token is an error
+	_, err = vault.LoginUserpass("bob", "password")
+	require.EqualError(t, err, "userpass login returned empty token id")
+}
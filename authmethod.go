@@ -0,0 +1,259 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shoenig/toolkit"
+)
+
+// An AuthMethod is able to login with vault by using one of the
+// auth backends, producing a Secret whose Auth is the token that can
+// be used to authenticate further requests. The requests of a login
+// should be made by a Client given ctx with WithContext, which sends
+// them without a token when the login is made by SetAuth.
+type AuthMethod interface {
+	Login(ctx context.Context) (Secret, error)
+}
+
+// AuthMethodFunc is an adapter to allow the use of an ordinary
+// function as an AuthMethod.
+type AuthMethodFunc func(ctx context.Context) (Secret, error)
+
+// Login calls f(ctx).
+func (f AuthMethodFunc) Login(ctx context.Context) (Secret, error) {
+	return f(ctx)
+}
+
+// loginMethod adapts the login function of an auth backend
+// into an AuthMethod.
+func loginMethod(client Client, login func(client Client) (CreatedToken, error)) AuthMethod {
+	return AuthMethodFunc(func(ctx context.Context) (Secret, error) {
+		created, err := login(client.WithContext(ctx))
+		if err != nil {
+			return Secret{}, err
+		}
+		return Secret{Auth: &created}, nil
+	})
+}
+
+// NewAppRoleLogin creates an AuthMethod that will login
+// with the approle auth backend.
+func NewAppRoleLogin(client Client, roleID, secretID string) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		return client.LoginAppRole(roleID, secretID)
+	})
+}
+
+// NewKubernetesLogin creates an AuthMethod that will login with
+// the kubernetes auth backend. If jwt is empty, the JWT of the
+// service account of the pod is re-read upon each login.
+func NewKubernetesLogin(client Client, role, jwt string) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		return client.LoginKubernetes(role, jwt)
+	})
+}
+
+// NewAWSLogin creates an AuthMethod that will login with the
+// aws auth backend by using the iam method.
+func NewAWSLogin(client Client, opts AWSLoginOptions) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		return client.LoginAWS(opts)
+	})
+}
+
+// NewLDAPLogin creates an AuthMethod that will login with
+// the ldap auth backend.
+func NewLDAPLogin(client Client, username, password string) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		return client.LoginLDAP(username, password)
+	})
+}
+
+// NewUserpassLogin creates an AuthMethod that will login with
+// the userpass auth backend.
+func NewUserpassLogin(client Client, username, password string) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		return client.LoginUserpass(username, password)
+	})
+}
+
+// NewGitHubLogin creates an AuthMethod that will login with
+// the github auth backend.
+func NewGitHubLogin(client Client, token string) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		return client.LoginGitHub(token)
+	})
+}
+
+// NewJWTLogin creates an AuthMethod that will login with
+// the jwt auth backend.
+func NewJWTLogin(client Client, role, jwt string) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		return client.LoginJWT(role, jwt)
+	})
+}
+
+// NewAzureLogin creates an AuthMethod that will login with the
+// azure auth backend. If opts.JWT is empty, the MSI access token
+// is re-read from the instance metadata service upon each login.
+func NewAzureLogin(client Client, role string, opts AzureLoginOptions) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		return client.LoginAzure(role, opts)
+	})
+}
+
+// NewKerberosLogin creates an AuthMethod that will login with the
+// kerberos auth backend. SPNEGO tokens are only valid once, so the
+// negotiate function is called to produce a new one upon each login,
+// e.g. with kerberos.SPNEGOToken.
+func NewKerberosLogin(client Client, negotiate func() ([]byte, error)) AuthMethod {
+	return loginMethod(client, func(client Client) (CreatedToken, error) {
+		spnegoToken, err := negotiate()
+		if err != nil {
+			return CreatedToken{}, err
+		}
+		return client.LoginKerberos(spnegoToken)
+	})
+}
+
+// reloginFraction is the fraction of the lease duration of a
+// token after which a new token is obtained by logging in again
+const reloginFraction = 0.8
+
+// anonymousKey marks the context of the login made by SetAuth, whose
+// requests are sent without a token, so that obtaining the token does
+// not recursively depend on itself.
+type anonymousKey struct{}
+
+// anonymous returns whether the requests of c are sent without a token.
+func (c *client) anonymous() bool {
+	return c.ctx != nil && c.ctx.Value(anonymousKey{}) != nil
+}
+
+type authToken struct {
+	method AuthMethod
+	now    func() time.Time
+
+	lock    sync.Mutex
+	token   string
+	expires time.Time
+}
+
+var _ Tokener = (*authToken)(nil)
+
+func newAuthToken(method AuthMethod) *authToken {
+	return &authToken{
+		method: method,
+		now:    time.Now,
+	}
+}
+
+// Token returns the token obtained by the most recent login, or
+// logs in again if there is no token yet or the lease of the token
+// is nearly expired. A token without a lease never expires.
+func (t *authToken) Token() (string, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	if t.token != "" && (t.expires.IsZero() || now.Before(t.expires)) {
+		return t.token, nil
+	}
+
+	ctx := context.WithValue(context.Background(), anonymousKey{}, true)
+	secret, err := t.method.Login(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if secret.Auth == nil || secret.Auth.ID == "" {
+		return "", errors.New("login returned empty token id")
+	}
+
+	t.token = secret.Auth.ID
+	t.expires = time.Time{}
+	if secret.Auth.LeaseDuration > 0 {
+		lease := time.Duration(secret.Auth.LeaseDuration) * time.Second
+		t.expires = now.Add(time.Duration(float64(lease) * reloginFraction))
+	}
+
+	return t.token, nil
+}
+
+// invalidate discards token, if it is still the current token, so
+// that the next use of the authToken logs in again.
+func (t *authToken) invalidate(token string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+// SetAuth configures the Client to obtain its own token by logging
+// in with method, replacing the Tokener the Client was created with.
+// The login happens upon the next request, again whenever the lease
+// of the token is nearly expired, and again whenever a request is
+// denied because the token is no longer valid, e.g. once revoked.
+func (c *client) SetAuth(method AuthMethod) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.tokener = newAuthToken(method)
+}
+
+// relogin returns whether request, which was denied with response,
+// should be made again with a new token, because the token obtained by
+// SetAuth is no longer valid. If so, the response is drained, and the
+// request is prepared with the new token.
+func (c *client) relogin(request *http.Request, response *http.Response) bool {
+	if response.StatusCode != http.StatusForbidden || c.anonymous() {
+		return false
+	}
+
+	c.lock.RLock()
+	auth, ok := c.tokener.(*authToken)
+	c.lock.RUnlock()
+	if !ok {
+		return false
+	}
+
+	// the body of the request must be read again
+	if request.Body != nil && request.GetBody == nil {
+		return false
+	}
+
+	// a valid token may be denied by its policies, in which case
+	// logging in again would not help
+	used := request.Header.Get(headerVaultToken)
+	checker := c.derive()
+	checker.tokener = NewStaticToken(used)
+	if _, err := checker.LookupSelfToken(); !errors.Is(err, ErrPermissionDenied) {
+		return false
+	}
+
+	auth.invalidate(used)
+	token, err := c.token()
+	if err != nil {
+		c.opts.Logger.Warnf("failed to login again: %v", err)
+		return false
+	}
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return false
+		}
+		request.Body = body
+	}
+
+	toolkit.Drain(response.Body)
+	request.Header.Set(headerVaultToken, token)
+	return true
+}
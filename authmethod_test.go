@@ -0,0 +1,144 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_authToken(t *testing.T) {
+	logins := 0
+	method := AuthMethodFunc(func(context.Context) (Secret, error) {
+		logins++
+		return Secret{Auth: &CreatedToken{
+			ID:            []string{"t0", "t1", "t2"}[logins-1],
+			LeaseDuration: 100,
+		}}, nil
+	})
+
+	start := time.Date(2018, 5, 25, 0, 0, 0, 0, time.UTC)
+	now := start
+	tokener := newAuthToken(method)
+	tokener.now = func() time.Time { return now }
+
+	// first use logs in
+	token, err := tokener.Token()
+	require.NoError(t, err)
+	require.Equal(t, "t0", token)
+	require.Equal(t, 1, logins)
+
+	// token is reused while the lease is fresh
+	now = start.Add(79 * time.Second)
+	token, err = tokener.Token()
+	require.NoError(t, err)
+	require.Equal(t, "t0", token)
+	require.Equal(t, 1, logins)
+
+	// nearly expired lease causes another login
+	now = start.Add(80 * time.Second)
+	token, err = tokener.Token()
+	require.NoError(t, err)
+	require.Equal(t, "t1", token)
+	require.Equal(t, 2, logins)
+}
+
+func Test_authToken_noLease(t *testing.T) {
+	logins := 0
+	method := AuthMethodFunc(func(context.Context) (Secret, error) {
+		logins++
+		return Secret{Auth: &CreatedToken{ID: "root"}}, nil
+	})
+
+	tokener := newAuthToken(method)
+	for i := 0; i < 3; i++ {
+		token, err := tokener.Token()
+		require.NoError(t, err)
+		require.Equal(t, "root", token)
+	}
+	require.Equal(t, 1, logins)
+}
+
+func Test_authToken_error(t *testing.T) {
+	method := AuthMethodFunc(func(context.Context) (Secret, error) {
+		return Secret{}, errors.New("permission denied")
+	})
+
+	tokener := newAuthToken(method)
+	_, err := tokener.Token()
+	require.Error(t, err)
+
+	// a login without a token is an error too
+	method = AuthMethodFunc(func(context.Context) (Secret, error) {
+		return Secret{}, nil
+	})
+
+	tokener = newAuthToken(method)
+	_, err = tokener.Token()
+	require.Error(t, err)
+}
+
+func Test_client_SetAuth_relogin(t *testing.T) {
+	var requests []string
+	logins := 0
+	revoked := map[string]bool{}
+
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		token := request.Header.Get(headerVaultToken)
+		requests = append(requests, fmt.Sprintf("%s %s %q", request.Method, request.URL.Path, token))
+
+		code, body := http.StatusOK, ""
+		switch {
+		case request.URL.Path == "/v1/auth/approle/login":
+			logins++
+			body = fmt.Sprintf(`{"auth":{"client_token":"t%d","lease_duration":3600}}`, logins)
+		case revoked[token]:
+			code, body = http.StatusForbidden, `{"errors":["permission denied"]}`
+		case request.URL.Path == "/v1/secret/denied":
+			code, body = http.StatusForbidden, `{"errors":["permission denied"]}`
+		default:
+			body = `{"data":{"value":"abc123"}}`
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken(""))
+	require.NoError(t, err)
+	client.SetAuth(NewAppRoleLogin(client, "role", "secret"))
+
+	value, err := client.Get("/foo")
+	require.NoError(t, err)
+	require.Equal(t, "abc123", value)
+
+	// a revoked token is replaced by logging in again
+	revoked["t1"] = true
+	err = client.Put("/foo", "abc123")
+	require.NoError(t, err)
+
+	// a valid token denied by its policies is kept
+	_, err = client.Get("/denied")
+	require.True(t, errors.Is(err, ErrPermissionDenied))
+
+	require.Equal(t, []string{
+		`POST /v1/auth/approle/login ""`,
+		`GET /v1/secret/foo "t1"`,
+		`POST /v1/secret/foo "t1"`,
+		`GET /v1/auth/token/lookup-self "t1"`,
+		`POST /v1/auth/approle/login ""`,
+		`POST /v1/secret/foo "t2"`,
+		`GET /v1/secret/denied "t2"`,
+		`GET /v1/auth/token/lookup-self "t2"`,
+	}, requests)
+}
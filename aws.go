@@ -0,0 +1,261 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	stsEndpoint    = "https://sts.amazonaws.com/"
+	stsRegion      = "us-east-1"
+	stsRequestBody = "Action=GetCallerIdentity&Version=2011-06-15"
+
+	headerAWSIAMServerID = "X-Vault-AWS-IAM-Server-ID"
+)
+
+// AWSAuth provides a way for EC2 instances, ECS tasks, lambdas
+// and anything else with AWS IAM credentials to authenticate with
+// vault by using the iam method of the aws auth backend, and to
+// manage the configuration and roles of that backend.
+//
+// The aws backend must be enabled at the default path of
+// auth/aws for this API to work.
+//
+// More information about the aws auth backend can be found here:
+// https://www.vaultproject.io/docs/auth/aws.html
+type AWSAuth interface {
+	LoginAWS(opts AWSLoginOptions) (CreatedToken, error)
+	ConfigureAWSClient(config AWSClientConfig) error
+	LookupAWSClientConfig() (AWSClientConfig, error)
+	DeleteAWSClientConfig() error
+	CreateAWSRole(opts AWSRoleOptions) error
+	LookupAWSRole(name string) (LookedUpAWSRole, error)
+	ListAWSRoles() ([]string, error)
+	DeleteAWSRole(name string) error
+}
+
+// AWSCredentials are the IAM credentials used to sign the
+// sts:GetCallerIdentity request which vault uses to verify
+// the identity of the caller. The credentials themselves are
+// never sent to vault.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AWSCredentialsFromEnv creates AWSCredentials from the standard
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN
+// environment variables.
+func AWSCredentialsFromEnv() (AWSCredentials, error) {
+	creds := AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return AWSCredentials{}, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+	return creds, nil
+}
+
+// AWSLoginOptions are used to login with the iam method of the
+// aws auth backend.
+type AWSLoginOptions struct {
+	// Role is the name of the aws role to login against.
+	Role string
+
+	// Credentials are used to sign the sts:GetCallerIdentity
+	// request that is sent to vault. If Credentials are empty,
+	// they are looked up with AWSCredentialsFromDefaults.
+	Credentials AWSCredentials
+
+	// ServerID must be set if the aws backend is configured
+	// with an iam_server_id_header_value, and must match it.
+	ServerID string
+}
+
+type awsLogin struct {
+	Role    string `json:"role"`
+	Method  string `json:"iam_http_request_method"`
+	URL     string `json:"iam_request_url"`
+	Body    string `json:"iam_request_body"`
+	Headers string `json:"iam_request_headers"`
+}
+
+// signedAWSLogin constructs the signed sts:GetCallerIdentity request
+// that vault forwards on to AWS to verify the caller identity.
+func signedAWSLogin(opts AWSLoginOptions, now time.Time) (awsLogin, error) {
+	body := []byte(stsRequestBody)
+	request, err := http.NewRequest(http.MethodPost, stsEndpoint, strings.NewReader(stsRequestBody))
+	if err != nil {
+		return awsLogin{}, errors.Wrap(err, "failed to build sts request")
+	}
+	request.Header.Set(headerContentType, "application/x-www-form-urlencoded; charset=utf-8")
+	if opts.ServerID != "" {
+		request.Header.Set(headerAWSIAMServerID, opts.ServerID)
+	}
+
+	signV4(request, body, opts.Credentials, stsRegion, "sts", now)
+
+	headers, err := json.Marshal(request.Header)
+	if err != nil {
+		return awsLogin{}, errors.Wrap(err, "failed to marshal sts request headers")
+	}
+
+	return awsLogin{
+		Role:    opts.Role,
+		Method:  request.Method,
+		URL:     base64.StdEncoding.EncodeToString([]byte(stsEndpoint)),
+		Body:    base64.StdEncoding.EncodeToString(body),
+		Headers: base64.StdEncoding.EncodeToString(headers),
+	}, nil
+}
+
+func (c *client) LoginAWS(opts AWSLoginOptions) (CreatedToken, error) {
+	if opts.Credentials == (AWSCredentials{}) {
+		creds, err := AWSCredentialsFromDefaults()
+		if err != nil {
+			return CreatedToken{}, err
+		}
+		opts.Credentials = creds
+	}
+
+	request, err := signedAWSLogin(opts, time.Now())
+	if err != nil {
+		return CreatedToken{}, err
+	}
+
+	return c.login("aws", "/v1/auth/aws/login", request)
+}
+
+// AWSClientConfig is the configuration of the credentials and
+// endpoints that the aws auth backend uses to talk to AWS.
+type AWSClientConfig struct {
+	AccessKey        string `json:"access_key,omitempty"`
+	SecretKey        string `json:"secret_key,omitempty"`
+	Endpoint         string `json:"endpoint,omitempty"`
+	IAMEndpoint      string `json:"iam_endpoint,omitempty"`
+	STSEndpoint      string `json:"sts_endpoint,omitempty"`
+	IAMServerIDValue string `json:"iam_server_id_header_value,omitempty"`
+}
+
+func (c *client) ConfigureAWSClient(config AWSClientConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling aws client config to JSON request body")
+	}
+
+	if err := c.post("/v1/auth/aws/config/client", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure aws client")
+	}
+
+	return nil
+}
+
+type awsClientConfigWrapper struct {
+	Data AWSClientConfig `json:"data"`
+}
+
+func (c *client) LookupAWSClientConfig() (AWSClientConfig, error) {
+	var wrapper awsClientConfigWrapper
+	if err := c.get("/v1/auth/aws/config/client", &wrapper); err != nil {
+		return AWSClientConfig{}, errors.Wrap(err, "failed to read aws client config")
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) DeleteAWSClientConfig() error {
+	if err := c.delete("/v1/auth/aws/config/client"); err != nil {
+		return errors.Wrap(err, "failed to delete aws client config")
+	}
+	return nil
+}
+
+// AWSRoleOptions are used to define properties of an aws role
+// being created or updated. Durations are expressed as strings
+// that vault understands, such as "1h". ResolveAWSUniqueIDs is
+// left to the default of vault, which is true, unless set with Bool.
+type AWSRoleOptions struct {
+	Name                  string   `json:"-"`
+	AuthType              string   `json:"auth_type,omitempty"`
+	BoundIAMPrincipalARNs []string `json:"bound_iam_principal_arn,omitempty"`
+	ResolveAWSUniqueIDs   *bool    `json:"resolve_aws_unique_ids,omitempty"`
+	Policies              []string `json:"policies,omitempty"`
+	TTL                   string   `json:"ttl,omitempty"`
+	MaxTTL                string   `json:"max_ttl,omitempty"`
+	Period                string   `json:"period,omitempty"`
+}
+
+func (c *client) CreateAWSRole(opts AWSRoleOptions) error {
+	if opts.AuthType == "" {
+		opts.AuthType = "iam"
+	}
+
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling aws role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/aws/role/%s", opts.Name)
+	c.logRequest("aws-role-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating aws role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpAWSRoleWrapper struct {
+	Data LookedUpAWSRole `json:"data"`
+}
+
+// A LookedUpAWSRole represents information returned from vault
+// after making a request for information about a particular
+// aws role.
+type LookedUpAWSRole struct {
+	AuthType              string   `json:"auth_type"`
+	BoundIAMPrincipalARNs []string `json:"bound_iam_principal_arn"`
+	ResolveAWSUniqueIDs   bool     `json:"resolve_aws_unique_ids"`
+	Policies              []string `json:"policies"`
+	TTL                   int      `json:"ttl"`
+	MaxTTL                int      `json:"max_ttl"`
+	Period                int      `json:"period"`
+}
+
+func (c *client) LookupAWSRole(name string) (LookedUpAWSRole, error) {
+	var wrapper lookedUpAWSRoleWrapper
+	requestPath := fmt.Sprintf("/v1/auth/aws/role/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpAWSRole{}, errors.Wrapf(err, "failed to look up aws role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListAWSRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/auth/aws/roles"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list aws roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteAWSRole(name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/aws/role/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete aws role %q", name)
+	}
+	return nil
+}
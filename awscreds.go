@@ -0,0 +1,216 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shoenig/toolkit"
+)
+
+const (
+	ecsCredentialsEndpoint = "http://169.254.170.2"
+	ec2MetadataEndpoint    = "http://169.254.169.254"
+
+	headerEC2MetadataToken    = "X-aws-ec2-metadata-token"
+	headerEC2MetadataTokenTTL = "X-aws-ec2-metadata-token-ttl-seconds"
+
+	ec2MetadataTokenTTL = "21600"
+)
+
+// AWSCredentialsFromDefaults looks up AWSCredentials in the same order
+// as the AWS SDKs, from the environment, then from the ECS container
+// credentials service, and then from the instance profile of the EC2
+// instance, so that a client running on ECS or EC2 can login without
+// distributing static credentials.
+func AWSCredentialsFromDefaults() (AWSCredentials, error) {
+	if creds, err := AWSCredentialsFromEnv(); err == nil {
+		return creds, nil
+	}
+
+	if containerCredentialsURI() != "" {
+		return AWSCredentialsFromContainer()
+	}
+
+	creds, err := AWSCredentialsFromInstance()
+	if err != nil {
+		return AWSCredentials{}, errors.Wrap(err, "no aws credentials in environment, container, or instance")
+	}
+	return creds, nil
+}
+
+// containerCredentialsURI returns the URI of the credentials of the
+// ECS task, as set by the ECS agent, or the empty string if there are
+// no such credentials.
+func containerCredentialsURI() string {
+	if uri := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); uri != "" {
+		return ecsCredentialsEndpoint + uri
+	}
+	return os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+}
+
+// containerAuthorization returns the value of the Authorization header
+// of requests for the credentials of the ECS task, if any.
+func containerAuthorization() (string, error) {
+	if file := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE"); file != "" {
+		bs, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read container authorization token")
+		}
+		return strings.TrimSpace(string(bs)), nil
+	}
+	return os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN"), nil
+}
+
+// awsMetadataCredentials are credentials as provided by both the ECS
+// container credentials and EC2 instance metadata services.
+type awsMetadataCredentials struct {
+	Code            string `json:"Code"`
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+}
+
+func (m awsMetadataCredentials) credentials() (AWSCredentials, error) {
+	if m.Code != "" && m.Code != "Success" {
+		return AWSCredentials{}, errors.Errorf("credentials are not available: %s", m.Code)
+	}
+
+	if m.AccessKeyID == "" || m.SecretAccessKey == "" {
+		return AWSCredentials{}, errors.New("credentials are missing an access key")
+	}
+
+	return AWSCredentials{
+		AccessKeyID:     m.AccessKeyID,
+		SecretAccessKey: m.SecretAccessKey,
+		SessionToken:    m.Token,
+	}, nil
+}
+
+// AWSCredentialsFromContainer retrieves the AWSCredentials of the IAM
+// role of an ECS task from the ECS container credentials service, as
+// described by the AWS_CONTAINER_CREDENTIALS_RELATIVE_URI or
+// AWS_CONTAINER_CREDENTIALS_FULL_URI environment variables.
+func AWSCredentialsFromContainer() (AWSCredentials, error) {
+	url := containerCredentialsURI()
+	if url == "" {
+		return AWSCredentials{}, errors.New("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI or AWS_CONTAINER_CREDENTIALS_FULL_URI must be set")
+	}
+
+	authorization, err := containerAuthorization()
+	if err != nil {
+		return AWSCredentials{}, err
+	}
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return AWSCredentials{}, errors.Wrapf(err, "failed to build container credentials request to %q", url)
+	}
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	var creds awsMetadataCredentials
+	if err := awsMetadataDo(request, &creds); err != nil {
+		return AWSCredentials{}, errors.Wrap(err, "failed to get aws container credentials")
+	}
+	return creds.credentials()
+}
+
+// AWSCredentialsFromInstance retrieves the AWSCredentials of the
+// instance profile of an EC2 instance from the instance metadata
+// service, using a session token as required by IMDSv2.
+func AWSCredentialsFromInstance() (AWSCredentials, error) {
+	token, err := ec2MetadataToken()
+	if err != nil {
+		return AWSCredentials{}, errors.Wrap(err, "failed to get aws instance metadata token")
+	}
+
+	rolesURL := ec2MetadataEndpoint + "/latest/meta-data/iam/security-credentials/"
+	roles, err := ec2MetadataGet(rolesURL, token)
+	if err != nil {
+		return AWSCredentials{}, errors.Wrap(err, "failed to get aws instance profile")
+	}
+
+	// an instance has at most one instance profile, with one role
+	role := strings.TrimSpace(strings.SplitN(string(roles), "\n", 2)[0])
+	if role == "" {
+		return AWSCredentials{}, errors.New("no instance profile is associated with the instance")
+	}
+
+	bs, err := ec2MetadataGet(rolesURL+role, token)
+	if err != nil {
+		return AWSCredentials{}, errors.Wrapf(err, "failed to get aws instance credentials of role %q", role)
+	}
+
+	var creds awsMetadataCredentials
+	if err := json.Unmarshal(bs, &creds); err != nil {
+		return AWSCredentials{}, errors.Wrapf(err, "failed to read aws instance credentials of role %q", role)
+	}
+	return creds.credentials()
+}
+
+func ec2MetadataToken() (string, error) {
+	url := ec2MetadataEndpoint + "/latest/api/token"
+	request, err := http.NewRequest(http.MethodPut, url, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build aws metadata request to %q", url)
+	}
+	request.Header.Set(headerEC2MetadataTokenTTL, ec2MetadataTokenTTL)
+
+	var token []byte
+	if err := awsMetadataDo(request, &token); err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
+
+func ec2MetadataGet(url, token string) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build aws metadata request to %q", url)
+	}
+	request.Header.Set(headerEC2MetadataToken, token)
+
+	var bs []byte
+	if err := awsMetadataDo(request, &bs); err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// awsMetadataDo executes request with the imdsClient, reading the body
+// of the response into i, which is either a *[]byte for the raw body,
+// or anything else for the decoded JSON body.
+func awsMetadataDo(request *http.Request, i interface{}) error {
+	url := request.URL.String()
+
+	response, err := imdsClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to execute aws metadata request to %q", url)
+	}
+	defer toolkit.Drain(response.Body)
+
+	if response.StatusCode >= 400 {
+		return errors.Errorf("bad status code: %d, url: %s", response.StatusCode, url)
+	}
+
+	if raw, ok := i.(*[]byte); ok {
+		bs, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read response from %q", url)
+		}
+		*raw = bs
+		return nil
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(i); err != nil {
+		return errors.Wrapf(err, "failed to read response from %q", url)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const metadataCredentials = `{
+	"Code": "Success",
+	"AccessKeyId": "ASIAEXAMPLE",
+	"SecretAccessKey": "secret",
+	"Token": "session"
+}`
+
+// fakeIMDS replaces the imdsClient with one which answers requests
+// with f, until the returned function is called.
+func fakeIMDS(f roundTripperFunc) func() {
+	original := imdsClient
+	imdsClient = &http.Client{Transport: f}
+	return func() { imdsClient = original }
+}
+
+func imdsResponse(code int, body string) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: code,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func Test_AWSCredentialsFromContainer(t *testing.T) {
+	os.Setenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "/v2/credentials/abc")
+	os.Setenv("AWS_CONTAINER_AUTHORIZATION_TOKEN", "auth")
+	defer os.Unsetenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI")
+	defer os.Unsetenv("AWS_CONTAINER_AUTHORIZATION_TOKEN")
+
+	defer fakeIMDS(func(request *http.Request) (*http.Response, error) {
+		require.Equal(t, "http://169.254.170.2/v2/credentials/abc", request.URL.String())
+		require.Equal(t, "auth", request.Header.Get("Authorization"))
+		return imdsResponse(http.StatusOK, metadataCredentials)
+	})()
+
+	creds, err := AWSCredentialsFromContainer()
+	require.NoError(t, err)
+	require.Equal(t, AWSCredentials{
+		AccessKeyID:     "ASIAEXAMPLE",
+		SecretAccessKey: "secret",
+		SessionToken:    "session",
+	}, creds)
+}
+
+func Test_AWSCredentialsFromInstance(t *testing.T) {
+	var requests []string
+
+	defer fakeIMDS(func(request *http.Request) (*http.Response, error) {
+		requests = append(requests, request.Method+" "+request.URL.Path)
+
+		if request.Method == http.MethodPut {
+			require.Equal(t, ec2MetadataTokenTTL, request.Header.Get(headerEC2MetadataTokenTTL))
+			return imdsResponse(http.StatusOK, "token")
+		}
+
+		// IMDSv2 rejects requests without the session token
+		if request.Header.Get(headerEC2MetadataToken) != "token" {
+			return imdsResponse(http.StatusUnauthorized, "")
+		}
+
+		switch request.URL.Path {
+		case "/latest/meta-data/iam/security-credentials/":
+			return imdsResponse(http.StatusOK, "web-role\n")
+		case "/latest/meta-data/iam/security-credentials/web-role":
+			return imdsResponse(http.StatusOK, metadataCredentials)
+		}
+		return imdsResponse(http.StatusNotFound, "")
+	})()
+
+	creds, err := AWSCredentialsFromInstance()
+	require.NoError(t, err)
+	require.Equal(t, "ASIAEXAMPLE", creds.AccessKeyID)
+	require.Equal(t, "session", creds.SessionToken)
+
+	require.Equal(t, []string{
+		"PUT /latest/api/token",
+		"GET /latest/meta-data/iam/security-credentials/",
+		"GET /latest/meta-data/iam/security-credentials/web-role",
+	}, requests)
+}
+
+func Test_AWSCredentialsFromInstance_noProfile(t *testing.T) {
+	defer fakeIMDS(func(request *http.Request) (*http.Response, error) {
+		if request.Method == http.MethodPut {
+			return imdsResponse(http.StatusOK, "token")
+		}
+		return imdsResponse(http.StatusNotFound, "")
+	})()
+
+	_, err := AWSCredentialsFromInstance()
+	require.Error(t, err)
+}
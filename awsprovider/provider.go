@@ -0,0 +1,83 @@
+// Author hoenig
+
+// Package awsprovider adapts the aws secrets engine of vault to the
+// credentials provider of the AWS SDK for Go v2, e.g.
+//
+//	provider := awsprovider.New(client.AWSSecretsMount("aws"), "deploy", awsprovider.Options{STS: true})
+//	cfg, err := config.LoadDefaultConfig(ctx, config.WithCredentialsProvider(provider))
+//
+// The SDK caches the credentials, and calls the provider again for
+// new credentials shortly before they expire.
+package awsprovider
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/shoenig/vaultapi"
+)
+
+// source is reported as the Source of the retrieved credentials
+const source = "VaultAWSSecretsProvider"
+
+// Options are used to configure a Provider.
+type Options struct {
+	// STS configures whether credentials are generated through the sts
+	// endpoint of the role, rather than the creds endpoint. Credentials
+	// from the creds endpoint of an "iam_user" role are not usable
+	// for several seconds after being created.
+	STS bool
+
+	// Credentials are optional parameters of generating credentials.
+	Credentials vaultapi.AWSCredentialsOptions
+}
+
+// A Provider is an aws.CredentialsProvider which generates AWS
+// credentials with a role of an aws secrets engine.
+type Provider struct {
+	secrets vaultapi.AWSSecrets
+	role    string
+	opts    Options
+	now     func() time.Time
+}
+
+var _ aws.CredentialsProvider = (*Provider)(nil)
+
+// New creates a Provider which generates credentials with role.
+func New(secrets vaultapi.AWSSecrets, role string, opts Options) *Provider {
+	return &Provider{
+		secrets: secrets,
+		role:    role,
+		opts:    opts,
+		now:     time.Now,
+	}
+}
+
+// Retrieve generates new credentials, which expire along with
+// their lease in vault.
+func (p *Provider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	generate := p.secrets.Credentials
+	if p.opts.STS {
+		generate = p.secrets.STSCredentials
+	}
+
+	creds, err := generate(p.role, p.opts.Credentials)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	retrieved := aws.Credentials{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		Source:          source,
+	}
+
+	if creds.LeaseDuration > 0 {
+		retrieved.CanExpire = true
+		retrieved.Expires = p.now().Add(time.Duration(creds.LeaseDuration) * time.Second)
+	}
+
+	return retrieved, nil
+}
@@ -0,0 +1,71 @@
+// Author hoenig
+
+package awsprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shoenig/vaultapi"
+	"github.com/stretchr/testify/require"
+)
+
+// generator is an AWSSecrets which only implements Credentials
+// and STSCredentials
+type generator struct {
+	vaultapi.AWSSecrets
+	err error
+}
+
+func (g *generator) Credentials(role string, opts vaultapi.AWSCredentialsOptions) (vaultapi.AWSLeasedCredentials, error) {
+	return vaultapi.AWSLeasedCredentials{
+		AWSCredentials: vaultapi.AWSCredentials{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+		},
+		LeaseDuration: 3600,
+	}, g.err
+}
+
+func (g *generator) STSCredentials(role string, opts vaultapi.AWSCredentialsOptions) (vaultapi.AWSLeasedCredentials, error) {
+	return vaultapi.AWSLeasedCredentials{
+		AWSCredentials: vaultapi.AWSCredentials{
+			AccessKeyID:     "ASIAEXAMPLE",
+			SecretAccessKey: "secret",
+			SessionToken:    "token",
+		},
+		LeaseDuration: 900,
+	}, g.err
+}
+
+func Test_Provider_Retrieve(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	provider := New(&generator{}, "deploy", Options{})
+	provider.now = func() time.Time { return now }
+
+	creds, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "AKIDEXAMPLE", creds.AccessKeyID)
+	require.Empty(t, creds.SessionToken)
+	require.True(t, creds.CanExpire)
+	require.Equal(t, now.Add(time.Hour), creds.Expires)
+
+	provider = New(&generator{}, "deploy", Options{STS: true})
+	provider.now = func() time.Time { return now }
+
+	creds, err = provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "ASIAEXAMPLE", creds.AccessKeyID)
+	require.Equal(t, "token", creds.SessionToken)
+	require.Equal(t, now.Add(15*time.Minute), creds.Expires)
+}
+
+func Test_Provider_Retrieve_error(t *testing.T) {
+	provider := New(&generator{err: errors.New("permission denied")}, "deploy", Options{})
+
+	_, err := provider.Retrieve(context.Background())
+	require.Error(t, err)
+}
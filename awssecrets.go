@@ -0,0 +1,228 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// An AWSSecrets represents an aws secrets engine, which generates
+// short lived AWS credentials. This is unrelated to the AWSAuth
+// method, which logs in to vault with AWS credentials.
+//
+// More information about the aws secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/aws/index.html
+type AWSSecrets interface {
+	// Configuration
+	ConfigureRoot(config AWSRootConfig) error
+	LookupRootConfig() (AWSRootConfig, error)
+
+	// Roles
+	CreateRole(opts AWSSecretsRoleOptions) error
+	LookupRole(name string) (LookedUpAWSSecretsRole, error)
+	ListRoles() ([]string, error)
+	DeleteRole(name string) error
+
+	// Credentials
+	Credentials(role string, opts AWSCredentialsOptions) (AWSLeasedCredentials, error)
+	STSCredentials(role string, opts AWSCredentialsOptions) (AWSLeasedCredentials, error)
+}
+
+func (c *client) AWSSecretsMount(mount string) AWSSecrets {
+	return &awsSecrets{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type awsSecrets struct {
+	client *client
+	mount  string
+}
+
+// AWSRootConfig is the configuration of the IAM credentials used by
+// vault to manage AWS credentials. The SecretKey is never returned
+// when looking up the configuration.
+type AWSRootConfig struct {
+	AccessKey   string `json:"access_key,omitempty"`
+	SecretKey   string `json:"secret_key,omitempty"`
+	Region      string `json:"region,omitempty"`
+	IAMEndpoint string `json:"iam_endpoint,omitempty"`
+	STSEndpoint string `json:"sts_endpoint,omitempty"`
+	MaxRetries  int    `json:"max_retries,omitempty"`
+}
+
+type awsRootConfigWrapper struct {
+	Data AWSRootConfig `json:"data"`
+}
+
+func (a *awsSecrets) ConfigureRoot(config AWSRootConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// do not log the request, which contains the secret key
+	requestPath := a.mount + "/config/root"
+	if err := a.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "configuring aws root credentials at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (a *awsSecrets) LookupRootConfig() (AWSRootConfig, error) {
+	var wrapper awsRootConfigWrapper
+	if err := a.client.get(a.mount+"/config/root", &wrapper); err != nil {
+		return AWSRootConfig{}, errors.Wrap(err, "failed to read aws root config")
+	}
+	return wrapper.Data, nil
+}
+
+// AWSSecretsRoleOptions are used to define properties of an aws secrets
+// role being created or updated. CredentialType is one of "iam_user",
+// "assumed_role", or "federation_token", which determines which of the
+// other fields apply. Durations are expressed as strings that vault
+// understands, such as "1h".
+type AWSSecretsRoleOptions struct {
+	Name           string   `json:"-"`
+	CredentialType string   `json:"credential_type"`
+	RoleARNs       []string `json:"role_arns,omitempty"`
+	PolicyARNs     []string `json:"policy_arns,omitempty"`
+	PolicyDocument string   `json:"policy_document,omitempty"`
+	IAMGroups      []string `json:"iam_groups,omitempty"`
+	DefaultSTSTTL  string   `json:"default_sts_ttl,omitempty"`
+	MaxSTSTTL      string   `json:"max_sts_ttl,omitempty"`
+	UserPath       string   `json:"user_path,omitempty"`
+}
+
+func (a *awsSecrets) CreateRole(opts AWSSecretsRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling aws secrets role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/roles/%s", a.mount, opts.Name)
+	a.client.logRequest("aws-secrets-role-create", requestPath, bs)
+	if err := a.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating aws secrets role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpAWSSecretsRoleWrapper struct {
+	Data LookedUpAWSSecretsRole `json:"data"`
+}
+
+// A LookedUpAWSSecretsRole represents information returned from vault
+// after making a request for information about a particular aws
+// secrets role.
+type LookedUpAWSSecretsRole struct {
+	CredentialType string   `json:"credential_type"`
+	RoleARNs       []string `json:"role_arns"`
+	PolicyARNs     []string `json:"policy_arns"`
+	PolicyDocument string   `json:"policy_document"`
+	IAMGroups      []string `json:"iam_groups"`
+	DefaultSTSTTL  int      `json:"default_sts_ttl"`
+	MaxSTSTTL      int      `json:"max_sts_ttl"`
+	UserPath       string   `json:"user_path"`
+}
+
+func (a *awsSecrets) LookupRole(name string) (LookedUpAWSSecretsRole, error) {
+	var wrapper lookedUpAWSSecretsRoleWrapper
+	requestPath := fmt.Sprintf("%s/roles/%s", a.mount, name)
+	if err := a.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpAWSSecretsRole{}, errors.Wrapf(err, "failed to look up aws secrets role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (a *awsSecrets) ListRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := a.mount + "/roles"
+	if err := a.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list aws secrets roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (a *awsSecrets) DeleteRole(name string) error {
+	requestPath := fmt.Sprintf("%s/roles/%s", a.mount, name)
+	if err := a.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete aws secrets role %q", name)
+	}
+	return nil
+}
+
+// AWSCredentialsOptions are optional parameters of generating AWS
+// credentials. RoleARN selects one of the RoleARNs of a role, and is
+// required if the role has more than one. TTL is expressed as a string
+// that vault understands, such as "1h", and does not apply to
+// credentials of type "iam_user".
+type AWSCredentialsOptions struct {
+	RoleARN string `json:"role_arn,omitempty"`
+	TTL     string `json:"ttl,omitempty"`
+}
+
+// AWSLeasedCredentials are AWS credentials generated by vault, along
+// with their lease. The SessionToken is only set for STS credentials.
+type AWSLeasedCredentials struct {
+	AWSCredentials
+	LeaseID       string
+	LeaseDuration int
+	Renewable     bool
+}
+
+type awsLeasedCredentialsWrapper struct {
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+	Data          struct {
+		AccessKey     string `json:"access_key"`
+		SecretKey     string `json:"secret_key"`
+		SecurityToken string `json:"security_token"`
+	} `json:"data"`
+}
+
+func (a *awsSecrets) credentials(endpoint, role string, opts AWSCredentialsOptions) (AWSLeasedCredentials, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return AWSLeasedCredentials{}, err
+	}
+
+	var wrapper awsLeasedCredentialsWrapper
+	requestPath := fmt.Sprintf("%s/%s/%s", a.mount, endpoint, role)
+	if err := a.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return AWSLeasedCredentials{}, errors.Wrapf(err, "failed to generate aws credentials for role %q", role)
+	}
+
+	return AWSLeasedCredentials{
+		AWSCredentials: AWSCredentials{
+			AccessKeyID:     wrapper.Data.AccessKey,
+			SecretAccessKey: wrapper.Data.SecretKey,
+			SessionToken:    wrapper.Data.SecurityToken,
+		},
+		LeaseID:       wrapper.LeaseID,
+		LeaseDuration: wrapper.LeaseDuration,
+		Renewable:     wrapper.Renewable,
+	}, nil
+}
+
+// Credentials generates AWS credentials of the credential type
+// of the role.
+func (a *awsSecrets) Credentials(role string, opts AWSCredentialsOptions) (AWSLeasedCredentials, error) {
+	return a.credentials("creds", role, opts)
+}
+
+// STSCredentials generates STS credentials, which requires a role of
+// type "assumed_role" or "federation_token".
+func (a *awsSecrets) STSCredentials(role string, opts AWSCredentialsOptions) (AWSLeasedCredentials, error) {
+	return a.credentials("sts", role, opts)
+}
@@ -0,0 +1,106 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_awsSecrets_RootConfig(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/aws/config/root": "",
+		"GET /v1/aws/config/root":  `{"data":{"access_key":"AKIAEXAMPLE","region":"us-west-2"}}`,
+	})
+	aws := vault.AWSSecretsMount("/aws/")
+
+	err := aws.ConfigureRoot(AWSRootConfig{
+		AccessKey: "AKIAEXAMPLE",
+		SecretKey: "secret",
+		Region:    "us-west-2",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"access_key":"AKIAEXAMPLE","secret_key":"secret","region":"us-west-2"}`, vault.requests[0].Body)
+
+	config, err := aws.LookupRootConfig()
+	require.NoError(t, err)
+	require.Equal(t, AWSRootConfig{AccessKey: "AKIAEXAMPLE", Region: "us-west-2"}, config)
+}
+
+func Test_awsSecrets_Roles(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/aws/roles/deploy":   "",
+		"GET /v1/aws/roles/deploy":    `{"data":{"credential_type":"assumed_role","role_arns":["arn:aws:iam::123:role/deploy"],"default_sts_ttl":900}}`,
+		"LIST /v1/aws/roles":          `{"data":{"keys":["deploy","audit"]}}`,
+		"DELETE /v1/aws/roles/deploy": "",
+	})
+	aws := vault.AWSSecretsMount("aws")
+
+	err := aws.CreateRole(AWSSecretsRoleOptions{
+		Name:           "deploy",
+		CredentialType: "assumed_role",
+		RoleARNs:       []string{"arn:aws:iam::123:role/deploy"},
+		DefaultSTSTTL:  "15m",
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"credential_type":"assumed_role","role_arns":["arn:aws:iam::123:role/deploy"],"default_sts_ttl":"15m"}`,
+		vault.requests[0].Body,
+	)
+
+	role, err := aws.LookupRole("deploy")
+	require.NoError(t, err)
+	require.Equal(t, LookedUpAWSSecretsRole{
+		CredentialType: "assumed_role",
+		RoleARNs:       []string{"arn:aws:iam::123:role/deploy"},
+		DefaultSTSTTL:  900,
+	}, role)
+
+	roles, err := aws.ListRoles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"audit", "deploy"}, roles)
+
+	require.NoError(t, aws.DeleteRole("deploy"))
+}
+
+func Test_awsSecrets_Credentials(t *testing.T) {
+	const leased = `{
+		"lease_id": "aws/creds/deploy/abc",
+		"lease_duration": 900,
+		"renewable": true,
+		"data": {"access_key": "ASIAEXAMPLE", "secret_key": "secret", "security_token": "session"}
+	}`
+
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/aws/creds/deploy": leased,
+		"POST /v1/aws/sts/deploy":   leased,
+	})
+	aws := vault.AWSSecretsMount("aws")
+
+	creds, err := aws.Credentials("deploy", AWSCredentialsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, AWSLeasedCredentials{
+		AWSCredentials: AWSCredentials{
+			AccessKeyID:     "ASIAEXAMPLE",
+			SecretAccessKey: "secret",
+			SessionToken:    "session",
+		},
+		LeaseID:       "aws/creds/deploy/abc",
+		LeaseDuration: 900,
+		Renewable:     true,
+	}, creds)
+
+	_, err = aws.STSCredentials("deploy", AWSCredentialsOptions{
+		RoleARN: "arn:aws:iam::123:role/deploy",
+		TTL:     "15m",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, `{}`, vault.requests[0].Body)
+	require.Equal(t, fakeRequest{
+		Method: "POST",
+		URI:    "/v1/aws/sts/deploy",
+		Body:   `{"role_arn":"arn:aws:iam::123:role/deploy","ttl":"15m"}`,
+	}, vault.requests[1])
+}
@@ -0,0 +1,242 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shoenig/toolkit"
+)
+
+const (
+	azureMSIAPIVersion  = "2018-02-01"
+	azureIMDSAPIVersion = "2017-08-01"
+
+	// AzureManagementResource is the default resource for which the
+	// MSI access token is requested, as expected by the azure auth
+	// backend unless it has been configured otherwise.
+	AzureManagementResource = "https://management.azure.com/"
+)
+
+// AzureAuth provides a way for Azure virtual machines to authenticate
+// with vault by using their managed service identity (MSI), and a
+// way to manage the roles of the azure auth backend.
+//
+// The azure backend must be enabled at the default path of
+// auth/azure for this API to work.
+//
+// More information about the azure auth backend can be found here:
+// https://www.vaultproject.io/docs/auth/azure.html
+type AzureAuth interface {
+	LoginAzure(role string, opts AzureLoginOptions) (CreatedToken, error)
+	CreateAzureRole(opts AzureRoleOptions) error
+	LookupAzureRole(name string) (LookedUpAzureRole, error)
+	ListAzureRoles() ([]string, error)
+	DeleteAzureRole(name string) error
+}
+
+// AzureLoginOptions are used to login with the azure auth backend.
+// If JWT is empty, the MSI access token and the instance information
+// are retrieved automatically from the Azure instance metadata service,
+// which is only possible when running on an Azure virtual machine.
+type AzureLoginOptions struct {
+	JWT               string `json:"jwt"`
+	SubscriptionID    string `json:"subscription_id,omitempty"`
+	ResourceGroupName string `json:"resource_group_name,omitempty"`
+	VMName            string `json:"vm_name,omitempty"`
+	VMSSName          string `json:"vmss_name,omitempty"`
+}
+
+type azureLogin struct {
+	Role string `json:"role"`
+	AzureLoginOptions
+}
+
+func (c *client) LoginAzure(role string, opts AzureLoginOptions) (CreatedToken, error) {
+	if opts.JWT == "" {
+		jwt, err := AzureMSIToken(AzureManagementResource)
+		if err != nil {
+			return CreatedToken{}, err
+		}
+
+		instance, err := AzureInstanceMetadata()
+		if err != nil {
+			return CreatedToken{}, err
+		}
+
+		opts = AzureLoginOptions{
+			JWT:               jwt,
+			SubscriptionID:    instance.SubscriptionID,
+			ResourceGroupName: instance.ResourceGroupName,
+			VMName:            instance.Name,
+			VMSSName:          instance.VMScaleSetName,
+		}
+	}
+
+	return c.login("azure", "/v1/auth/azure/login", azureLogin{
+		Role:              role,
+		AzureLoginOptions: opts,
+	})
+}
+
+// azureMetadataEndpoint is the Azure instance metadata service,
+// which is only reachable from within Azure virtual machines
+var azureMetadataEndpoint = "http://169.254.169.254/metadata"
+
+// imds requests must not go through any proxy, and are
+// expected to be answered very quickly
+var imdsClient = &http.Client{
+	Transport: &http.Transport{Proxy: nil},
+	Timeout:   5 * time.Second,
+}
+
+func imdsGet(url string, i interface{}) error {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build azure metadata request to %q", url)
+	}
+	request.Header.Set("Metadata", "true")
+
+	response, err := imdsClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to execute azure metadata request to %q", url)
+	}
+	defer toolkit.Drain(response.Body)
+
+	if response.StatusCode >= 400 {
+		return errors.Errorf("bad status code: %d, url: %s", response.StatusCode, url)
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(i); err != nil {
+		return errors.Wrapf(err, "failed to read response from %q", url)
+	}
+
+	return nil
+}
+
+type azureMSIToken struct {
+	AccessToken string `json:"access_token"`
+}
+
+// AzureMSIToken retrieves an access token for resource from the
+// managed service identity endpoint of the Azure instance metadata
+// service.
+func AzureMSIToken(resource string) (string, error) {
+	url := fixup(azureMetadataEndpoint, "identity/oauth2/token",
+		[2]string{"api-version", azureMSIAPIVersion},
+		[2]string{"resource", resource},
+	)
+
+	var token azureMSIToken
+	if err := imdsGet(url, &token); err != nil {
+		return "", errors.Wrap(err, "failed to get azure msi token")
+	}
+	return token.AccessToken, nil
+}
+
+// An AzureInstance contains information about the Azure virtual
+// machine, as provided by the Azure instance metadata service.
+type AzureInstance struct {
+	Name              string `json:"name"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	SubscriptionID    string `json:"subscriptionId"`
+	VMScaleSetName    string `json:"vmScaleSetName"`
+}
+
+type azureInstanceWrapper struct {
+	Compute AzureInstance `json:"compute"`
+}
+
+// AzureInstanceMetadata retrieves information about the Azure virtual
+// machine from the Azure instance metadata service.
+func AzureInstanceMetadata() (AzureInstance, error) {
+	url := fixup(azureMetadataEndpoint, "instance", [2]string{"api-version", azureIMDSAPIVersion})
+
+	var wrapper azureInstanceWrapper
+	if err := imdsGet(url, &wrapper); err != nil {
+		return AzureInstance{}, errors.Wrap(err, "failed to get azure instance metadata")
+	}
+	return wrapper.Compute, nil
+}
+
+// AzureRoleOptions are used to define properties of an azure role
+// being created or updated. Durations are expressed as strings
+// that vault understands, such as "1h".
+type AzureRoleOptions struct {
+	Name                     string   `json:"-"`
+	BoundServicePrincipalIDs []string `json:"bound_service_principal_ids,omitempty"`
+	BoundGroupIDs            []string `json:"bound_group_ids,omitempty"`
+	BoundLocations           []string `json:"bound_locations,omitempty"`
+	BoundSubscriptionIDs     []string `json:"bound_subscription_ids,omitempty"`
+	BoundResourceGroups      []string `json:"bound_resource_groups,omitempty"`
+	Policies                 []string `json:"policies,omitempty"`
+	TTL                      string   `json:"ttl,omitempty"`
+	MaxTTL                   string   `json:"max_ttl,omitempty"`
+	Period                   string   `json:"period,omitempty"`
+}
+
+func (c *client) CreateAzureRole(opts AzureRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling azure role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/azure/role/%s", opts.Name)
+	c.logRequest("azure-role-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating azure role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpAzureRoleWrapper struct {
+	Data LookedUpAzureRole `json:"data"`
+}
+
+// A LookedUpAzureRole represents information returned from vault
+// after making a request for information about a particular
+// azure role.
+type LookedUpAzureRole struct {
+	BoundServicePrincipalIDs []string `json:"bound_service_principal_ids"`
+	BoundGroupIDs            []string `json:"bound_group_ids"`
+	BoundLocations           []string `json:"bound_locations"`
+	BoundSubscriptionIDs     []string `json:"bound_subscription_ids"`
+	BoundResourceGroups      []string `json:"bound_resource_groups"`
+	Policies                 []string `json:"policies"`
+	TTL                      int      `json:"ttl"`
+	MaxTTL                   int      `json:"max_ttl"`
+	Period                   int      `json:"period"`
+}
+
+func (c *client) LookupAzureRole(name string) (LookedUpAzureRole, error) {
+	var wrapper lookedUpAzureRoleWrapper
+	requestPath := fmt.Sprintf("/v1/auth/azure/role/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpAzureRole{}, errors.Wrapf(err, "failed to look up azure role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListAzureRoles() ([]string, error) {
+	var wrapper rolesWrapper
+	requestPath := "/v1/auth/azure/role"
+	if err := c.list(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list azure roles at %q", requestPath)
+	}
+	sort.Strings(wrapper.Data.Keys)
+	return wrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteAzureRole(name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/azure/role/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete azure role %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAzureIMDS points the azureMetadataEndpoint at a server which
+// answers like the Azure instance metadata service, until the
+// returned function is called.
+func fakeAzureIMDS(t *testing.T) func() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the metadata service rejects requests without the header
+		if r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/metadata/identity/oauth2/token":
+			require.Equal(t, azureMSIAPIVersion, query.Get("api-version"))
+			fmt.Fprintf(w, `{"access_token":"jwt-for-%s"}`, query.Get("resource"))
+		case "/metadata/instance":
+			require.Equal(t, azureIMDSAPIVersion, query.Get("api-version"))
+			fmt.Fprint(w, `{"compute":{"name":"vm1","resourceGroupName":"rg1","subscriptionId":"sub1","vmScaleSetName":""}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	original := azureMetadataEndpoint
+	azureMetadataEndpoint = server.URL + "/metadata"
+	return func() {
+		azureMetadataEndpoint = original
+		server.Close()
+	}
+}
+
+func Test_imdsClient_noProxy(t *testing.T) {
+	require.Nil(t, imdsClient.Transport.(*http.Transport).Proxy)
+}
+
+func Test_AzureMSIToken(t *testing.T) {
+	defer fakeAzureIMDS(t)()
+
+	token, err := AzureMSIToken("https://vault.example.com/")
+	require.NoError(t, err)
+	require.Equal(t, "jwt-for-https://vault.example.com/", token)
+}
+
+func Test_AzureInstanceMetadata(t *testing.T) {
+	defer fakeAzureIMDS(t)()
+
+	instance, err := AzureInstanceMetadata()
+	require.NoError(t, err)
+	require.Equal(t, AzureInstance{
+		Name:              "vm1",
+		ResourceGroupName: "rg1",
+		SubscriptionID:    "sub1",
+	}, instance)
+}
+
+func Test_client_LoginAzure(t *testing.T) {
+	defer fakeAzureIMDS(t)()
+
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/auth/azure/login": `{"auth":{"client_token":"s.azure"}}`,
+	})
+
+	// without a jwt, the login is made with the msi of the vm
+	token, err := vault.LoginAzure("web", AzureLoginOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "s.azure", token.ID)
+	require.Equal(t,
+		`{"role":"web","jwt":"jwt-for-https://management.azure.com/","subscription_id":"sub1","resource_group_name":"rg1","vm_name":"vm1"}`,
+		vault.requests[0].Body,
+	)
+}
+
+func Test_client_AzureRoles(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/auth/azure/role/web":   "",
+		"GET /v1/auth/azure/role/web":    `{"data":{"bound_subscription_ids":["sub1"],"policies":["web"],"ttl":600}}`,
+		"LIST /v1/auth/azure/role":       `{"data":{"keys":["web"]}}`,
+		"DELETE /v1/auth/azure/role/web": "",
+	})
+
+	err := vault.CreateAzureRole(AzureRoleOptions{
+		Name:                 "web",
+		BoundSubscriptionIDs: []string{"sub1"},
+		Policies:             []string{"web"},
+		TTL:                  "10m",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"bound_subscription_ids":["sub1"],"policies":["web"],"ttl":"10m"}`, vault.requests[0].Body)
+
+	role, err := vault.LookupAzureRole("web")
+	require.NoError(t, err)
+	require.Equal(t, []string{"sub1"}, role.BoundSubscriptionIDs)
+	require.Equal(t, 600, role.TTL)
+
+	roles, err := vault.ListAzureRoles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"web"}, roles)
+
+	require.NoError(t, vault.DeleteAzureRole("web"))
+}
@@ -0,0 +1,243 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// An AzureSecrets represents an azure secrets engine, which generates
+// short lived Azure service principals. This is unrelated to the
+// AzureAuth method, which logs in to vault with Azure identities.
+//
+// More information about the azure secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/azure/index.html
+type AzureSecrets interface {
+	// Configuration
+	Configure(config AzureSecretsConfig) error
+	LookupConfig() (AzureSecretsConfig, error)
+	DeleteConfig() error
+
+	// Roles
+	CreateRole(opts AzureSecretsRoleOptions) error
+	LookupRole(name string) (LookedUpAzureSecretsRole, error)
+	ListRoles() ([]string, error)
+	DeleteRole(name string) error
+
+	// Credentials
+	Credentials(role string) (AzureCredentials, error)
+}
+
+func (c *client) AzureSecretsMount(mount string) AzureSecrets {
+	return &azureSecrets{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type azureSecrets struct {
+	client *client
+	mount  string
+}
+
+// AzureSecretsConfig is the configuration of the service principal
+// used by vault to manage other service principals. The ClientSecret
+// is never returned when looking up the configuration.
+type AzureSecretsConfig struct {
+	SubscriptionID string `json:"subscription_id"`
+	TenantID       string `json:"tenant_id"`
+	ClientID       string `json:"client_id,omitempty"`
+	ClientSecret   string `json:"client_secret,omitempty"`
+	Environment    string `json:"environment,omitempty"`
+}
+
+type azureSecretsConfigWrapper struct {
+	Data AzureSecretsConfig `json:"data"`
+}
+
+func (a *azureSecrets) Configure(config AzureSecretsConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// do not log the request, which contains the client secret
+	requestPath := a.mount + "/config"
+	if err := a.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "configuring azure secrets at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (a *azureSecrets) LookupConfig() (AzureSecretsConfig, error) {
+	var wrapper azureSecretsConfigWrapper
+	if err := a.client.get(a.mount+"/config", &wrapper); err != nil {
+		return AzureSecretsConfig{}, errors.Wrap(err, "failed to read azure secrets config")
+	}
+	return wrapper.Data, nil
+}
+
+func (a *azureSecrets) DeleteConfig() error {
+	if err := a.client.delete(a.mount + "/config"); err != nil {
+		return errors.Wrap(err, "failed to delete azure secrets config")
+	}
+	return nil
+}
+
+// An AzureRole is an Azure role assigned to the service principals
+// generated for a role. Either the RoleName or the RoleID is required.
+type AzureRole struct {
+	RoleName string `json:"role_name,omitempty"`
+	RoleID   string `json:"role_id,omitempty"`
+	Scope    string `json:"scope"`
+}
+
+// An AzureGroup is an Azure group which the service principals
+// generated for a role are added to. Either the GroupName or the
+// ObjectID is required.
+type AzureGroup struct {
+	GroupName string `json:"group_name,omitempty"`
+	ObjectID  string `json:"object_id,omitempty"`
+}
+
+// AzureSecretsRoleOptions are used to define properties of an azure
+// secrets role being created or updated. A role either generates new
+// service principals with the given AzureRoles and AzureGroups, or
+// generates new secrets for the existing application with the given
+// ApplicationObjectID. Durations are expressed as strings that vault
+// understands, such as "1h".
+type AzureSecretsRoleOptions struct {
+	Name                string       `json:"-"`
+	AzureRoles          []AzureRole  `json:"-"`
+	AzureGroups         []AzureGroup `json:"-"`
+	ApplicationObjectID string       `json:"application_object_id,omitempty"`
+	TTL                 string       `json:"ttl,omitempty"`
+	MaxTTL              string       `json:"max_ttl,omitempty"`
+}
+
+type azureSecretsRoleRequest struct {
+	AzureSecretsRoleOptions
+	AzureRoles  string `json:"azure_roles,omitempty"`
+	AzureGroups string `json:"azure_groups,omitempty"`
+}
+
+func (a *azureSecrets) CreateRole(opts AzureSecretsRoleOptions) error {
+	request := azureSecretsRoleRequest{AzureSecretsRoleOptions: opts}
+
+	// vault expects the roles and groups to be JSON encoded strings
+	if len(opts.AzureRoles) > 0 {
+		roles, err := json.Marshal(opts.AzureRoles)
+		if err != nil {
+			return errors.Wrap(err, "marshalling azure roles")
+		}
+		request.AzureRoles = string(roles)
+	}
+
+	if len(opts.AzureGroups) > 0 {
+		groups, err := json.Marshal(opts.AzureGroups)
+		if err != nil {
+			return errors.Wrap(err, "marshalling azure groups")
+		}
+		request.AzureGroups = string(groups)
+	}
+
+	bs, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "marshalling azure secrets role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/roles/%s", a.mount, opts.Name)
+	a.client.logRequest("azure-secrets-role-create", requestPath, bs)
+	if err := a.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating azure secrets role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpAzureSecretsRoleWrapper struct {
+	Data LookedUpAzureSecretsRole `json:"data"`
+}
+
+// A LookedUpAzureSecretsRole represents information returned from
+// vault after making a request for information about a particular
+// azure secrets role.
+type LookedUpAzureSecretsRole struct {
+	AzureRoles          []AzureRole  `json:"azure_roles"`
+	AzureGroups         []AzureGroup `json:"azure_groups"`
+	ApplicationObjectID string       `json:"application_object_id"`
+	TTL                 int          `json:"ttl"`
+	MaxTTL              int          `json:"max_ttl"`
+}
+
+func (a *azureSecrets) LookupRole(name string) (LookedUpAzureSecretsRole, error) {
+	var wrapper lookedUpAzureSecretsRoleWrapper
+	requestPath := fmt.Sprintf("%s/roles/%s", a.mount, name)
+	if err := a.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpAzureSecretsRole{}, errors.Wrapf(err, "failed to look up azure secrets role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (a *azureSecrets) ListRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := a.mount + "/roles"
+	if err := a.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list azure secrets roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (a *azureSecrets) DeleteRole(name string) error {
+	requestPath := fmt.Sprintf("%s/roles/%s", a.mount, name)
+	if err := a.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete azure secrets role %q", name)
+	}
+	return nil
+}
+
+// AzureCredentials are the credentials of an Azure service principal
+// generated by vault, along with their lease.
+type AzureCredentials struct {
+	ClientID      string
+	ClientSecret  string
+	LeaseID       string
+	LeaseDuration int
+	Renewable     bool
+}
+
+type azureCredentialsWrapper struct {
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+	Data          struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	} `json:"data"`
+}
+
+// Credentials generates credentials of a service principal with the
+// parameters of the role. New service principals may take a while to
+// propagate through Azure before they can be used.
+func (a *azureSecrets) Credentials(role string) (AzureCredentials, error) {
+	var wrapper azureCredentialsWrapper
+	requestPath := fmt.Sprintf("%s/creds/%s", a.mount, role)
+	if err := a.client.get(requestPath, &wrapper); err != nil {
+		return AzureCredentials{}, errors.Wrapf(err, "failed to generate azure credentials for role %q", role)
+	}
+
+	return AzureCredentials{
+		ClientID:      wrapper.Data.ClientID,
+		ClientSecret:  wrapper.Data.ClientSecret,
+		LeaseID:       wrapper.LeaseID,
+		LeaseDuration: wrapper.LeaseDuration,
+		Renewable:     wrapper.Renewable,
+	}, nil
+}
@@ -0,0 +1,95 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_azureSecrets_Config(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/azure/config":   "",
+		"GET /v1/azure/config":    `{"data":{"subscription_id":"sub1","tenant_id":"tenant1","client_id":"client1","environment":"AzurePublicCloud"}}`,
+		"DELETE /v1/azure/config": "",
+	})
+	azure := vault.AzureSecretsMount("azure")
+
+	err := azure.Configure(AzureSecretsConfig{
+		SubscriptionID: "sub1",
+		TenantID:       "tenant1",
+		ClientID:       "client1",
+		ClientSecret:   "secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"subscription_id":"sub1","tenant_id":"tenant1","client_id":"client1","client_secret":"secret"}`,
+		vault.requests[0].Body,
+	)
+
+	config, err := azure.LookupConfig()
+	require.NoError(t, err)
+	require.Equal(t, AzureSecretsConfig{
+		SubscriptionID: "sub1",
+		TenantID:       "tenant1",
+		ClientID:       "client1",
+		Environment:    "AzurePublicCloud",
+	}, config)
+
+	require.NoError(t, azure.DeleteConfig())
+}
+
+func Test_azureSecrets_Roles(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/azure/roles/web":   "",
+		"GET /v1/azure/roles/web":    `{"data":{"azure_roles":[{"role_name":"Reader","scope":"/subscriptions/sub1"}],"azure_groups":[{"object_id":"g1"}],"ttl":3600}}`,
+		"LIST /v1/azure/roles":       `{"data":{"keys":["web"]}}`,
+		"DELETE /v1/azure/roles/web": "",
+	})
+	azure := vault.AzureSecretsMount("azure")
+
+	// the roles and groups are sent as JSON encoded strings
+	err := azure.CreateRole(AzureSecretsRoleOptions{
+		Name:        "web",
+		AzureRoles:  []AzureRole{{RoleName: "Reader", Scope: "/subscriptions/sub1"}},
+		AzureGroups: []AzureGroup{{ObjectID: "g1"}},
+		TTL:         "1h",
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"ttl":"1h","azure_roles":"[{\"role_name\":\"Reader\",\"scope\":\"/subscriptions/sub1\"}]","azure_groups":"[{\"object_id\":\"g1\"}]"}`,
+		vault.requests[0].Body,
+	)
+
+	// but are returned as lists
+	role, err := azure.LookupRole("web")
+	require.NoError(t, err)
+	require.Equal(t, LookedUpAzureSecretsRole{
+		AzureRoles:  []AzureRole{{RoleName: "Reader", Scope: "/subscriptions/sub1"}},
+		AzureGroups: []AzureGroup{{ObjectID: "g1"}},
+		TTL:         3600,
+	}, role)
+
+	roles, err := azure.ListRoles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"web"}, roles)
+
+	require.NoError(t, azure.DeleteRole("web"))
+}
+
+func Test_azureSecrets_Credentials(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"GET /v1/azure/creds/web": `{"lease_id":"azure/creds/web/abc","lease_duration":3600,"renewable":true,"data":{"client_id":"sp1","client_secret":"secret"}}`,
+	})
+
+	creds, err := vault.AzureSecretsMount("azure").Credentials("web")
+	require.NoError(t, err)
+	require.Equal(t, AzureCredentials{
+		ClientID:      "sp1",
+		ClientSecret:  "secret",
+		LeaseID:       "azure/creds/web/abc",
+		LeaseDuration: 3600,
+		Renewable:     true,
+	}, creds)
+}
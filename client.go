@@ -3,13 +3,16 @@
 package vaultapi
 
 import (
-	"crypto/tls"
+	"bytes"
+	"context"
 	"encoding/json"
+	"io"
 	"io/ioutil"
-	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -18,10 +21,16 @@ import (
 
 const (
 	headerVaultToken  = "X-Vault-Token"
+	headerVaultMFA    = "X-Vault-MFA"
+	headerWrapTTL     = "X-Vault-Wrap-TTL"
+	headerNamespace   = "X-Vault-Namespace"
+	headerNoForward   = "X-Vault-No-Request-Forwarding"
 	headerContentType = "Content-Type"
 	mimeJSON          = "application/json"
 	mimeText          = "text/plain"
 	methodLIST        = "LIST" // ffs
+
+	defaultMaxRedirects = 10
 )
 
 // mocks generated with github.com/vektra/mockery
@@ -32,8 +41,136 @@ const (
 // by the vault server.
 type Client interface {
 	Auth
+	AppRoleAuth
+	KubernetesAuth
+	AWSAuth
+	LDAPAuth
+	UserpassAuth
+	GitHubAuth
+	JWTAuth
+	AzureAuth
+	KerberosAuth
 	KV
 	Sys
+	Transit
+	Identity
+
+	// KVMount returns a KV for the kv (version 1) secrets
+	// engine mounted at mount, rather than at secret/.
+	KVMount(mount string) KV
+
+	// Cubbyhole returns a KV for the cubbyhole of the token of
+	// the Client, which is private to that token and is destroyed
+	// along with the token.
+	Cubbyhole() KV
+
+	// KV2Mount returns a KV2 for the kv (version 2) secrets
+	// engine mounted at mount.
+	KV2Mount(mount string) KV2
+
+	// PKIMount returns a PKI for the pki secrets engine
+	// mounted at mount.
+	PKIMount(mount string) PKI
+
+	// DatabaseMount returns a Database for the database
+	// secrets engine mounted at mount, which is usually
+	// "database".
+	DatabaseMount(mount string) Database
+
+	// SSHMount returns an SSH for the ssh secrets
+	// engine mounted at mount.
+	SSHMount(mount string) SSH
+
+	// AWSSecretsMount returns an AWSSecrets for the aws
+	// secrets engine mounted at mount.
+	AWSSecretsMount(mount string) AWSSecrets
+
+	// AzureSecretsMount returns an AzureSecrets for the azure
+	// secrets engine mounted at mount.
+	AzureSecretsMount(mount string) AzureSecrets
+
+	// NomadSecretsMount returns a NomadSecrets for the nomad
+	// secrets engine mounted at mount.
+	NomadSecretsMount(mount string) NomadSecrets
+
+	// RabbitMQSecretsMount returns a RabbitMQSecrets for the
+	// rabbitmq secrets engine mounted at mount.
+	RabbitMQSecretsMount(mount string) RabbitMQSecrets
+
+	// LDAPSecretsMount returns an LDAPSecrets for the ldap
+	// secrets engine mounted at mount.
+	LDAPSecretsMount(mount string) LDAPSecrets
+
+	// TerraformSecretsMount returns a TerraformSecrets for the
+	// terraform secrets engine mounted at mount.
+	TerraformSecretsMount(mount string) TerraformSecrets
+
+	// Logical returns a Logical for making requests to arbitrary
+	// paths, for endpoints which the Client does not provide.
+	Logical() Logical
+
+	// RawRequest makes a request with the given method to the
+	// path of vault, and returns the response as is, e.g. for
+	// endpoints which stream responses or do not respond with
+	// JSON. The caller must close the body of the response.
+	RawRequest(method, path string, opts RawRequestOptions) (*http.Response, error)
+
+	// WithMFA returns a Client which sends the given MFA
+	// credentials with every request, in addition to those of
+	// ClientOptions.MFA. The Client shares its token with c.
+	WithMFA(credentials ...string) Client
+
+	// WithWrapTTL returns a Client whose requests are response
+	// wrapped with the given TTL. The WrapInfo of each wrapped
+	// response is stored into info, while the results of the
+	// methods of the Client are empty. The Client shares its
+	// token with c.
+	WithWrapTTL(ttl time.Duration, info *WrapInfo) Client
+
+	// WithContext returns a Client whose requests are made with
+	// ctx, so that they are abandoned once ctx is cancelled or its
+	// deadline passes, in addition to the HTTPTimeout of each
+	// request. The Client shares its token with c.
+	WithContext(ctx context.Context) Client
+
+	// WithResponseInfo returns a Client which stores the
+	// ResponseInfo of the response to each request into info,
+	// e.g. the ID of the request, the warnings of vault, or the
+	// remaining rate limit quota of the Client. The Client shares
+	// its token with c.
+	WithResponseInfo(info *ResponseInfo) Client
+
+	// WithNamespace returns a Client whose requests are made
+	// in the given namespace, instead of the namespace of
+	// ClientOptions.Namespace. The Client shares its token
+	// with c.
+	WithNamespace(namespace string) Client
+
+	// WithNoRequestForwarding returns a Client whose requests
+	// are serviced by the performance standby node which receives
+	// them if noForwarding is true, or are forwarded to the active
+	// node otherwise, instead of as configured by
+	// ClientOptions.NoRequestForwarding. The Client shares its
+	// token with c.
+	WithNoRequestForwarding(noForwarding bool) Client
+
+	// WithTimeout returns a Client whose requests time out after
+	// the given timeout, instead of the HTTPTimeout. The Client
+	// shares its token with c.
+	WithTimeout(timeout time.Duration) Client
+
+	// WithToken returns a Client which uses the given token,
+	// instead of the token of c.
+	WithToken(token string) Client
+
+	// WithHeader returns a Client which also sends the given
+	// header with every request, in addition to the headers of
+	// ClientOptions.Headers. The Client shares its token with c.
+	WithHeader(key, value string) Client
+
+	// SetAuth configures the Client to obtain and refresh its
+	// own token by logging in with the given AuthMethod.
+	SetAuth(method AuthMethod)
 }
 
 var (
@@ -45,7 +182,9 @@ var (
 	// was provided as a value for client HTTP timeouts.
 	ErrInvalidHTTPTimeout = errors.New("invalid HTTP timeout")
 
-	// ErrPathNotFound indicates the requested path did not exist.
+	// ErrPathNotFound indicates the requested path did not exist. The
+	// error of such a request is an APIError with a 404 StatusCode, so
+	// use errors.Is to check for ErrPathNotFound.
 	ErrPathNotFound = errors.New("requested path not found")
 )
 
@@ -54,9 +193,16 @@ var (
 type ClientOptions struct {
 	// Servers should be populated with complete URI including transport
 	// and port number of each of the vault servers that are running.
-	// An example URI: https://127.0.0.1:8200.
+	// An example URI: https://127.0.0.1:8200. A server may also be the
+	// path of a unix domain socket, such as the listener of a vault
+	// agent, e.g. unix:///var/run/vault-agent.sock, unless a custom
+	// HTTPClient or Transport is configured.
 	Servers []string
 
+	// SRV may be optionally configured to discover the vault servers
+	// from DNS SRV records, which are tried before any of the Servers.
+	SRV SRVOptions
+
 	// HTTPTimeout configures how long underlying HTTP requests should
 	// wait before giving up and returning a timeout error. By default,
 	// this value is 10 seconds.
@@ -69,19 +215,109 @@ type ClientOptions struct {
 	// do not use this option in production environments.
 	SkipTLSVerification bool
 
-	// Logger may be optionally configured as an output for trace
-	// level logging produced by the Client. This can be helpful
-	// for debugging logic errors in client code.
-	Logger *log.Logger
+	// TLS may be optionally configured with the CA certificates used to
+	// verify the certificates of vault, the client certificate presented
+	// to vault, and other options of TLS. By default, the certificates
+	// of vault are verified with the system roots.
+	TLS TLSOptions
+
+	// Logger may be optionally configured as an output for the
+	// leveled logging produced by the Client, including each request
+	// and response at the debug level. This can be helpful for
+	// debugging logic errors in client code.
+	Logger Logger
+
+	// Redact may be optionally configured to report whether the value
+	// of a field of a logged request body must be redacted, in addition
+	// to the fields which are always redacted, such as "password" and
+	// "token". The bodies of responses are never logged.
+	Redact func(field string) bool
+
+	// MFA may be optionally configured with credentials of MFA methods,
+	// which are sent with every request in the X-Vault-MFA header, for
+	// environments where vault enforces MFA. Each credential is of the
+	// form "method:passcode", where method is the name or ID of the
+	// MFA method. Methods which do not use a passcode are given
+	// by name or ID alone.
+	MFA []string
+
+	// Namespace may be optionally configured with the path of the
+	// vault enterprise namespace in which requests are made, which is
+	// sent with every request in the X-Vault-Namespace header. By
+	// default, requests are made in the root namespace.
+	Namespace string
+
+	// NoRequestForwarding may be optionally configured to send every
+	// request with the X-Vault-No-Request-Forwarding header, so that
+	// performance standby nodes service requests themselves rather
+	// than forwarding them to the active node. Requests which a
+	// standby node cannot service still fail or are redirected.
+	NoRequestForwarding bool
+
+	// MaxRedirects configures how many redirects are followed by a
+	// request, such as the redirects from standby nodes to the active
+	// node of vault. The body and headers of the request, including
+	// its token, are sent again with each redirect, but the token and
+	// Headers are sent only to the host of the request and the hosts of
+	// the servers. By default, this value is 10, and a negative value
+	// disables following redirects, in which case a redirect fails the
+	// request on that server.
+	MaxRedirects int
+
+	// Proxy may be optionally configured with the proxy through which
+	// requests are made. By default, requests are made directly.
+	Proxy ProxyOptions
+
+	// HTTPClient may be optionally configured with the http.Client used
+	// to make requests, e.g. to share a client tuned for connection
+	// pooling. If set, HTTPTimeout, SkipTLSVerification, TLS,
+	// MaxRedirects, Proxy, and Transport are ignored, and are instead
+	// configured by the HTTPClient itself.
+	HTTPClient *http.Client
+
+	// Transport may be optionally configured with the http.RoundTripper
+	// used to make requests, e.g. a corporate proxy transport, or one
+	// which instruments requests. If set, SkipTLSVerification, TLS, and
+	// Proxy are ignored, and are instead configured by the Transport
+	// itself.
+	Transport http.RoundTripper
+
+	// Headers may be optionally configured with headers which are
+	// sent with every request, in addition to those set by the Client.
+	Headers http.Header
+
+	// Retry configures how requests which fail in a way which is likely
+	// to be temporary are retried. By default, requests are not retried.
+	Retry RetryOptions
+
+	// Metrics may be optionally configured with a function which is
+	// called with the RequestMetrics of every request once it completes,
+	// e.g. to record them with a metrics library. The function must
+	// not block, as it is called before the request returns.
+	Metrics func(RequestMetrics)
+
+	// Middleware may be optionally configured to wrap the execution of
+	// every request, in order. The first Middleware is the outermost,
+	// and the last Middleware wraps the http.Client of the Client.
+	Middleware []Middleware
+
+	// ReadYourWrites may be optionally configured so that reads always
+	// reflect the writes previously made by the Client, even if made by
+	// a performance standby node which has not yet caught up with the
+	// active node. The X-Vault-Index header of each response is sent
+	// with subsequent requests, along with the X-Vault-Inconsistent
+	// header which asks standby nodes to forward the request to the
+	// active node if they are behind.
+	ReadYourWrites bool
 }
 
 // New creates a new Client that will connect to one or more vault
 // servers as specified by opts.Servers. The tokener is used to
 // aquire the token to be used to authenticate with vault. If
-// opts.Logger is not nil, trace output will be emitted to it which
+// opts.Logger is not nil, log output will be emitted to it which
 // can be helpful for debugging an application using the Client.
 func New(opts ClientOptions, tokener Tokener) (Client, error) {
-	if len(opts.Servers) == 0 {
+	if len(opts.Servers) == 0 && opts.SRV.Name == "" {
 		return nil, ErrNoServers
 	}
 
@@ -90,36 +326,355 @@ func New(opts ClientOptions, tokener Tokener) (Client, error) {
 	}
 
 	if opts.Logger == nil {
-		opts.Logger = log.New(ioutil.Discard, "", 0)
+		opts.Logger = nopLogger{}
 	}
 
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: opts.SkipTLSVerification,
-		},
+	opts.Retry = opts.Retry.withDefaults()
+
+	servers, sockets := unixSockets(opts.Servers)
+	opts.Servers = servers
+
+	var discovery *discovery
+	if opts.SRV.Name != "" {
+		var err error
+		if discovery, err = newDiscovery(opts, net.LookupSRV); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient, err := newHTTPClient(opts, sockets, discovery)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes *indexStore
+	if opts.ReadYourWrites {
+		indexes = new(indexStore)
 	}
 
 	return &client{
-		opts:    opts,
-		tokener: tokener,
-		httpClient: &http.Client{
-			Transport: transport,
-			Timeout:   opts.HTTPTimeout,
-		},
+		opts:       opts,
+		tokener:    tokener,
+		httpClient: httpClient,
+		doer:       chain(httpClient, opts.Middleware),
+		discovery:  discovery,
+		indexes:    indexes,
 	}, nil
 }
 
+func newHTTPClient(opts ClientOptions, sockets map[string]string, discovery *discovery) (*http.Client, error) {
+	if opts.HTTPClient != nil {
+		return opts.HTTPClient, nil
+	}
+
+	transport := opts.Transport
+	if transport == nil {
+		tlsConfig, err := opts.TLS.config(opts.SkipTLSVerification)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to configure TLS")
+		}
+
+		proxy, err := opts.Proxy.proxy(sockets)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to configure proxy")
+		}
+
+		transport = &http.Transport{
+			Proxy:           proxy,
+			TLSClientConfig: tlsConfig,
+			DialContext:     dialer(sockets),
+		}
+	}
+
+	return &http.Client{
+		Transport:     transport,
+		Timeout:       opts.HTTPTimeout,
+		CheckRedirect: checkRedirect(opts.MaxRedirects, opts.Headers, trusted(opts.Servers, discovery)),
+	}, nil
+}
+
+// checkRedirect returns a redirect policy which follows up to
+// maxRedirects redirects, with the headers of the original request.
+// The headers of vault, and the configured headers, are only sent
+// to the host of the original request and to trusted hosts.
+func checkRedirect(maxRedirects int, headers http.Header, trusted func(*url.URL) bool) func(*http.Request, []*http.Request) error {
+	if maxRedirects < 0 {
+		return func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	if maxRedirects == 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	return func(request *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return errors.Errorf("too many redirects, last to %q", request.URL)
+		}
+
+		if request.URL.Host != via[0].URL.Host && !trusted(request.URL) {
+			// net/http copies the headers of the original request,
+			// which must not leak the token to some other host
+			for key := range request.Header {
+				if strings.HasPrefix(key, "X-Vault-") {
+					delete(request.Header, key)
+				}
+			}
+			for key := range headers {
+				request.Header.Del(key)
+			}
+			return nil
+		}
+
+		// the token is not a sensitive header to net/http,
+		// but make sure every header survives the redirect
+		for key, values := range via[0].Header {
+			if _, exists := request.Header[key]; !exists {
+				request.Header[key] = values
+			}
+		}
+		return nil
+	}
+}
+
+// trusted returns whether the host of a URL is the host of one of
+// the servers, or of the servers discovered by discovery.
+func trusted(servers []string, discovery *discovery) func(*url.URL) bool {
+	return func(target *url.URL) bool {
+		current := servers
+		if discovery != nil {
+			current = discovery.Servers()
+		}
+
+		host := canonicalAddress(target)
+		for _, server := range current {
+			if u, err := url.Parse(server); err == nil && canonicalAddress(u) == host {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 type client struct {
 	opts ClientOptions
 
+	lock       sync.RWMutex // protects tokener
 	tokener    Tokener
 	httpClient *http.Client
+	doer       Doer
+	discovery  *discovery
+	indexes    *indexStore
+
+	// set by WithWrapTTL
+	wrapTTL  time.Duration
+	wrapInfo *WrapInfo
+
+	// set by WithContext
+	ctx context.Context
+
+	// set by WithResponseInfo
+	responseInfo *ResponseInfo
 }
 
 func (c *client) token() (string, error) {
+	if c.anonymous() {
+		return "", nil
+	}
+
+	c.lock.RLock()
+	tokener := c.tokener
+	c.lock.RUnlock()
+
 	// the tokener is responsible for locking
 	// its own token, whatever that means
-	return c.tokener.Token()
+	return tokener.Token()
+}
+
+// derive returns a copy of c, which shares its token
+func (c *client) derive() *client {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return &client{
+		opts:         c.opts,
+		tokener:      c.tokener,
+		httpClient:   c.httpClient,
+		doer:         c.doer,
+		discovery:    c.discovery,
+		indexes:      c.indexes,
+		wrapTTL:      c.wrapTTL,
+		wrapInfo:     c.wrapInfo,
+		ctx:          c.ctx,
+		responseInfo: c.responseInfo,
+	}
+}
+
+// WithMFA returns a copy of c which also sends credentials.
+func (c *client) WithMFA(credentials ...string) Client {
+	derived := c.derive()
+	derived.opts.MFA = append(append([]string(nil), c.opts.MFA...), credentials...)
+	return derived
+}
+
+// WithWrapTTL returns a copy of c which wraps responses into info.
+func (c *client) WithWrapTTL(ttl time.Duration, info *WrapInfo) Client {
+	derived := c.derive()
+	derived.wrapTTL = ttl
+	derived.wrapInfo = info
+	return derived
+}
+
+// WithContext returns a copy of c which makes requests with ctx.
+func (c *client) WithContext(ctx context.Context) Client {
+	derived := c.derive()
+	derived.ctx = ctx
+	return derived
+}
+
+// context returns the context of the requests made by c.
+func (c *client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// final returns whether a request which failed with err must not be
+// tried again with the next server, because the failure is not caused
+// by the server, or because the context of the request is done.
+func (c *client) final(err error) bool {
+	return isControlGroup(err) || clientError(err) || c.context().Err() != nil
+}
+
+// WithResponseInfo returns a copy of c which stores into info.
+func (c *client) WithResponseInfo(info *ResponseInfo) Client {
+	derived := c.derive()
+	derived.responseInfo = info
+	return derived
+}
+
+// WithNamespace returns a copy of c which uses namespace.
+func (c *client) WithNamespace(namespace string) Client {
+	derived := c.derive()
+	derived.opts.Namespace = namespace
+	return derived
+}
+
+// WithNoRequestForwarding returns a copy of c which sets whether
+// requests are forwarded to the active node.
+func (c *client) WithNoRequestForwarding(noForwarding bool) Client {
+	derived := c.derive()
+	derived.opts.NoRequestForwarding = noForwarding
+	return derived
+}
+
+// WithTimeout returns a copy of c which times out after timeout.
+func (c *client) WithTimeout(timeout time.Duration) Client {
+	derived := c.derive()
+	httpClient := *c.httpClient
+	httpClient.Timeout = timeout
+	derived.httpClient = &httpClient
+	derived.doer = chain(&httpClient, c.opts.Middleware)
+	return derived
+}
+
+// WithToken returns a copy of c which uses token.
+func (c *client) WithToken(token string) Client {
+	derived := c.derive()
+	derived.tokener = NewStaticToken(token)
+	return derived
+}
+
+// WithHeader returns a copy of c which also sends the header.
+func (c *client) WithHeader(key, value string) Client {
+	derived := c.derive()
+	derived.opts.Headers = make(http.Header)
+	for k, values := range c.opts.Headers {
+		derived.opts.Headers[k] = append([]string(nil), values...)
+	}
+	derived.opts.Headers.Add(key, value)
+
+	// the redirect policy must also strip the header on redirects to
+	// untrusted hosts, unless the http.Client is not ours to change
+	if c.opts.HTTPClient == nil {
+		httpClient := *c.httpClient
+		httpClient.CheckRedirect = checkRedirect(
+			c.opts.MaxRedirects,
+			derived.opts.Headers,
+			trusted(c.opts.Servers, c.discovery),
+		)
+		derived.httpClient = &httpClient
+		derived.doer = chain(&httpClient, c.opts.Middleware)
+	}
+	return derived
+}
+
+// setHeaders sets the headers configured for every request
+// made by the client, beyond the token.
+func (c *client) setHeaders(request *http.Request) {
+	for key, values := range c.opts.Headers {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
+	for _, credentials := range c.opts.MFA {
+		request.Header.Add(headerVaultMFA, credentials)
+	}
+
+	if c.opts.Namespace != "" {
+		request.Header.Set(headerNamespace, c.opts.Namespace)
+	}
+
+	if c.opts.NoRequestForwarding {
+		request.Header.Set(headerNoForward, "true")
+	}
+
+	if c.wrapTTL > 0 {
+		request.Header.Set(headerWrapTTL, durationString(c.wrapTTL))
+	}
+}
+
+// decode decodes the JSON response body into i, which may be nil if
+// only the WrapInfo of a wrapped response is wanted. A response which
+// is wrapped even though the client did not ask for wrapping is how
+// vault enforces control groups, and is returned as a ControlGroupError,
+// unless i is a Secret, which has a WrapInfo of its own.
+func (c *client) decode(body io.Reader, i interface{}) error {
+	bs, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	var envelope responseEnvelope
+	// responses which are not JSON objects are never wrapped
+	if err := json.Unmarshal(bs, &envelope); err == nil {
+		c.recordEnvelope(envelope)
+	}
+
+	if envelope.WrapInfo != nil {
+		// the wrapping endpoints always respond with a wrap_info, and
+		// the generic Logical responses may be from any endpoint
+		var expected bool
+		switch i.(type) {
+		case *wrapInfoWrapper, *Secret:
+			expected = true
+		}
+
+		switch {
+		case c.wrapInfo != nil:
+			*c.wrapInfo = *envelope.WrapInfo
+		case !expected:
+			return &ControlGroupError{WrapInfo: *envelope.WrapInfo}
+		}
+	}
+
+	if i == nil {
+		return nil
+	}
+	return json.Unmarshal(bs, i)
 }
 
 func fixup(prefix, path string, params ...[2]string) string {
@@ -145,24 +700,29 @@ func fixup(prefix, path string, params ...[2]string) string {
 }
 
 func (c *client) get(path string, i interface{}) error {
-	for _, address := range c.opts.Servers {
+	var last error
+	servers := c.servers()
+	for _, address := range servers {
 		err := c.singleGet(address, path, i)
-		if err == ErrPathNotFound {
-			c.opts.Logger.Printf("GET request for uknown path %q", path)
-			return ErrPathNotFound
+		if errors.Is(err, ErrNotFound) {
+			c.opts.Logger.Debugf("GET request for unknown path: %q", path)
+			return err
+		} else if c.final(err) {
+			return err
 		} else if err != nil {
-			c.opts.Logger.Printf("GET request failed: %v", err)
+			c.opts.Logger.Warnf("GET request failed: %v", err)
+			last = err
 		} else {
 			return nil
 		}
 	}
-	return errors.Errorf("all attempts for GET request failed to: %v", c.opts.Servers)
+	return allFailed("GET", servers, last)
 }
 
 func (c *client) singleGet(address, path string, i interface{}) error {
 	url := address + path
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(c.context(), http.MethodGet, url, nil)
 	if err != nil {
 		return errors.Wrapf(err, "failed to build GET request to %q", url)
 	}
@@ -173,26 +733,30 @@ func (c *client) singleGet(address, path string, i interface{}) error {
 	}
 
 	request.Header.Set(headerVaultToken, token)
+	c.setHeaders(request)
 	request.Header.Set(headerContentType, mimeText)
 
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return errors.Wrapf(err, "failed to execute GET request to %q", url)
 	}
 
 	defer toolkit.Drain(response.Body)
 
-	// special case 404, because we need to be able to explicitly identify
-	// cases where the requested path was not available.
-	if response.StatusCode == http.StatusNotFound {
-		return ErrPathNotFound
+	if response.StatusCode >= 300 {
+		return newAPIError(request, response)
 	}
 
-	if response.StatusCode >= 400 {
-		return errors.Errorf("bad status code: %d, url: %s", response.StatusCode, url)
+	// some endpoints, like the pki CA and CRL, respond
+	// with raw bytes rather than JSON
+	if raw, ok := i.(*[]byte); ok {
+		if *raw, err = ioutil.ReadAll(response.Body); err != nil {
+			return errors.Wrapf(err, "failed to read response from %q", url)
+		}
+		return nil
 	}
 
-	if err := json.NewDecoder(response.Body).Decode(i); err != nil {
+	if err := c.decode(response.Body, i); err != nil {
 		return errors.Wrapf(err, "failed to read response from %q", url)
 	}
 
@@ -200,24 +764,29 @@ func (c *client) singleGet(address, path string, i interface{}) error {
 }
 
 func (c *client) list(path string, i interface{}) error {
-	for _, address := range c.opts.Servers {
+	var last error
+	servers := c.servers()
+	for _, address := range servers {
 		err := c.singleList(address, path, i)
-		if err == ErrPathNotFound {
-			c.opts.Logger.Printf("LIST request for unknown path: %q", path)
-			return ErrPathNotFound
+		if errors.Is(err, ErrNotFound) {
+			c.opts.Logger.Debugf("LIST request for unknown path: %q", path)
+			return err
+		} else if c.final(err) {
+			return err
 		} else if err != nil {
-			c.opts.Logger.Printf("LIST request failed: %v", err)
+			c.opts.Logger.Warnf("LIST request failed: %v", err)
+			last = err
 			continue
 		}
 		return nil
 	}
-	return errors.Errorf("all attempts for LIST request failed to: %v", c.opts.Servers)
+	return allFailed("LIST", servers, last)
 }
 
 func (c *client) singleList(address, path string, i interface{}) error {
 	url := address + path
 
-	request, err := http.NewRequest(methodLIST, url, nil)
+	request, err := http.NewRequestWithContext(c.context(), methodLIST, url, nil)
 	if err != nil {
 		return errors.Wrapf(err, "failed to build LIST request to: %q", url)
 	}
@@ -228,27 +797,23 @@ func (c *client) singleList(address, path string, i interface{}) error {
 	}
 
 	request.Header.Set(headerVaultToken, token)
+	c.setHeaders(request)
 	request.Header.Set(headerContentType, mimeJSON)
 
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return errors.Wrapf(err, "failed to execute LIST request to %q", url)
 	}
 
-	// special case 404, because we need to be able to explicitly identify
-	// cases where the requested path was not available.
-	if response.StatusCode == http.StatusNotFound {
-		return ErrPathNotFound
-	}
-
-	if response.StatusCode >= 400 {
-		return errors.Errorf("bad status code: %d, url: %s", response.StatusCode, url)
+	if response.StatusCode >= 300 {
+		defer toolkit.Drain(response.Body)
+		return newAPIError(request, response)
 	}
 
-	if i != nil {
+	if i != nil || c.wrapInfo != nil || c.responseInfo != nil {
 		// read the response iff we have something to unmarshal it into
 		defer toolkit.Drain(response.Body)
-		if err := json.NewDecoder(response.Body).Decode(i); err != nil {
+		if err := c.decode(response.Body, i); err != nil {
 			return errors.Wrapf(err, "failed to read response from %q", url)
 		}
 	}
@@ -257,24 +822,35 @@ func (c *client) singleList(address, path string, i interface{}) error {
 }
 
 func (c *client) post(path, body string, i interface{}) error {
-	for _, address := range c.opts.Servers {
-		err := c.singlePost(address, path, body, i)
-		if err == ErrPathNotFound {
-			c.opts.Logger.Printf("POST request for unknown path: %q", path)
-			return ErrPathNotFound
+	return c.postHeaders(path, body, nil, i)
+}
+
+// postHeaders is like post, but also sets the given header on
+// the request, for endpoints which expect more than the token.
+func (c *client) postHeaders(path, body string, header http.Header, i interface{}) error {
+	var last error
+	servers := c.servers()
+	for _, address := range servers {
+		err := c.singlePost(address, path, body, header, i)
+		if errors.Is(err, ErrNotFound) {
+			c.opts.Logger.Debugf("POST request for unknown path: %q", path)
+			return err
+		} else if c.final(err) {
+			return err
 		} else if err != nil {
-			c.opts.Logger.Printf("POST request failed: %v", err)
+			c.opts.Logger.Warnf("POST request failed: %v", err)
+			last = err
 			continue
 		}
 		return nil
 	}
-	return errors.Errorf("all attempts for POST request failed to: %v", c.opts.Servers)
+	return allFailed("POST", servers, last)
 }
 
-func (c *client) singlePost(address, path, body string, i interface{}) error {
+func (c *client) singlePost(address, path, body string, header http.Header, i interface{}) error {
 	url := address + path
 
-	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	request, err := http.NewRequestWithContext(c.context(), http.MethodPost, url, strings.NewReader(body))
 	if err != nil {
 		return errors.Wrapf(err, "failed to build POST request to %q", url)
 	}
@@ -284,28 +860,53 @@ func (c *client) singlePost(address, path, body string, i interface{}) error {
 		return errors.Wrap(err, "failed to get token for request")
 	}
 
+	for key, values := range header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
 	request.Header.Set(headerVaultToken, token)
+	c.setHeaders(request)
 	request.Header.Set(headerContentType, mimeJSON)
 
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return errors.Wrapf(err, "failed to execute POST request to %q", url)
 	}
 
-	// special case 404, because we need to be able to explicitly identify
-	// cases where the requested path was not available.
-	if response.StatusCode == http.StatusNotFound {
-		return ErrPathNotFound
+	// some endpoints, like transit batch operations, fail with a bad
+	// request status code while still providing a useful response
+	if p, partial := i.(partialResponse); partial && response.StatusCode == http.StatusBadRequest {
+		defer toolkit.Drain(response.Body)
+		bs, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read response from %q", url)
+		}
+		if json.Unmarshal(bs, i) == nil && p.partial() {
+			return nil
+		}
+
+		// the request as a whole was rejected
+		response.Body = ioutil.NopCloser(bytes.NewReader(bs))
+		return newAPIError(request, response)
+	}
+
+	if response.StatusCode >= 300 {
+		defer toolkit.Drain(response.Body)
+		return newAPIError(request, response)
 	}
 
-	if response.StatusCode >= 400 {
-		return errors.Errorf("bad status code: %d, url: %s", response.StatusCode, url)
+	// some endpoints, like remount, respond with content only in
+	// newer versions of vault
+	if response.StatusCode == http.StatusNoContent {
+		return nil
 	}
 
-	if i != nil {
+	if i != nil || c.wrapInfo != nil || c.responseInfo != nil {
 		// read the response iff we have something to unmarshal it into
 		defer toolkit.Drain(response.Body)
-		if err := json.NewDecoder(response.Body).Decode(i); err != nil {
+		if err := c.decode(response.Body, i); err != nil {
 			return errors.Wrapf(err, "failed to read response from %q", url)
 		}
 	}
@@ -313,25 +914,39 @@ func (c *client) singlePost(address, path, body string, i interface{}) error {
 	return nil
 }
 
+// A partialResponse is a response which is decoded even if the
+// request fails with a bad request status code, because it may
+// describe which parts of the request failed. Once decoded, partial
+// returns whether it does, rather than the request as a whole
+// having been rejected.
+type partialResponse interface {
+	partial() bool
+}
+
 func (c *client) put(path, body string) error {
-	for _, address := range c.opts.Servers {
+	var last error
+	servers := c.servers()
+	for _, address := range servers {
 		err := c.singlePut(address, path, body)
-		if err == ErrPathNotFound {
-			c.opts.Logger.Printf("PUT request to unknown path: %q", path)
-			return ErrPathNotFound
+		if errors.Is(err, ErrNotFound) {
+			c.opts.Logger.Debugf("PUT request to unknown path: %q", path)
+			return err
+		} else if c.final(err) {
+			return err
 		} else if err != nil {
-			c.opts.Logger.Printf("PUT request failed: %v", err)
+			c.opts.Logger.Warnf("PUT request failed: %v", err)
+			last = err
 			continue
 		}
 		return nil
 	}
-	return errors.Errorf("all attempts for PUT request failed to: %v", c.opts.Servers)
+	return allFailed("PUT", servers, last)
 }
 
 func (c *client) singlePut(address, path, body string) error {
 	url := address + path
 
-	request, err := http.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	request, err := http.NewRequestWithContext(c.context(), http.MethodPut, url, strings.NewReader(body))
 	if err != nil {
 		return errors.Wrapf(err, "failed to build PUT request to %q", url)
 	}
@@ -342,78 +957,57 @@ func (c *client) singlePut(address, path, body string) error {
 	}
 
 	request.Header.Set(headerVaultToken, token)
+	c.setHeaders(request)
 	request.Header.Set(headerContentType, mimeJSON)
 
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return errors.Wrapf(err, "failed to execute PUT request to %q", url)
 	}
 
-	// do not read response
+	// do not read response, unless its ResponseInfo is wanted
 
-	// special case 404, because we need to be able to explicitly identify
-	// cases where the requested path was not available.
-	if response.StatusCode == http.StatusNotFound {
-		return ErrPathNotFound
+	if response.StatusCode >= 300 {
+		defer toolkit.Drain(response.Body)
+		return newAPIError(request, response)
 	}
 
-	if response.StatusCode >= 400 {
-		return errors.Errorf("bad status code: %d, url: %s", response.StatusCode, url)
+	if c.responseInfo != nil {
+		defer toolkit.Drain(response.Body)
+		if err := c.decode(response.Body, nil); err != nil {
+			return errors.Wrapf(err, "failed to read response from %q", url)
+		}
 	}
 
 	return nil
 }
 
-// we have to implement recursion ourselves - which will
-// be the case for paths that end in a trailing slash
-// see: https://github.com/hashicorp/vault/issues/885
 func (c *client) delete(path string) error {
-	c.opts.Logger.Printf("delete %q", path)
-	noprefix := strings.TrimPrefix(path, "/v1/secret")
-
-	// recursively descend if this path is a directory
-	if strings.HasSuffix(path, "/") {
-		keys, err := c.Keys(noprefix)
-		if err != nil {
-			c.opts.Logger.Printf("delete recursion error: %v", err)
-			return err
-		}
-		c.opts.Logger.Print("recursive keys:", keys)
-		// call delete on every key under this path
-		for _, subpath := range keys {
-			if err := c.delete(path + subpath); err != nil {
-				return err
-			}
-		}
-
-		return nil
-	}
-	// base case: actually delete this path, which is a concrete
-	// key and not a directory
-	c.opts.Logger.Printf("delete concrete path: %q", path)
-	return c.deleteKey(path)
-}
-
-func (c *client) deleteKey(path string) error {
-	for _, address := range c.opts.Servers {
+	var last error
+	servers := c.servers()
+	for _, address := range servers {
 		err := c.singleDelete(address, path)
-		if err == ErrPathNotFound {
-			c.opts.Logger.Printf("DELETE request to unknown path: %q", path)
+		if errors.Is(err, ErrNotFound) {
+			c.opts.Logger.Debugf("DELETE request to unknown path: %q", path)
+			last = err
 			continue
+		} else if c.final(err) {
+			return err
 		} else if err != nil {
-			c.opts.Logger.Printf("DELETE request failed: %v", err)
+			c.opts.Logger.Warnf("DELETE request failed: %v", err)
+			last = err
 			continue
 		}
 		return nil
 	}
-	return errors.Errorf("all attempts for DELETE request failed to: %v", c.opts.Servers)
+	return allFailed("DELETE", servers, last)
 }
 
 func (c *client) singleDelete(address, path string) error {
 	url := address + path
-	c.opts.Logger.Printf("delete url: %q", url)
+	c.opts.Logger.Debugf("delete url: %q", url)
 
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	request, err := http.NewRequestWithContext(c.context(), http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
@@ -424,23 +1018,26 @@ func (c *client) singleDelete(address, path string) error {
 	}
 
 	request.Header.Set(headerVaultToken, token)
+	c.setHeaders(request)
 
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return err
 	}
-	// do not read response
+	// do not read response, unless its ResponseInfo is wanted
 
-	// special case 404, because we need to be able to explicitly identify
-	// cases where the requested path was not available.
-	if response.StatusCode == http.StatusNotFound {
-		return ErrPathNotFound
+	if response.StatusCode >= 300 {
+		defer toolkit.Drain(response.Body)
+		return newAPIError(request, response)
 	}
+	c.opts.Logger.Debugf("delete status code: %d", response.StatusCode)
 
-	if response.StatusCode >= 400 {
-		return errors.Errorf("bad status code: %d", response.StatusCode)
+	if c.responseInfo != nil {
+		defer toolkit.Drain(response.Body)
+		if err := c.decode(response.Body, nil); err != nil {
+			return errors.Wrapf(err, "failed to read response from %q", url)
+		}
 	}
-	c.opts.Logger.Printf("delete status code: %d", response.StatusCode)
 
 	return nil
 }
@@ -3,9 +3,16 @@
 package vaultapi
 
 import (
+	"context"
+	"io/ioutil"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -48,6 +55,300 @@ func devOpts() ClientOptions {
 	return ClientOptions{
 		Servers:             []string{"http://localhost:8200"},
 		SkipTLSVerification: true,
-		Logger:              log.New(os.Stdout, "[vaultapi] ", log.LstdFlags),
+		Logger:              NewStdLogger(log.New(os.Stdout, "[vaultapi] ", log.LstdFlags)),
 	}
 }
+
+func Test_client_WithContext(t *testing.T) {
+	opts := devOpts()
+	opts.Servers = []string{"http://localhost:8200", "http://localhost:8201"}
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the request is abandoned rather than tried with every server
+	_, err = client.WithContext(ctx).Get("/foo")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), context.Canceled.Error())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// a fakeRequest is a request received by a fakeVault
+type fakeRequest struct {
+	Method string
+	URI    string
+	Body   string
+}
+
+// a fakeVault is a Client of a vault which answers each request with
+// the response registered for its method and URI, e.g. "GET /v1/sys/health",
+// or with a 404 if there is none. An empty response is a 204. The
+// requests received are recorded in order.
+type fakeVault struct {
+	Client
+	responses map[string]string
+	requests  []fakeRequest
+}
+
+func newFakeVault(t *testing.T, responses map[string]string) *fakeVault {
+	vault := &fakeVault{responses: responses}
+
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		var body []byte
+		if request.Body != nil {
+			body, _ = ioutil.ReadAll(request.Body)
+		}
+		uri := request.URL.RequestURI()
+		vault.requests = append(vault.requests, fakeRequest{
+			Method: request.Method,
+			URI:    uri,
+			Body:   string(body),
+		})
+
+		code := http.StatusOK
+		response, exists := vault.responses[request.Method+" "+uri]
+		switch {
+		case !exists:
+			code, response = http.StatusNotFound, `{"errors":[]}`
+		case response == "":
+			code = http.StatusNoContent
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(strings.NewReader(response)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+	vault.Client = client
+	return vault
+}
+
+func Test_client_Transport(t *testing.T) {
+	var paths []string
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		paths = append(paths, request.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"data":{"value":"abc123"}}`)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	value, err := client.Get("/foo")
+	require.NoError(t, err)
+	require.Equal(t, "abc123", value)
+	require.Equal(t, []string{"/v1/secret/foo"}, paths)
+}
+
+func Test_client_unixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vaultapi-unix")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/foo" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"value":"abc123"}}`))
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	opts := devOpts()
+	opts.Servers = []string{"unix://" + socket}
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	value, err := client.Get("/foo")
+	require.NoError(t, err)
+	require.Equal(t, "abc123", value)
+}
+
+func Test_client_perCallOptions(t *testing.T) {
+	var requests []*http.Request
+	opts := devOpts()
+	opts.Headers = http.Header{"X-Team": []string{"platform"}}
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		requests = append(requests, request)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"data":{"value":"abc123"}}`)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	_, err = client.
+		WithToken("other").
+		WithNamespace("team-a").
+		WithHeader("X-Request-Source", "batch").
+		WithTimeout(time.Second).
+		WithNoRequestForwarding(true).
+		Get("/foo")
+	require.NoError(t, err)
+
+	_, err = client.Get("/foo")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	require.Equal(t, "other", requests[0].Header.Get("X-Vault-Token"))
+	require.Equal(t, "team-a", requests[0].Header.Get("X-Vault-Namespace"))
+	require.Equal(t, "batch", requests[0].Header.Get("X-Request-Source"))
+	require.Equal(t, "platform", requests[0].Header.Get("X-Team"))
+	require.Equal(t, "true", requests[0].Header.Get("X-Vault-No-Request-Forwarding"))
+
+	// the options of the derived client do not affect the client
+	require.Equal(t, "token", requests[1].Header.Get("X-Vault-Token"))
+	require.Equal(t, "", requests[1].Header.Get("X-Vault-Namespace"))
+	require.Equal(t, "", requests[1].Header.Get("X-Request-Source"))
+	require.Equal(t, "platform", requests[1].Header.Get("X-Team"))
+	require.Equal(t, "", requests[1].Header.Get("X-Vault-No-Request-Forwarding"))
+}
+
+func Test_client_MFA(t *testing.T) {
+	var requests []*http.Request
+	opts := devOpts()
+	opts.MFA = []string{"m1:123456"}
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		requests = append(requests, request)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"data":{"value":"abc123"}}`)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	_, err = client.Get("/foo")
+	require.NoError(t, err)
+
+	_, err = client.WithMFA("m2").Get("/foo")
+	require.NoError(t, err)
+
+	_, err = client.Get("/foo")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 3)
+	require.Equal(t, []string{"m1:123456"}, requests[0].Header.Values("X-Vault-MFA"))
+	require.Equal(t, []string{"m1:123456", "m2"}, requests[1].Header.Values("X-Vault-MFA"))
+
+	// the credentials of the derived client do not affect the client
+	require.Equal(t, []string{"m1:123456"}, requests[2].Header.Values("X-Vault-MFA"))
+}
+
+func Test_client_redirect(t *testing.T) {
+	type sent struct {
+		host, token, body string
+	}
+	var requests []sent
+	var activeSource string
+
+	opts := devOpts()
+	opts.Servers = []string{"http://standby:8200", "http://active:8200"}
+	opts.Headers = http.Header{"X-Team": []string{"platform"}}
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(request.Body)
+		switch request.URL.Host {
+		case "evil:8200":
+			require.Empty(t, request.Header.Get("X-Team"))
+			require.Empty(t, request.Header.Get("X-Request-Source"))
+		case "active:8200":
+			activeSource = request.Header.Get("X-Request-Source")
+		}
+		requests = append(requests, sent{
+			host:  request.URL.Host,
+			token: request.Header.Get("X-Vault-Token"),
+			body:  string(body),
+		})
+
+		if request.URL.Host == "standby:8200" || strings.Contains(request.URL.Path, "loop") {
+			target := "http://active:8200" + request.URL.Path
+			if strings.Contains(request.URL.Path, "loop") {
+				target = "http://loop:8200" + request.URL.Path
+			} else if strings.Contains(request.URL.Path, "evil") {
+				target = "http://evil:8200" + request.URL.Path
+			}
+			return &http.Response{
+				StatusCode: http.StatusTemporaryRedirect,
+				Header:     http.Header{"Location": []string{target}},
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	// the body and token are sent again to the active node
+	err = client.Put("/foo", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, []sent{
+		{host: "standby:8200", token: "token", body: `{"value":"abc123"}`},
+		{host: "active:8200", token: "token", body: `{"value":"abc123"}`},
+	}, requests)
+
+	// the token is not sent to hosts other than the servers
+	requests = nil
+	err = client.Put("/evil", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, []sent{
+		{host: "standby:8200", token: "token", body: `{"value":"abc123"}`},
+		{host: "evil:8200", token: "", body: `{"value":"abc123"}`},
+	}, requests)
+
+	// nor are the headers of a derived client, which are still
+	// sent to the servers
+	derived := client.WithHeader("X-Request-Source", "batch")
+	requests = nil
+	err = derived.Put("/evil", "abc123")
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	err = derived.Put("/foo", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, "batch", activeSource)
+
+	// redirects are followed only so many times
+	requests = nil
+	err = client.Put("/loop", "abc123")
+	require.Error(t, err)
+	require.Len(t, requests, 2*(1+defaultMaxRedirects))
+
+	// redirects which are not followed fail over to the next server
+	opts.MaxRedirects = -1
+	client, err = New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	requests = nil
+	err = client.Put("/foo", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, []sent{
+		{host: "standby:8200", token: "token", body: `{"value":"abc123"}`},
+		{host: "active:8200", token: "token", body: `{"value":"abc123"}`},
+	}, requests)
+}
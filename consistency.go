@@ -0,0 +1,121 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	headerIndex        = "X-Vault-Index"
+	headerInconsistent = "X-Vault-Inconsistent"
+
+	forwardActiveNode = "forward-active-node"
+)
+
+// An indexStore keeps the replication states of the writes made by a
+// client, as reported by vault in the X-Vault-Index header, and is
+// shared by a client and the clients derived from it. Sending the states
+// with later requests makes a performance standby node wait until it
+// has caught up with those writes, or forward the request to the active
+// node, so that a client always reads its own writes.
+type indexStore struct {
+	lock   sync.Mutex // protects states
+	states []string
+}
+
+// capture records the replication state of the response, if any.
+func (s *indexStore) capture(response *http.Response) {
+	state := response.Header.Get(headerIndex)
+	if state == "" {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.states = mergeIndexStates(s.states, state)
+}
+
+// apply sets the replication states recorded so far on the request.
+func (s *indexStore) apply(request *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.states) == 0 {
+		return
+	}
+
+	request.Header.Del(headerIndex)
+	for _, state := range s.states {
+		request.Header.Add(headerIndex, state)
+	}
+	request.Header.Set(headerInconsistent, forwardActiveNode)
+}
+
+// An indexState is a parsed replication state, which is the base64
+// encoding of "cluster:local:replicated:hmac", where local and
+// replicated are the WAL indexes of the write on the cluster.
+type indexState struct {
+	cluster    string
+	local      uint64
+	replicated uint64
+}
+
+func parseIndexState(state string) (indexState, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(state)
+	if err != nil {
+		return indexState{}, false
+	}
+
+	pieces := strings.Split(string(decoded), ":")
+	if len(pieces) != 4 {
+		return indexState{}, false
+	}
+
+	local, err := strconv.ParseUint(pieces[1], 10, 64)
+	if err != nil {
+		return indexState{}, false
+	}
+
+	replicated, err := strconv.ParseUint(pieces[2], 10, 64)
+	if err != nil {
+		return indexState{}, false
+	}
+
+	return indexState{
+		cluster:    pieces[0],
+		local:      local,
+		replicated: replicated,
+	}, true
+}
+
+// mergeIndexStates adds state to states, keeping only the latest state
+// of each cluster. A state which cannot be parsed replaces all states,
+// as there is no telling which of them it supersedes.
+func mergeIndexStates(states []string, state string) []string {
+	parsed, ok := parseIndexState(state)
+	if !ok {
+		return []string{state}
+	}
+
+	var merged []string
+	for _, existing := range states {
+		other, ok := parseIndexState(existing)
+		if !ok || other.cluster != parsed.cluster {
+			merged = append(merged, existing)
+			continue
+		}
+
+		// the existing state is newer, so keep it instead
+		if other.replicated > parsed.replicated ||
+			(other.replicated == parsed.replicated && other.local > parsed.local) {
+			return states
+		}
+	}
+	return append(merged, state)
+}
@@ -0,0 +1,84 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodeIndexState(raw string) string {
+	return base64.StdEncoding.EncodeToString([]byte(raw))
+}
+
+func Test_mergeIndexStates(t *testing.T) {
+	a1 := encodeIndexState("cluster-a:10:20:abcd")
+	a2 := encodeIndexState("cluster-a:11:20:abcd")
+	a3 := encodeIndexState("cluster-a:5:30:abcd")
+	b1 := encodeIndexState("cluster-b:1:1:abcd")
+
+	states := mergeIndexStates(nil, a1)
+	require.Equal(t, []string{a1}, states)
+
+	states = mergeIndexStates(states, b1)
+	require.Equal(t, []string{a1, b1}, states)
+
+	// newer states of a cluster replace older ones
+	states = mergeIndexStates(states, a2)
+	require.Equal(t, []string{b1, a2}, states)
+
+	states = mergeIndexStates(states, a3)
+	require.Equal(t, []string{b1, a3}, states)
+
+	// older states of a cluster are ignored
+	states = mergeIndexStates(states, a1)
+	require.Equal(t, []string{b1, a3}, states)
+
+	// unparsable states replace everything
+	states = mergeIndexStates(states, "garbage")
+	require.Equal(t, []string{"garbage"}, states)
+}
+
+func Test_client_ReadYourWrites(t *testing.T) {
+	state := encodeIndexState("cluster-a:10:20:abcd")
+
+	var requests []*http.Request
+	opts := devOpts()
+	opts.ReadYourWrites = true
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		requests = append(requests, request)
+		header := make(http.Header)
+		if request.Method == http.MethodPost {
+			header.Set("X-Vault-Index", state)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"data":{"value":"abc123"}}`)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	_, err = client.Get("/foo")
+	require.NoError(t, err)
+
+	err = client.Put("/foo", "abc123")
+	require.NoError(t, err)
+
+	// derived clients share the states of writes
+	_, err = client.WithNamespace("team-a").Get("/foo")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 3)
+	require.Empty(t, requests[0].Header.Get("X-Vault-Index"))
+	require.Empty(t, requests[0].Header.Get("X-Vault-Inconsistent"))
+	require.Equal(t, state, requests[2].Header.Get("X-Vault-Index"))
+	require.Equal(t, "forward-active-node", requests[2].Header.Get("X-Vault-Inconsistent"))
+}
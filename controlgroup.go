@@ -0,0 +1,93 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// A ControlGroupError is returned when a request is subject to a vault
+// enterprise control group, which must authorize the request before
+// its response is released. Vault responds with the WrapInfo of the
+// wrapped response instead, whose Accessor is given to the authorizers
+// of the control group, and whose Token is unwrapped by the requester
+// once the request has been authorized.
+//
+// The ControlGroupError may be wrapped; use errors.Cause to find it.
+//
+// More information about control groups can be found here:
+// https://www.vaultproject.io/docs/enterprise/control-groups
+type ControlGroupError struct {
+	WrapInfo WrapInfo
+}
+
+func (e *ControlGroupError) Error() string {
+	return fmt.Sprintf("request to %q requires control group authorization (accessor %q)",
+		e.WrapInfo.CreationPath, e.WrapInfo.Accessor)
+}
+
+func isControlGroup(err error) bool {
+	_, ok := errors.Cause(err).(*ControlGroupError)
+	return ok
+}
+
+// A ControlGroupRequest describes a request which is subject to a
+// control group, including the entity which made the request, and the
+// entities which have authorized it so far.
+type ControlGroupRequest struct {
+	Approved      bool   `json:"approved"`
+	RequestPath   string `json:"request_path"`
+	RequestEntity struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"request_entity"`
+	Authorizations []struct {
+		EntityID   string `json:"entity_id"`
+		EntityName string `json:"entity_name"`
+	} `json:"authorizations"`
+}
+
+type controlGroupAccessor struct {
+	Accessor string `json:"accessor"`
+}
+
+// AuthorizeControlGroup authorizes the request identified by the
+// accessor of its wrapping token, on behalf of the entity of the token
+// of the Client. It returns whether the request has been approved,
+// which happens once enough authorizers have authorized it.
+func (c *client) AuthorizeControlGroup(accessor string) (bool, error) {
+	bs, err := json.Marshal(controlGroupAccessor{Accessor: accessor})
+	if err != nil {
+		return false, err
+	}
+
+	var wrapper struct {
+		Data struct {
+			Approved bool `json:"approved"`
+		} `json:"data"`
+	}
+	if err := c.post("/v1/sys/control-group/authorize", string(bs), &wrapper); err != nil {
+		return false, errors.Wrapf(err, "failed to authorize control group request %q", accessor)
+	}
+	return wrapper.Data.Approved, nil
+}
+
+// LookupControlGroupRequest looks up the request identified by
+// the accessor of its wrapping token.
+func (c *client) LookupControlGroupRequest(accessor string) (ControlGroupRequest, error) {
+	bs, err := json.Marshal(controlGroupAccessor{Accessor: accessor})
+	if err != nil {
+		return ControlGroupRequest{}, err
+	}
+
+	var wrapper struct {
+		Data ControlGroupRequest `json:"data"`
+	}
+	if err := c.post("/v1/sys/control-group/request", string(bs), &wrapper); err != nil {
+		return ControlGroupRequest{}, errors.Wrapf(err, "failed to look up control group request %q", accessor)
+	}
+	return wrapper.Data, nil
+}
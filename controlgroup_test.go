@@ -0,0 +1,53 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const controlGroupResponse = `{
+  "request_id": "",
+  "data": null,
+  "wrap_info": {
+    "token": "s.TwV2sZXDMVxHzr5nYNfDOWDz",
+    "accessor": "a2tBpFBcQDmiBkc7LzZKSCpX",
+    "ttl": 86400,
+    "creation_time": "2019-03-01T12:00:00Z",
+    "creation_path": "secret/app/db"
+  }
+}`
+
+func Test_client_decode_controlGroup(t *testing.T) {
+	c := &client{}
+
+	var data keyData
+	err := c.decode(strings.NewReader(controlGroupResponse), &data)
+	require.Error(t, err)
+	require.True(t, isControlGroup(err))
+
+	cgErr := errors.Cause(err).(*ControlGroupError)
+	require.Equal(t, "a2tBpFBcQDmiBkc7LzZKSCpX", cgErr.WrapInfo.Accessor)
+	require.Equal(t, "secret/app/db", cgErr.WrapInfo.CreationPath)
+}
+
+func Test_client_decode_wrapped(t *testing.T) {
+	// responses which were asked to be wrapped are not control groups
+	var info WrapInfo
+	c := &client{wrapInfo: &info}
+
+	var data keyData
+	err := c.decode(strings.NewReader(controlGroupResponse), &data)
+	require.NoError(t, err)
+	require.Equal(t, "a2tBpFBcQDmiBkc7LzZKSCpX", info.Accessor)
+
+	// nor are responses of the wrapping endpoints
+	var wrapper wrapInfoWrapper
+	err = (&client{}).decode(strings.NewReader(controlGroupResponse), &wrapper)
+	require.NoError(t, err)
+	require.Equal(t, "s.TwV2sZXDMVxHzr5nYNfDOWDz", wrapper.WrapInfo.Token)
+}
@@ -0,0 +1,197 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A Database represents a database secrets engine, which manages
+// credentials of database users.
+//
+// More information about the database secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/databases/index.html
+type Database interface {
+	// Credentials will generate new credentials with the parameters
+	// of the named role.
+	Credentials(role string) (DatabaseCredentials, error)
+
+	// Static roles
+	CreateStaticRole(opts DatabaseStaticRoleOptions) error
+	LookupStaticRole(name string) (LookedUpDatabaseStaticRole, error)
+	ListStaticRoles() ([]string, error)
+	DeleteStaticRole(name string) error
+	StaticCredentials(name string) (DatabaseStaticCredentials, error)
+
+	// Rotation
+	RotateStaticRole(name string) error
+	RotateRoot(connection string) error
+}
+
+func (c *client) DatabaseMount(mount string) Database {
+	return &database{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type database struct {
+	client *client
+	mount  string
+}
+
+// DatabaseCredentials are credentials of a database user created
+// by vault. The user is dropped once the lease expires, unless the
+// lease is renewed.
+type DatabaseCredentials struct {
+	LeaseID       string
+	LeaseDuration int
+	Renewable     bool
+	Username      string
+	Password      string
+}
+
+type databaseCredentialsWrapper struct {
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+	Data          struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"data"`
+}
+
+func (d *database) Credentials(role string) (DatabaseCredentials, error) {
+	var wrapper databaseCredentialsWrapper
+	requestPath := fmt.Sprintf("%s/creds/%s", d.mount, role)
+	if err := d.client.get(requestPath, &wrapper); err != nil {
+		return DatabaseCredentials{}, errors.Wrapf(err, "failed to generate credentials of database role %q", role)
+	}
+	return DatabaseCredentials{
+		LeaseID:       wrapper.LeaseID,
+		LeaseDuration: wrapper.LeaseDuration,
+		Renewable:     wrapper.Renewable,
+		Username:      wrapper.Data.Username,
+		Password:      wrapper.Data.Password,
+	}, nil
+}
+
+// DatabaseStaticRoleOptions are used to define properties of a
+// database static role being created or updated. A static role maps
+// to an existing database user, whose password is rotated by vault
+// every RotationPeriod, expressed as a string that vault understands,
+// such as "24h".
+type DatabaseStaticRoleOptions struct {
+	Name               string   `json:"-"`
+	DBName             string   `json:"db_name"`
+	Username           string   `json:"username"`
+	RotationPeriod     string   `json:"rotation_period"`
+	RotationStatements []string `json:"rotation_statements,omitempty"`
+}
+
+func (d *database) CreateStaticRole(opts DatabaseStaticRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling database static role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/static-roles/%s", d.mount, opts.Name)
+	d.client.logRequest("database-static-role-create", requestPath, bs)
+	if err := d.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating database static role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpDatabaseStaticRoleWrapper struct {
+	Data LookedUpDatabaseStaticRole `json:"data"`
+}
+
+// A LookedUpDatabaseStaticRole represents information returned from
+// vault after making a request for information about a particular
+// database static role.
+type LookedUpDatabaseStaticRole struct {
+	DBName             string   `json:"db_name"`
+	Username           string   `json:"username"`
+	RotationPeriod     int      `json:"rotation_period"`
+	RotationStatements []string `json:"rotation_statements"`
+	LastVaultRotation  string   `json:"last_vault_rotation"`
+}
+
+func (d *database) LookupStaticRole(name string) (LookedUpDatabaseStaticRole, error) {
+	var wrapper lookedUpDatabaseStaticRoleWrapper
+	requestPath := fmt.Sprintf("%s/static-roles/%s", d.mount, name)
+	if err := d.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpDatabaseStaticRole{}, errors.Wrapf(err, "failed to look up database static role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (d *database) ListStaticRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := d.mount + "/static-roles"
+	if err := d.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list database static roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (d *database) DeleteStaticRole(name string) error {
+	requestPath := fmt.Sprintf("%s/static-roles/%s", d.mount, name)
+	if err := d.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete database static role %q", name)
+	}
+	return nil
+}
+
+// DatabaseStaticCredentials are the current credentials of the
+// database user of a static role. TTL is the number of seconds until
+// the password is next rotated.
+type DatabaseStaticCredentials struct {
+	Username          string `json:"username"`
+	Password          string `json:"password"`
+	LastVaultRotation string `json:"last_vault_rotation"`
+	RotationPeriod    int    `json:"rotation_period"`
+	TTL               int    `json:"ttl"`
+}
+
+type databaseStaticCredentialsWrapper struct {
+	Data DatabaseStaticCredentials `json:"data"`
+}
+
+func (d *database) StaticCredentials(name string) (DatabaseStaticCredentials, error) {
+	var wrapper databaseStaticCredentialsWrapper
+	requestPath := fmt.Sprintf("%s/static-creds/%s", d.mount, name)
+	if err := d.client.get(requestPath, &wrapper); err != nil {
+		return DatabaseStaticCredentials{}, errors.Wrapf(err, "failed to read credentials of database static role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+// RotateStaticRole immediately rotates the password of the database
+// user of the named static role, and resets its rotation period.
+func (d *database) RotateStaticRole(name string) error {
+	requestPath := fmt.Sprintf("%s/rotate-role/%s", d.mount, name)
+	if err := d.client.post(requestPath, "", nil); err != nil {
+		return errors.Wrapf(err, "failed to rotate database static role %q", name)
+	}
+	return nil
+}
+
+// RotateRoot rotates the password of the user vault uses to manage
+// the named database connection. Afterwards, the password is only
+// known to vault.
+func (d *database) RotateRoot(connection string) error {
+	requestPath := fmt.Sprintf("%s/rotate-root/%s", d.mount, connection)
+	if err := d.client.post(requestPath, "", nil); err != nil {
+		return errors.Wrapf(err, "failed to rotate root credentials of database connection %q", connection)
+	}
+	return nil
+}
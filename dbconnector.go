@@ -0,0 +1,173 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"time"
+
+	"github.com/shoenig/vaultapi/internal/watch"
+)
+
+// DatabaseConnectorOptions are used to configure a DatabaseConnector.
+type DatabaseConnectorOptions struct {
+	// Mount is where the database secrets engine is mounted.
+	// By default, this value is "database".
+	Mount string
+
+	// Role is the database role used to generate credentials.
+	Role string
+
+	// Driver is the driver of the database, e.g. &pq.Driver{}.
+	Driver driver.Driver
+
+	// DSN builds the data source name passed to the Driver
+	// from the generated credentials.
+	DSN func(username, password string) string
+
+	// Jitter is the fraction of time by which lease renewals are
+	// randomly moved earlier. By default, this value is 0.1.
+	Jitter float64
+}
+
+// A DatabaseConnector is a driver.Connector which opens connections
+// with credentials generated by a database secrets engine, e.g.
+//
+//	db := sql.OpenDB(vaultapi.NewDatabaseConnector(client, opts))
+//
+// Credentials are generated on the first connection, and their lease
+// is renewed in the background. Once the lease can no longer be renewed
+// (e.g. upon reaching its max TTL), new connections are opened with new
+// credentials after 2/3 of the remaining lease. Connections using the
+// old credentials break once vault drops the old user, which is why
+// SetConnMaxLifetime of the sql.DB should be set to less than the
+// max TTL of the role.
+type DatabaseConnector struct {
+	client Client
+	db     Database
+	opts   DatabaseConnectorOptions
+
+	lock    sync.Mutex
+	creds   DatabaseCredentials
+	lease   time.Duration
+	expires time.Time
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewDatabaseConnector creates a DatabaseConnector which generates
+// database credentials with client.
+func NewDatabaseConnector(client Client, opts DatabaseConnectorOptions) *DatabaseConnector {
+	if opts.Mount == "" {
+		opts.Mount = "database"
+	}
+
+	if opts.Jitter <= 0 || opts.Jitter >= 1 {
+		opts.Jitter = watch.DefaultJitter
+	}
+
+	return &DatabaseConnector{
+		client: client,
+		db:     client.DatabaseMount(opts.Mount),
+		opts:   opts,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Connect opens a new connection to the database, using the
+// current credentials.
+func (c *DatabaseConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	creds, err := c.credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	dsn := c.opts.DSN(creds.Username, creds.Password)
+
+	if dc, ok := c.opts.Driver.(driver.DriverContext); ok {
+		connector, err := dc.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return connector.Connect(ctx)
+	}
+
+	return c.opts.Driver.Open(dsn)
+}
+
+// Driver returns the underlying driver of the database.
+func (c *DatabaseConnector) Driver() driver.Driver {
+	return c.opts.Driver
+}
+
+// Close stops renewing the lease of the current credentials, which
+// are left to expire. It is called by the Close method of a sql.DB.
+func (c *DatabaseConnector) Close() error {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+	return nil
+}
+
+// credentials returns the current credentials, generating new
+// credentials if there are none or they are about to expire
+func (c *DatabaseConnector) credentials() (DatabaseCredentials, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.creds.LeaseID != "" && time.Until(c.expires) > c.lease/3 {
+		return c.creds, nil
+	}
+
+	creds, err := c.db.Credentials(c.opts.Role)
+	if err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	c.creds = creds
+	c.lease = time.Duration(creds.LeaseDuration) * time.Second
+	c.expires = time.Now().Add(c.lease)
+
+	if creds.Renewable && c.lease > 0 {
+		go c.renew(creds.LeaseID, c.lease)
+	}
+
+	return creds, nil
+}
+
+// renew renews the lease of the credentials until renewing fails,
+// or until the credentials are replaced
+func (c *DatabaseConnector) renew(id string, lease time.Duration) {
+	for {
+		if !watch.Sleep(watch.RenewDelay(lease, c.opts.Jitter), c.stop) {
+			return
+		}
+
+		renewed, err := c.client.RenewLease(id, 0)
+
+		c.lock.Lock()
+		if c.creds.LeaseID != id {
+			// the credentials have been replaced
+			c.lock.Unlock()
+			return
+		}
+
+		if err != nil {
+			// the credentials will be replaced once they
+			// are close enough to expiring
+			c.lock.Unlock()
+			return
+		}
+
+		lease = time.Duration(renewed.LeaseDuration) * time.Second
+		c.expires = time.Now().Add(lease)
+		c.lock.Unlock()
+
+		if !renewed.Renewable || lease <= 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	generateRootPrefix          = "/v1/sys/generate-root"
+	generateRecoveryTokenPrefix = "/v1/sys/generate-recovery-token"
+)
+
+// A GenerateRootStatus describes the progress of generating a root
+// token. The Nonce identifies the attempt, and must be provided with
+// each unseal key share. Progress of the Required key shares have been
+// provided so far. Once Complete, the EncodedToken is the new root
+// token, encoded with the OTP of the attempt (or encrypted with the
+// PGP key).
+//
+// Newer versions of vault generate the OTP of the attempt, which is
+// returned only by GenerateRootInit, and is OTPLength characters long.
+//
+// More information about generating root tokens can be found here:
+// https://www.vaultproject.io/guides/operations/generate-root.html
+type GenerateRootStatus struct {
+	Nonce          string `json:"nonce"`
+	Started        bool   `json:"started"`
+	Progress       int    `json:"progress"`
+	Required       int    `json:"required"`
+	Complete       bool   `json:"complete"`
+	EncodedToken   string `json:"encoded_token"`
+	PGPFingerprint string `json:"pgp_fingerprint"`
+	OTP            string `json:"otp"`
+	OTPLength      int    `json:"otp_length"`
+}
+
+type generateRootStatusWrapper struct {
+	GenerateRootStatus
+
+	// older versions of vault use a different name
+	EncodedRootToken string `json:"encoded_root_token"`
+}
+
+func (w generateRootStatusWrapper) status() GenerateRootStatus {
+	status := w.GenerateRootStatus
+	if status.EncodedToken == "" {
+		status.EncodedToken = w.EncodedRootToken
+	}
+	return status
+}
+
+func (c *client) GenerateRootStatus() (GenerateRootStatus, error) {
+	return c.generateRootStatus(generateRootPrefix)
+}
+
+// GenerateRootInit starts an attempt to generate a root token. The new
+// token is encrypted with pgpKey if set, and otherwise encoded with an
+// OTP. Newer versions of vault generate the OTP, which is returned in
+// the status, and otp must be empty. Older versions of vault require
+// otp to be set, as 16 random bytes encoded in base64.
+func (c *client) GenerateRootInit(otp, pgpKey string) (GenerateRootStatus, error) {
+	return c.generateRootInit(generateRootPrefix, otp, pgpKey)
+}
+
+func (c *client) GenerateRootCancel() error {
+	return c.generateRootCancel(generateRootPrefix)
+}
+
+// GenerateRootUpdate provides one of the unseal key shares to the
+// attempt to generate a root token identified by nonce.
+func (c *client) GenerateRootUpdate(key, nonce string) (GenerateRootStatus, error) {
+	return c.generateRootUpdate(generateRootPrefix, key, nonce)
+}
+
+// GenerateRecoveryTokenStatus is like GenerateRootStatus, but for an
+// attempt to generate a recovery token, which vault servers using auto
+// unseal accept for a limited set of operations while sealed.
+func (c *client) GenerateRecoveryTokenStatus() (GenerateRootStatus, error) {
+	return c.generateRootStatus(generateRecoveryTokenPrefix)
+}
+
+// GenerateRecoveryTokenInit is like GenerateRootInit, but starts an
+// attempt to generate a recovery token.
+func (c *client) GenerateRecoveryTokenInit(otp, pgpKey string) (GenerateRootStatus, error) {
+	return c.generateRootInit(generateRecoveryTokenPrefix, otp, pgpKey)
+}
+
+func (c *client) GenerateRecoveryTokenCancel() error {
+	return c.generateRootCancel(generateRecoveryTokenPrefix)
+}
+
+// GenerateRecoveryTokenUpdate provides one of the recovery key shares
+// to the attempt to generate a recovery token identified by nonce.
+func (c *client) GenerateRecoveryTokenUpdate(key, nonce string) (GenerateRootStatus, error) {
+	return c.generateRootUpdate(generateRecoveryTokenPrefix, key, nonce)
+}
+
+func (c *client) generateRootStatus(prefix string) (GenerateRootStatus, error) {
+	var wrapper generateRootStatusWrapper
+	if err := c.get(prefix+"/attempt", &wrapper); err != nil {
+		return GenerateRootStatus{}, errors.Wrap(err, "failed to read generate root status")
+	}
+	return wrapper.status(), nil
+}
+
+func (c *client) generateRootInit(prefix, otp, pgpKey string) (GenerateRootStatus, error) {
+	bs, err := json.Marshal(struct {
+		OTP    string `json:"otp,omitempty"`
+		PGPKey string `json:"pgp_key,omitempty"`
+	}{OTP: otp, PGPKey: pgpKey})
+	if err != nil {
+		return GenerateRootStatus{}, err
+	}
+
+	// do not log the request, which contains the otp
+	var wrapper generateRootStatusWrapper
+	if err := c.post(prefix+"/attempt", string(bs), &wrapper); err != nil {
+		return GenerateRootStatus{}, errors.Wrap(err, "failed to start generate root")
+	}
+	return wrapper.status(), nil
+}
+
+func (c *client) generateRootCancel(prefix string) error {
+	if err := c.delete(prefix + "/attempt"); err != nil {
+		return errors.Wrap(err, "failed to cancel generate root")
+	}
+	return nil
+}
+
+func (c *client) generateRootUpdate(prefix, key, nonce string) (GenerateRootStatus, error) {
+	bs, err := json.Marshal(keyShare{Key: key, Nonce: nonce})
+	if err != nil {
+		return GenerateRootStatus{}, err
+	}
+
+	// do not log the request, which contains the key share
+	var wrapper generateRootStatusWrapper
+	if err := c.post(prefix+"/update", string(bs), &wrapper); err != nil {
+		return GenerateRootStatus{}, errors.Wrapf(err, "failed to update generate root %q", nonce)
+	}
+	return wrapper.status(), nil
+}
+
+// DecodeRootToken decodes the encoded root token of a completed attempt
+// to generate a root token (or recovery token), using the OTP of the
+// attempt. Both the
+// OTPs generated by newer versions of vault and the base64 encoded
+// OTPs of older versions of vault are supported.
+func DecodeRootToken(encoded, otp string) (string, error) {
+	token, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode root token")
+	}
+
+	// newer versions of vault xor the token with the otp itself
+	if len(otp) == len(token) {
+		return string(xor(token, []byte(otp))), nil
+	}
+
+	// older versions of vault xor the bytes of a uuid token with
+	// the base64 decoded otp
+	otpBytes, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(otp, "="))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode otp")
+	}
+
+	if len(otpBytes) != len(token) || len(token) != 16 {
+		return "", errors.New("length of otp does not match length of root token")
+	}
+
+	id := xor(token, otpBytes)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16]), nil
+}
+
+func xor(a, b []byte) []byte {
+	result := make([]byte, len(a))
+	for i := range a {
+		result[i] = a[i] ^ b[i]
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DecodeRootToken(t *testing.T) {
+	// newer versions of vault
+	token := "s.3XKnEZ8Gk2ZUFmi9fZr4TjaA"
+	otp := "kRbc1OJ0rbN9zfiA7vtJCpr2pF"
+	encoded := base64.RawStdEncoding.EncodeToString(xor([]byte(token), []byte(otp)))
+
+	decoded, err := DecodeRootToken(encoded, otp)
+	require.NoError(t, err)
+	require.Equal(t, token, decoded)
+
+	// older versions of vault
+	id := []byte{
+		0x9a, 0x4f, 0x2c, 0x11, 0x5e, 0x07, 0x4b, 0x3d,
+		0x8c, 0x21, 0x6d, 0x0e, 0xf3, 0x52, 0xa9, 0x14,
+	}
+	otpBytes := make([]byte, 16)
+	_, err = rand.Read(otpBytes)
+	require.NoError(t, err)
+	encoded = base64.StdEncoding.EncodeToString(xor(id, otpBytes))
+
+	decoded, err = DecodeRootToken(encoded, base64.StdEncoding.EncodeToString(otpBytes))
+	require.NoError(t, err)
+	require.Equal(t, "9a4f2c11-5e07-4b3d-8c21-6d0ef352a914", decoded)
+
+	_, err = DecodeRootToken(encoded, "short")
+	require.Error(t, err)
+}
+
+func Test_Client_GenerateRoot(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	status, err := client.GenerateRootStatus()
+	require.NoError(t, err)
+	require.False(t, status.Started)
+
+	otp := make([]byte, 16)
+	_, err = rand.Read(otp)
+	require.NoError(t, err)
+
+	status, err = client.GenerateRootInit(base64.StdEncoding.EncodeToString(otp), "")
+	require.NoError(t, err)
+	require.True(t, status.Started)
+	require.NotEmpty(t, status.Nonce)
+	require.False(t, status.Complete)
+
+	// the dev server has a single unseal key, which is unknown here
+	err = client.GenerateRootCancel()
+	require.NoError(t, err)
+
+	status, err = client.GenerateRootStatus()
+	require.NoError(t, err)
+	require.False(t, status.Started)
+}
@@ -0,0 +1,179 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GitHubAuth provides a way for users and CI jobs to authenticate
+// with vault by using a GitHub personal access token, and to manage
+// the mappings of GitHub teams and users onto vault policies.
+//
+// The github backend must be enabled at the default path of
+// auth/github for this API to work.
+//
+// More information about the github auth backend can be found here:
+// https://www.vaultproject.io/docs/auth/github.html
+type GitHubAuth interface {
+	LoginGitHub(token string) (CreatedToken, error)
+	ConfigureGitHub(config GitHubConfig) error
+	LookupGitHubConfig() (GitHubConfig, error)
+	MapGitHubTeam(team string, policies []string) error
+	LookupGitHubTeam(team string) ([]string, error)
+	ListGitHubTeams() ([]string, error)
+	DeleteGitHubTeam(team string) error
+	MapGitHubUser(user string, policies []string) error
+	LookupGitHubUser(user string) ([]string, error)
+	ListGitHubUsers() ([]string, error)
+	DeleteGitHubUser(user string) error
+}
+
+type gitHubLogin struct {
+	Token string `json:"token"`
+}
+
+func (c *client) LoginGitHub(token string) (CreatedToken, error) {
+	return c.login("github", "/v1/auth/github/login", gitHubLogin{Token: token})
+}
+
+// GitHubConfig is the configuration of the github auth backend.
+// Organization is required; users must be members of it to login.
+// BaseURL only needs to be set for GitHub Enterprise.
+type GitHubConfig struct {
+	Organization string `json:"organization"`
+	BaseURL      string `json:"base_url,omitempty"`
+	TTL          string `json:"ttl,omitempty"`
+	MaxTTL       string `json:"max_ttl,omitempty"`
+}
+
+func (c *client) ConfigureGitHub(config GitHubConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling github config to JSON request body")
+	}
+
+	if err := c.post("/v1/auth/github/config", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure github auth")
+	}
+
+	return nil
+}
+
+type gitHubConfigWrapper struct {
+	Data struct {
+		Organization string `json:"organization"`
+		BaseURL      string `json:"base_url"`
+		TTL          int    `json:"ttl"`
+		MaxTTL       int    `json:"max_ttl"`
+	} `json:"data"`
+}
+
+func (c *client) LookupGitHubConfig() (GitHubConfig, error) {
+	var wrapper gitHubConfigWrapper
+	if err := c.get("/v1/auth/github/config", &wrapper); err != nil {
+		return GitHubConfig{}, errors.Wrap(err, "failed to read github auth config")
+	}
+	return GitHubConfig{
+		Organization: wrapper.Data.Organization,
+		BaseURL:      wrapper.Data.BaseURL,
+		TTL:          fmt.Sprintf("%ds", wrapper.Data.TTL),
+		MaxTTL:       fmt.Sprintf("%ds", wrapper.Data.MaxTTL),
+	}, nil
+}
+
+// the github backend stores mappings as a comma separated
+// string of policies, which is the value of the mapping
+type gitHubMapping struct {
+	Value string `json:"value"`
+}
+
+type gitHubMappingWrapper struct {
+	Data gitHubMapping `json:"data"`
+}
+
+func (c *client) setGitHubMapping(kind, name string, policies []string) error {
+	bs, err := json.Marshal(gitHubMapping{Value: strings.Join(policies, ",")})
+	if err != nil {
+		return errors.Wrapf(err, "marshalling github %s mapping to JSON request body", kind)
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/github/map/%ss/%s", kind, name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to map github %s %q", kind, name)
+	}
+
+	return nil
+}
+
+func (c *client) lookupGitHubMapping(kind, name string) ([]string, error) {
+	var wrapper gitHubMappingWrapper
+	requestPath := fmt.Sprintf("/v1/auth/github/map/%ss/%s", kind, name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to look up github %s %q", kind, name)
+	}
+
+	var policies []string
+	for _, policy := range strings.Split(wrapper.Data.Value, ",") {
+		if policy = strings.TrimSpace(policy); policy != "" {
+			policies = append(policies, policy)
+		}
+	}
+	sort.Strings(policies)
+	return policies, nil
+}
+
+func (c *client) listGitHubMappings(kind string) ([]string, error) {
+	var wrapper rolesWrapper
+	requestPath := fmt.Sprintf("/v1/auth/github/map/%ss", kind)
+	if err := c.list(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list github %ss at %q", kind, requestPath)
+	}
+	sort.Strings(wrapper.Data.Keys)
+	return wrapper.Data.Keys, nil
+}
+
+func (c *client) deleteGitHubMapping(kind, name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/github/map/%ss/%s", kind, name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete github %s %q", kind, name)
+	}
+	return nil
+}
+
+func (c *client) MapGitHubTeam(team string, policies []string) error {
+	return c.setGitHubMapping("team", team, policies)
+}
+
+func (c *client) LookupGitHubTeam(team string) ([]string, error) {
+	return c.lookupGitHubMapping("team", team)
+}
+
+func (c *client) ListGitHubTeams() ([]string, error) {
+	return c.listGitHubMappings("team")
+}
+
+func (c *client) DeleteGitHubTeam(team string) error {
+	return c.deleteGitHubMapping("team", team)
+}
+
+func (c *client) MapGitHubUser(user string, policies []string) error {
+	return c.setGitHubMapping("user", user, policies)
+}
+
+func (c *client) LookupGitHubUser(user string) ([]string, error) {
+	return c.lookupGitHubMapping("user", user)
+}
+
+func (c *client) ListGitHubUsers() ([]string, error) {
+	return c.listGitHubMappings("user")
+}
+
+func (c *client) DeleteGitHubUser(user string) error {
+	return c.deleteGitHubMapping("user", user)
+}
@@ -1,7 +1,8 @@
 #!/bin/bash
 
-# Start vault in dev mode, which puts a root token in ~/.vault-token
-/tmp/vault server -dev &
+# Start vault in dev mode, which puts a root token in ~/.vault-token,
+# keeping the default secret mount at version 1 of the kv store
+/tmp/vault server -dev -dev-kv-v1 &
 sleep 3
 ps -ef | grep vault
 
@@ -36,11 +37,41 @@ path "auth/token/renew-self" {
 }
 EOF
 
+# Enable the approle and userpass auth backends
+/tmp/vault auth enable approle || exit 1
+/tmp/vault auth enable userpass || exit 1
+
+# Mount a second kv store at kv1
+/tmp/vault secrets enable -path=kv1 -version=1 kv || exit 1
+
+# Mount a versioned kv store at kv2
+/tmp/vault secrets enable -path=kv2 -version=2 kv || exit 1
+
+# Mount the transit secrets engine
+/tmp/vault secrets enable transit || exit 1
+
+# Mount the pki secrets engine with a root CA and an example role
+/tmp/vault secrets enable pki || exit 1
+/tmp/vault secrets tune -max-lease-ttl=87600h pki || exit 1
+/tmp/vault write pki/root/generate/internal common_name=example.com ttl=87600h || exit 1
+/tmp/vault write pki/roles/example-dot-com allowed_domains=example.com allow_subdomains=true max_ttl=72h || exit 1
+
+# Mount empty pki secrets engines for generating CAs
+/tmp/vault secrets enable -path=pki_root pki || exit 1
+/tmp/vault secrets enable -path=pki_int pki || exit 1
+/tmp/vault secrets tune -max-lease-ttl=87600h pki_root || exit 1
+
+# Mount the ssh secrets engine with a CA, and roles for signing keys and otp
+/tmp/vault secrets enable ssh || exit 1
+/tmp/vault write ssh/config/ca generate_signing_key=true || exit 1
+/tmp/vault write ssh/roles/signer key_type=ca allow_user_certificates=true allowed_users="*" default_user=ubuntu ttl=30m || exit 1
+/tmp/vault write ssh/roles/otp key_type=otp default_user=ubuntu cidr_list=127.0.0.0/8 || exit 1
+
 # Write my_policy1 into vault
-/tmp/vault policy-write my_policy1 /tmp/my_policy1 || exit 1
+/tmp/vault policy write my_policy1 /tmp/my_policy1 || exit 1
 
 # Create a non-renewable token based on my_policy1
-my_token1=$(/tmp/vault token-create -policy=my_policy1 -orphan=true -format=json | jq -r .auth.client_token)
+my_token1=$(/tmp/vault token create -policy=my_policy1 -orphan=true -format=json | jq -r .auth.client_token)
 echo ${my_token1} > /tmp/t1.token
 echo "t1 token: $(cat /tmp/t1.token)"
 
@@ -49,6 +80,6 @@ echo "t1 token: $(cat /tmp/t1.token)"
 # Create a renewable token based on my_role which
 # incorporates my_policy1 and allows renewable tokens
 # with a period of 3 hours (10800 seconds).
-my_token2=$(/tmp/vault token-create -role=my_role1 -policy=my_policy1 -orphan=true -format=json | jq -r .auth.client_token)
+my_token2=$(/tmp/vault token create -role=my_role1 -policy=my_policy1 -orphan=true -format=json | jq -r .auth.client_token)
 echo ${my_token2} > /tmp/t2.token
 echo "t2 token: $(cat /tmp/t2.token)"
\ No newline at end of file
@@ -1,6 +1,6 @@
 #!/bin/bash
 
-curl -o /tmp/vault.zip  https://releases.hashicorp.com/vault/0.7.3/vault_0.7.3_linux_amd64.zip
+curl -o /tmp/vault.zip  https://releases.hashicorp.com/vault/1.14.8/vault_1.14.8_linux_amd64.zip
 unzip /tmp/vault.zip -d /tmp
 tree /tmp
 touch /tmp/dev-vault.token
@@ -0,0 +1,109 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"github.com/pkg/errors"
+)
+
+// A HostInfo is a snapshot of the resources of the host of the vault
+// server which served the request, as of Timestamp.
+type HostInfo struct {
+	Timestamp string         `json:"timestamp"`
+	CPU       []HostCPU      `json:"cpu"`
+	CPUTimes  []HostCPUTimes `json:"cpu_times"`
+	Disk      []HostDisk     `json:"disk"`
+	Host      HostDetails    `json:"host"`
+	Memory    HostMemory     `json:"memory"`
+}
+
+// A HostCPU describes a CPU of the host.
+type HostCPU struct {
+	CPU       int     `json:"cpu"`
+	VendorID  string  `json:"vendorId"`
+	Family    string  `json:"family"`
+	Model     string  `json:"model"`
+	ModelName string  `json:"modelName"`
+	Cores     int     `json:"cores"`
+	Mhz       float64 `json:"mhz"`
+}
+
+// HostCPUTimes are the seconds a CPU of the host has spent in each mode.
+type HostCPUTimes struct {
+	CPU     string  `json:"cpu"`
+	User    float64 `json:"user"`
+	System  float64 `json:"system"`
+	Idle    float64 `json:"idle"`
+	Nice    float64 `json:"nice"`
+	Iowait  float64 `json:"iowait"`
+	Irq     float64 `json:"irq"`
+	Softirq float64 `json:"softirq"`
+	Steal   float64 `json:"steal"`
+}
+
+// A HostDisk describes the usage of a disk of the host, in bytes.
+type HostDisk struct {
+	Path        string  `json:"path"`
+	Fstype      string  `json:"fstype"`
+	Total       uint64  `json:"total"`
+	Free        uint64  `json:"free"`
+	Used        uint64  `json:"used"`
+	UsedPercent float64 `json:"usedPercent"`
+}
+
+// HostDetails describe the operating system of the host. The Uptime
+// is in seconds, and the BootTime is in seconds since the epoch.
+type HostDetails struct {
+	Hostname        string `json:"hostname"`
+	Uptime          uint64 `json:"uptime"`
+	BootTime        uint64 `json:"bootTime"`
+	Procs           uint64 `json:"procs"`
+	OS              string `json:"os"`
+	Platform        string `json:"platform"`
+	PlatformFamily  string `json:"platformFamily"`
+	PlatformVersion string `json:"platformVersion"`
+	KernelVersion   string `json:"kernelVersion"`
+	KernelArch      string `json:"kernelArch"`
+	HostID          string `json:"hostid"`
+}
+
+// HostMemory describes the usage of the memory of the host, in bytes.
+type HostMemory struct {
+	Total       uint64  `json:"total"`
+	Available   uint64  `json:"available"`
+	Used        uint64  `json:"used"`
+	Free        uint64  `json:"free"`
+	UsedPercent float64 `json:"usedPercent"`
+}
+
+// HostInfo reads the resources of the host of the vault server,
+// which requires a root token, or sudo capability on sys/host-info.
+func (c *client) HostInfo() (HostInfo, error) {
+	var wrapper struct {
+		Data HostInfo `json:"data"`
+	}
+	if err := c.get("/v1/sys/host-info", &wrapper); err != nil {
+		return HostInfo{}, errors.Wrap(err, "failed to read host info")
+	}
+	return wrapper.Data, nil
+}
+
+// An InFlightRequest is a request currently being served by vault.
+type InFlightRequest struct {
+	StartTime           string `json:"start_time"`
+	ClientRemoteAddress string `json:"client_remote_address"`
+	ClientID            string `json:"client_id"`
+	RequestPath         string `json:"request_path"`
+	RequestMethod       string `json:"request_method"`
+}
+
+// InFlightRequests returns the requests currently being served by
+// the vault server, keyed by the ID of each request.
+func (c *client) InFlightRequests() (map[string]InFlightRequest, error) {
+	// the response is not wrapped in data
+	var requests map[string]InFlightRequest
+	if err := c.get("/v1/sys/in-flight-req", &requests); err != nil {
+		return nil, errors.Wrap(err, "failed to read in-flight requests")
+	}
+	return requests, nil
+}
@@ -0,0 +1,303 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// An Identity represents the identity secrets engine of vault, which
+// tracks the clients of vault as entities across auth methods.
+//
+// More information about the identity secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/identity/index.html
+type Identity interface {
+	// Entities
+	CreateEntity(opts EntityOptions) (string, error)
+	UpdateEntity(id string, opts EntityOptions) error
+	LookupEntity(id string) (Entity, error)
+	LookupEntityByName(name string) (Entity, error)
+	ListEntities() ([]string, error)
+	DeleteEntity(id string) error
+	MergeEntities(to string, from []string, force bool) error
+
+	// Entity Aliases
+	CreateEntityAlias(opts EntityAliasOptions) (string, error)
+	UpdateEntityAlias(id string, opts EntityAliasOptions) error
+	LookupEntityAlias(id string) (EntityAlias, error)
+	ListEntityAliases() ([]string, error)
+	DeleteEntityAlias(id string) error
+
+	// Identity Tokens
+	ConfigureIdentityTokens(config IdentityTokenConfig) error
+	LookupIdentityTokenConfig() (IdentityTokenConfig, error)
+	CreateIdentityTokenKey(opts IdentityTokenKeyOptions) error
+	LookupIdentityTokenKey(name string) (LookedUpIdentityTokenKey, error)
+	ListIdentityTokenKeys() ([]string, error)
+	DeleteIdentityTokenKey(name string) error
+	RotateIdentityTokenKey(name, verificationTTL string) error
+	CreateIdentityTokenRole(opts IdentityTokenRoleOptions) error
+	LookupIdentityTokenRole(name string) (LookedUpIdentityTokenRole, error)
+	ListIdentityTokenRoles() ([]string, error)
+	DeleteIdentityTokenRole(name string) error
+	GenerateIdentityToken(role string) (IdentityToken, error)
+	IntrospectIdentityToken(token, clientID string) (IntrospectedIdentityToken, error)
+
+	// OIDC Provider
+	CreateOIDCProvider(provider OIDCProvider) error
+	LookupOIDCProvider(name string) (OIDCProvider, error)
+	ListOIDCProviders() ([]string, error)
+	DeleteOIDCProvider(name string) error
+	OIDCProviderDiscovery(name string) (OIDCDiscovery, error)
+	CreateOIDCClient(opts OIDCClientOptions) error
+	LookupOIDCClient(name string) (LookedUpOIDCClient, error)
+	ListOIDCClients() ([]string, error)
+	DeleteOIDCClient(name string) error
+	CreateOIDCScope(scope OIDCScope) error
+	LookupOIDCScope(name string) (OIDCScope, error)
+	ListOIDCScopes() ([]string, error)
+	DeleteOIDCScope(name string) error
+	CreateOIDCAssignment(assignment OIDCAssignment) error
+	LookupOIDCAssignment(name string) (OIDCAssignment, error)
+	ListOIDCAssignments() ([]string, error)
+	DeleteOIDCAssignment(name string) error
+
+	// MFA
+	CreateMFAMethod(opts MFAMethodOptions) (string, error)
+	UpdateMFAMethod(id string, opts MFAMethodOptions) error
+	LookupMFAMethod(id string) (MFAMethod, error)
+	ListMFAMethods() ([]string, error)
+	DeleteMFAMethod(methodType, id string) error
+	GenerateTOTPSecret(methodID, entityID string) (TOTPSecret, error)
+	DestroyTOTPSecret(methodID, entityID string) error
+	CreateMFALoginEnforcement(enforcement MFALoginEnforcement) error
+	LookupMFALoginEnforcement(name string) (MFALoginEnforcement, error)
+	ListMFALoginEnforcements() ([]string, error)
+	DeleteMFALoginEnforcement(name string) error
+}
+
+// EntityOptions are used to define properties of an entity being
+// created or updated. The Policies are granted to tokens of the
+// entity, in addition to those of the token itself. A Disabled entity
+// cannot use its tokens.
+type EntityOptions struct {
+	Name     string            `json:"name,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Policies []string          `json:"policies,omitempty"`
+	Disabled bool              `json:"disabled"`
+}
+
+// An Entity represents a client of vault, which may log in with
+// any of its aliases.
+type Entity struct {
+	ID                string            `json:"id"`
+	Name              string            `json:"name"`
+	Metadata          map[string]string `json:"metadata"`
+	Policies          []string          `json:"policies"`
+	Disabled          bool              `json:"disabled"`
+	Aliases           []EntityAlias     `json:"aliases"`
+	DirectGroupIDs    []string          `json:"direct_group_ids"`
+	GroupIDs          []string          `json:"group_ids"`
+	InheritedGroupIDs []string          `json:"inherited_group_ids"`
+	CreationTime      string            `json:"creation_time"`
+	LastUpdateTime    string            `json:"last_update_time"`
+}
+
+// An EntityAlias maps an account of an auth method, such as a
+// user of the userpass auth method, to an Entity. The MountAccessor
+// identifies the mount of the auth method.
+type EntityAlias struct {
+	ID             string            `json:"id"`
+	CanonicalID    string            `json:"canonical_id"`
+	Name           string            `json:"name"`
+	MountAccessor  string            `json:"mount_accessor"`
+	MountPath      string            `json:"mount_path"`
+	MountType      string            `json:"mount_type"`
+	Metadata       map[string]string `json:"metadata"`
+	CreationTime   string            `json:"creation_time"`
+	LastUpdateTime string            `json:"last_update_time"`
+}
+
+type entityWrapper struct {
+	Data Entity `json:"data"`
+}
+
+// CreateEntity creates a new entity, and returns its generated ID.
+func (c *client) CreateEntity(opts EntityOptions) (string, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling entity data to JSON request body")
+	}
+
+	var wrapper entityWrapper
+	requestPath := "/v1/identity/entity"
+	c.logRequest("entity-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return "", errors.Wrapf(err, "creating entity %q", opts.Name)
+	}
+
+	return wrapper.Data.ID, nil
+}
+
+// UpdateEntity replaces the properties of the entity with the
+// given ID. Properties which are not set are cleared.
+func (c *client) UpdateEntity(id string, opts EntityOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling entity data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/entity/id/%s", id)
+	c.logRequest("entity-update", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "updating entity %q", id)
+	}
+
+	return nil
+}
+
+func (c *client) LookupEntity(id string) (Entity, error) {
+	var wrapper entityWrapper
+	requestPath := fmt.Sprintf("/v1/identity/entity/id/%s", id)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return Entity{}, errors.Wrapf(err, "failed to look up entity %q", id)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) LookupEntityByName(name string) (Entity, error) {
+	var wrapper entityWrapper
+	requestPath := fmt.Sprintf("/v1/identity/entity/name/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return Entity{}, errors.Wrapf(err, "failed to look up entity named %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+// ListEntities lists the names of all entities.
+func (c *client) ListEntities() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/entity/name"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list entities at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+// DeleteEntity deletes the entity with the given ID,
+// along with all of its aliases.
+func (c *client) DeleteEntity(id string) error {
+	requestPath := fmt.Sprintf("/v1/identity/entity/id/%s", id)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete entity %q", id)
+	}
+	return nil
+}
+
+type entityMerge struct {
+	FromEntityIDs []string `json:"from_entity_ids"`
+	ToEntityID    string   `json:"to_entity_id"`
+	Force         bool     `json:"force,omitempty"`
+}
+
+// MergeEntities merges the entities with the from IDs into the entity
+// with the to ID, which takes over their aliases. The from entities
+// are deleted. Merging fails if the entities have aliases on the same
+// mount, unless force is set.
+func (c *client) MergeEntities(to string, from []string, force bool) error {
+	bs, err := json.Marshal(entityMerge{
+		FromEntityIDs: from,
+		ToEntityID:    to,
+		Force:         force,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.post("/v1/identity/entity/merge", string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to merge entities %v into %q", from, to)
+	}
+
+	return nil
+}
+
+// EntityAliasOptions are used to define properties of an entity alias
+// being created or updated. The Name is the name of the account in the
+// auth method mounted with MountAccessor, e.g. the username for the
+// userpass auth method. The CanonicalID is the ID of the Entity the
+// alias belongs to.
+type EntityAliasOptions struct {
+	Name          string `json:"name"`
+	CanonicalID   string `json:"canonical_id"`
+	MountAccessor string `json:"mount_accessor"`
+}
+
+type entityAliasWrapper struct {
+	Data EntityAlias `json:"data"`
+}
+
+// CreateEntityAlias creates a new entity alias, and returns its
+// generated ID.
+func (c *client) CreateEntityAlias(opts EntityAliasOptions) (string, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling entity alias data to JSON request body")
+	}
+
+	var wrapper entityAliasWrapper
+	requestPath := "/v1/identity/entity-alias"
+	c.logRequest("entity-alias-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return "", errors.Wrapf(err, "creating entity alias %q", opts.Name)
+	}
+
+	return wrapper.Data.ID, nil
+}
+
+func (c *client) UpdateEntityAlias(id string, opts EntityAliasOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling entity alias data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/entity-alias/id/%s", id)
+	c.logRequest("entity-alias-update", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "updating entity alias %q", id)
+	}
+
+	return nil
+}
+
+func (c *client) LookupEntityAlias(id string) (EntityAlias, error) {
+	var wrapper entityAliasWrapper
+	requestPath := fmt.Sprintf("/v1/identity/entity-alias/id/%s", id)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return EntityAlias{}, errors.Wrapf(err, "failed to look up entity alias %q", id)
+	}
+	return wrapper.Data, nil
+}
+
+// ListEntityAliases lists the IDs of all entity aliases.
+func (c *client) ListEntityAliases() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/entity-alias/id"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list entity aliases at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteEntityAlias(id string) error {
+	requestPath := fmt.Sprintf("/v1/identity/entity-alias/id/%s", id)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete entity alias %q", id)
+	}
+	return nil
+}
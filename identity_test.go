@@ -0,0 +1,140 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_Entities(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	id, err := client.CreateEntity(EntityOptions{
+		Name:     "alice",
+		Metadata: map[string]string{"team": "ops"},
+		Policies: []string{"default"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	entity, err := client.LookupEntity(id)
+	require.NoError(t, err)
+	require.Equal(t, "alice", entity.Name)
+	require.Equal(t, map[string]string{"team": "ops"}, entity.Metadata)
+	require.Equal(t, []string{"default"}, entity.Policies)
+	require.False(t, entity.Disabled)
+
+	err = client.UpdateEntity(id, EntityOptions{
+		Name:     "alice",
+		Policies: []string{"default", "my_policy1"},
+		Disabled: true,
+	})
+	require.NoError(t, err)
+
+	entity, err = client.LookupEntityByName("alice")
+	require.NoError(t, err)
+	require.Equal(t, id, entity.ID)
+	require.Equal(t, []string{"default", "my_policy1"}, entity.Policies)
+	require.True(t, entity.Disabled)
+
+	names, err := client.ListEntities()
+	require.NoError(t, err)
+	require.Contains(t, names, "alice")
+
+	require.NoError(t, client.DeleteEntity(id))
+
+	_, err = client.LookupEntity(id)
+	require.True(t, errors.Is(err, ErrPathNotFound))
+}
+
+func Test_Client_MergeEntities(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	to, err := client.CreateEntity(EntityOptions{Name: "merge-to"})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.DeleteEntity(to))
+	}()
+
+	from, err := client.CreateEntity(EntityOptions{Name: "merge-from"})
+	require.NoError(t, err)
+
+	mounts, err := client.ListAuthMounts()
+	require.NoError(t, err)
+
+	aliasID, err := client.CreateEntityAlias(EntityAliasOptions{
+		Name:          "merged",
+		CanonicalID:   from,
+		MountAccessor: mounts["userpass/"].Accessor,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.MergeEntities(to, []string{from}, false))
+
+	// the from entity is deleted, and its aliases moved over
+	_, err = client.LookupEntity(from)
+	require.True(t, errors.Is(err, ErrPathNotFound))
+
+	alias, err := client.LookupEntityAlias(aliasID)
+	require.NoError(t, err)
+	require.Equal(t, to, alias.CanonicalID)
+}
+
+func Test_Client_EntityAliases(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	mounts, err := client.ListAuthMounts()
+	require.NoError(t, err)
+	accessor := mounts["userpass/"].Accessor
+	require.NotEmpty(t, accessor)
+
+	entityID, err := client.CreateEntity(EntityOptions{Name: "bob"})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.DeleteEntity(entityID))
+	}()
+
+	id, err := client.CreateEntityAlias(EntityAliasOptions{
+		Name:          "bob",
+		CanonicalID:   entityID,
+		MountAccessor: accessor,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	alias, err := client.LookupEntityAlias(id)
+	require.NoError(t, err)
+	require.Equal(t, "bob", alias.Name)
+	require.Equal(t, entityID, alias.CanonicalID)
+	require.Equal(t, accessor, alias.MountAccessor)
+	require.Equal(t, "userpass", alias.MountType)
+
+	// the aliases of an entity are included when looking it up
+	entity, err := client.LookupEntity(entityID)
+	require.NoError(t, err)
+	require.Len(t, entity.Aliases, 1)
+	require.Equal(t, id, entity.Aliases[0].ID)
+
+	err = client.UpdateEntityAlias(id, EntityAliasOptions{
+		Name:          "robert",
+		CanonicalID:   entityID,
+		MountAccessor: accessor,
+	})
+	require.NoError(t, err)
+
+	alias, err = client.LookupEntityAlias(id)
+	require.NoError(t, err)
+	require.Equal(t, "robert", alias.Name)
+
+	ids, err := client.ListEntityAliases()
+	require.NoError(t, err)
+	require.Contains(t, ids, id)
+
+	require.NoError(t, client.DeleteEntityAlias(id))
+
+	_, err = client.LookupEntityAlias(id)
+	require.True(t, errors.Is(err, ErrPathNotFound))
+}
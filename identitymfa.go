@@ -0,0 +1,279 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// The types of MFA methods supported by vault.
+const (
+	MFAMethodTOTP   = "totp"
+	MFAMethodDuo    = "duo"
+	MFAMethodOkta   = "okta"
+	MFAMethodPingID = "pingid"
+)
+
+// MFAMethodOptions are used to define properties of an MFA method being
+// created or updated. The options of each type of MFA method are
+// TOTPMethodOptions, DuoMethodOptions, OktaMethodOptions, and
+// PingIDMethodOptions.
+type MFAMethodOptions interface {
+	methodType() string
+}
+
+// TOTPMethodOptions are used to define properties of a TOTP MFA method,
+// where vault generates a secret per entity for use with authenticator
+// apps. Period is a duration expressed as a string that vault
+// understands, such as "30s".
+type TOTPMethodOptions struct {
+	MethodName            string `json:"method_name,omitempty"`
+	Issuer                string `json:"issuer"`
+	Period                string `json:"period,omitempty"`
+	KeySize               int    `json:"key_size,omitempty"`
+	QRSize                int    `json:"qr_size,omitempty"`
+	Algorithm             string `json:"algorithm,omitempty"`
+	Digits                int    `json:"digits,omitempty"`
+	Skew                  int    `json:"skew,omitempty"`
+	MaxValidationAttempts int    `json:"max_validation_attempts,omitempty"`
+}
+
+func (TOTPMethodOptions) methodType() string { return MFAMethodTOTP }
+
+// DuoMethodOptions are used to define properties of a Duo MFA method.
+// The UsernameFormat is a template of the Duo username of an entity,
+// e.g. "{{identity.entity.name}}".
+type DuoMethodOptions struct {
+	MethodName     string `json:"method_name,omitempty"`
+	UsernameFormat string `json:"username_format,omitempty"`
+	SecretKey      string `json:"secret_key"`
+	IntegrationKey string `json:"integration_key"`
+	APIHostname    string `json:"api_hostname"`
+	PushInfo       string `json:"push_info,omitempty"`
+	UsePasscode    bool   `json:"use_passcode"`
+}
+
+func (DuoMethodOptions) methodType() string { return MFAMethodDuo }
+
+// OktaMethodOptions are used to define properties of an Okta MFA
+// method, which uses the Okta organization OrgName, or the Okta
+// instance at BaseURL.
+type OktaMethodOptions struct {
+	MethodName     string `json:"method_name,omitempty"`
+	UsernameFormat string `json:"username_format,omitempty"`
+	OrgName        string `json:"org_name"`
+	APIToken       string `json:"api_token"`
+	BaseURL        string `json:"base_url,omitempty"`
+	PrimaryEmail   bool   `json:"primary_email"`
+}
+
+func (OktaMethodOptions) methodType() string { return MFAMethodOkta }
+
+// PingIDMethodOptions are used to define properties of a PingID MFA
+// method, configured by the base64 encoded settings file downloaded
+// from PingID.
+type PingIDMethodOptions struct {
+	MethodName         string `json:"method_name,omitempty"`
+	UsernameFormat     string `json:"username_format,omitempty"`
+	SettingsFileBase64 string `json:"settings_file_base64"`
+}
+
+func (PingIDMethodOptions) methodType() string { return MFAMethodPingID }
+
+// CreateMFAMethod creates a new MFA method, and returns its generated ID.
+func (c *client) CreateMFAMethod(opts MFAMethodOptions) (string, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling mfa method data to JSON request body")
+	}
+
+	// do not log the request, which contains the keys of the method
+	var wrapper struct {
+		Data struct {
+			MethodID string `json:"method_id"`
+		} `json:"data"`
+	}
+	requestPath := fmt.Sprintf("/v1/identity/mfa/method/%s", opts.methodType())
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return "", errors.Wrapf(err, "creating mfa method at %q", requestPath)
+	}
+
+	return wrapper.Data.MethodID, nil
+}
+
+func (c *client) UpdateMFAMethod(id string, opts MFAMethodOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling mfa method data to JSON request body")
+	}
+
+	// do not log the request, which contains the keys of the method
+	requestPath := fmt.Sprintf("/v1/identity/mfa/method/%s/%s", opts.methodType(), id)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "updating mfa method %q", id)
+	}
+
+	return nil
+}
+
+// An MFAMethod represents information returned from vault after making
+// a request for information about a particular MFA method. The Settings
+// are the complete configuration of the method, which varies by Type.
+type MFAMethod struct {
+	ID       string                 `json:"id"`
+	Type     string                 `json:"type"`
+	Name     string                 `json:"name"`
+	Settings map[string]interface{} `json:"-"`
+}
+
+func (c *client) LookupMFAMethod(id string) (MFAMethod, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+	requestPath := fmt.Sprintf("/v1/identity/mfa/method/%s", id)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return MFAMethod{}, errors.Wrapf(err, "failed to look up mfa method %q", id)
+	}
+
+	var method MFAMethod
+	if err := json.Unmarshal(wrapper.Data, &method); err != nil {
+		return MFAMethod{}, errors.Wrapf(err, "failed to decode mfa method %q", id)
+	}
+	if err := json.Unmarshal(wrapper.Data, &method.Settings); err != nil {
+		return MFAMethod{}, errors.Wrapf(err, "failed to decode mfa method %q", id)
+	}
+
+	return method, nil
+}
+
+// ListMFAMethods lists the IDs of all MFA methods, of every type.
+func (c *client) ListMFAMethods() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/mfa/method"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list mfa methods at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+// DeleteMFAMethod deletes the MFA method of methodType (e.g.
+// MFAMethodTOTP) with the given ID.
+func (c *client) DeleteMFAMethod(methodType, id string) error {
+	requestPath := fmt.Sprintf("/v1/identity/mfa/method/%s/%s", methodType, id)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete mfa method %q", id)
+	}
+	return nil
+}
+
+// A TOTPSecret is a TOTP secret generated for an entity, as a URL
+// understood by authenticator apps, and as a base64 encoded PNG
+// Barcode of the URL.
+type TOTPSecret struct {
+	Barcode string `json:"barcode"`
+	URL     string `json:"url"`
+}
+
+type totpAdminRequest struct {
+	MethodID string `json:"method_id"`
+	EntityID string `json:"entity_id"`
+}
+
+// GenerateTOTPSecret generates a TOTP secret of the TOTP method with
+// methodID for the entity with entityID. The entity must not
+// already have a secret for the method.
+func (c *client) GenerateTOTPSecret(methodID, entityID string) (TOTPSecret, error) {
+	bs, err := json.Marshal(totpAdminRequest{MethodID: methodID, EntityID: entityID})
+	if err != nil {
+		return TOTPSecret{}, err
+	}
+
+	var wrapper struct {
+		Data TOTPSecret `json:"data"`
+	}
+	if err := c.post("/v1/identity/mfa/method/totp/admin-generate", string(bs), &wrapper); err != nil {
+		return TOTPSecret{}, errors.Wrapf(err, "failed to generate totp secret for entity %q", entityID)
+	}
+
+	return wrapper.Data, nil
+}
+
+// DestroyTOTPSecret destroys the TOTP secret of the TOTP method with
+// methodID for the entity with entityID.
+func (c *client) DestroyTOTPSecret(methodID, entityID string) error {
+	bs, err := json.Marshal(totpAdminRequest{MethodID: methodID, EntityID: entityID})
+	if err != nil {
+		return err
+	}
+
+	if err := c.post("/v1/identity/mfa/method/totp/admin-destroy", string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to destroy totp secret for entity %q", entityID)
+	}
+
+	return nil
+}
+
+// An MFALoginEnforcement requires logins to pass the MFA methods with
+// MFAMethodIDs. It applies to logins with the auth methods of the given
+// accessors or types, and to logins of the given entities or members
+// of the given groups.
+type MFALoginEnforcement struct {
+	Name                string   `json:"-"`
+	MFAMethodIDs        []string `json:"mfa_method_ids"`
+	AuthMethodAccessors []string `json:"auth_method_accessors,omitempty"`
+	AuthMethodTypes     []string `json:"auth_method_types,omitempty"`
+	IdentityGroupIDs    []string `json:"identity_group_ids,omitempty"`
+	IdentityEntityIDs   []string `json:"identity_entity_ids,omitempty"`
+}
+
+type mfaLoginEnforcementWrapper struct {
+	Data MFALoginEnforcement `json:"data"`
+}
+
+func (c *client) CreateMFALoginEnforcement(enforcement MFALoginEnforcement) error {
+	bs, err := json.Marshal(enforcement)
+	if err != nil {
+		return errors.Wrap(err, "marshalling mfa login enforcement data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/mfa/login-enforcement/%s", enforcement.Name)
+	c.logRequest("mfa-login-enforcement-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating mfa login enforcement at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (c *client) LookupMFALoginEnforcement(name string) (MFALoginEnforcement, error) {
+	var wrapper mfaLoginEnforcementWrapper
+	requestPath := fmt.Sprintf("/v1/identity/mfa/login-enforcement/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return MFALoginEnforcement{}, errors.Wrapf(err, "failed to look up mfa login enforcement %q", name)
+	}
+	wrapper.Data.Name = name
+	return wrapper.Data, nil
+}
+
+func (c *client) ListMFALoginEnforcements() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/mfa/login-enforcement"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list mfa login enforcements at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteMFALoginEnforcement(name string) error {
+	requestPath := fmt.Sprintf("/v1/identity/mfa/login-enforcement/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete mfa login enforcement %q", name)
+	}
+	return nil
+}
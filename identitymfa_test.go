@@ -0,0 +1,176 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_MFAMethods(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	id, err := client.CreateMFAMethod(TOTPMethodOptions{
+		MethodName: "test-totp1",
+		Issuer:     "vaultapi",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+	defer func() {
+		require.NoError(t, client.DeleteMFAMethod(MFAMethodTOTP, id))
+	}()
+
+	method, err := client.LookupMFAMethod(id)
+	require.NoError(t, err)
+	require.Equal(t, id, method.ID)
+	require.Equal(t, MFAMethodTOTP, method.Type)
+	require.Equal(t, "test-totp1", method.Name)
+	require.Equal(t, "vaultapi", method.Settings["issuer"])
+
+	ids, err := client.ListMFAMethods()
+	require.NoError(t, err)
+	require.Contains(t, ids, id)
+
+	entityID, err := client.CreateEntity(EntityOptions{Name: "test-totp-entity1"})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.DeleteEntity(entityID))
+	}()
+
+	secret, err := client.GenerateTOTPSecret(id, entityID)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret.URL, "otpauth://totp/vaultapi:"))
+	require.NotEmpty(t, secret.Barcode)
+	require.NoError(t, client.DestroyTOTPSecret(id, entityID))
+
+	// only enforced on the entity, so that other logins are unaffected
+	err = client.CreateMFALoginEnforcement(MFALoginEnforcement{
+		Name:              "test-enforcement1",
+		MFAMethodIDs:      []string{id},
+		IdentityEntityIDs: []string{entityID},
+	})
+	require.NoError(t, err)
+
+	enforcement, err := client.LookupMFALoginEnforcement("test-enforcement1")
+	require.NoError(t, err)
+	require.Equal(t, "test-enforcement1", enforcement.Name)
+	require.Equal(t, []string{id}, enforcement.MFAMethodIDs)
+	require.Equal(t, []string{entityID}, enforcement.IdentityEntityIDs)
+
+	names, err := client.ListMFALoginEnforcements()
+	require.NoError(t, err)
+	require.Contains(t, names, "test-enforcement1")
+
+	require.NoError(t, client.DeleteMFALoginEnforcement("test-enforcement1"))
+}
+
+func Test_client_MFAMethods(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/identity/mfa/method/duo":      `{"data":{"method_id":"m1"}}`,
+		"POST /v1/identity/mfa/method/duo/m1":   "",
+		"GET /v1/identity/mfa/method/m1":        `{"data":{"id":"m1","type":"duo","name":"push","api_hostname":"api-1.duosecurity.com","use_passcode":false}}`,
+		"LIST /v1/identity/mfa/method":          `{"data":{"keys":["m2","m1"]}}`,
+		"DELETE /v1/identity/mfa/method/duo/m1": "",
+		"POST /v1/identity/mfa/method/okta":     `{"data":{"method_id":"m2"}}`,
+		"POST /v1/identity/mfa/method/pingid":   `{"data":{"method_id":"m3"}}`,
+	})
+
+	// the type of the options selects the path of the method
+	id, err := vault.CreateMFAMethod(DuoMethodOptions{
+		MethodName:     "push",
+		SecretKey:      "secret",
+		IntegrationKey: "integration",
+		APIHostname:    "api-1.duosecurity.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "m1", id)
+	require.Equal(t,
+		`{"method_name":"push","secret_key":"secret","integration_key":"integration","api_hostname":"api-1.duosecurity.com","use_passcode":false}`,
+		vault.requests[0].Body,
+	)
+
+	id, err = vault.CreateMFAMethod(OktaMethodOptions{OrgName: "example", APIToken: "token"})
+	require.NoError(t, err)
+	require.Equal(t, "m2", id)
+
+	id, err = vault.CreateMFAMethod(PingIDMethodOptions{SettingsFileBase64: "c2V0dGluZ3M="})
+	require.NoError(t, err)
+	require.Equal(t, "m3", id)
+
+	err = vault.UpdateMFAMethod("m1", DuoMethodOptions{
+		SecretKey:      "secret",
+		IntegrationKey: "integration",
+		APIHostname:    "api-1.duosecurity.com",
+		UsePasscode:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "/v1/identity/mfa/method/duo/m1", vault.requests[3].URI)
+
+	// the settings of the method vary by its type
+	method, err := vault.LookupMFAMethod("m1")
+	require.NoError(t, err)
+	require.Equal(t, "m1", method.ID)
+	require.Equal(t, MFAMethodDuo, method.Type)
+	require.Equal(t, "push", method.Name)
+	require.Equal(t, "api-1.duosecurity.com", method.Settings["api_hostname"])
+	require.Equal(t, false, method.Settings["use_passcode"])
+
+	ids, err := vault.ListMFAMethods()
+	require.NoError(t, err)
+	require.Equal(t, []string{"m1", "m2"}, ids)
+
+	require.NoError(t, vault.DeleteMFAMethod(MFAMethodDuo, "m1"))
+}
+
+func Test_client_TOTPSecrets(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/identity/mfa/method/totp/admin-generate": `{"data":{"barcode":"iVBORw0KGgo=","url":"otpauth://totp/vaultapi:alice?secret=ABC"}}`,
+		"POST /v1/identity/mfa/method/totp/admin-destroy":  "",
+	})
+
+	secret, err := vault.GenerateTOTPSecret("m1", "e1")
+	require.NoError(t, err)
+	require.Equal(t, TOTPSecret{
+		Barcode: "iVBORw0KGgo=",
+		URL:     "otpauth://totp/vaultapi:alice?secret=ABC",
+	}, secret)
+
+	require.NoError(t, vault.DestroyTOTPSecret("m1", "e1"))
+
+	for _, request := range vault.requests {
+		require.Equal(t, `{"method_id":"m1","entity_id":"e1"}`, request.Body)
+	}
+}
+
+func Test_client_MFALoginEnforcements(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/identity/mfa/login-enforcement/ops":   "",
+		"GET /v1/identity/mfa/login-enforcement/ops":    `{"data":{"mfa_method_ids":["m1"],"auth_method_types":["userpass"]}}`,
+		"LIST /v1/identity/mfa/login-enforcement":       `{"data":{"keys":["ops"]}}`,
+		"DELETE /v1/identity/mfa/login-enforcement/ops": "",
+	})
+
+	err := vault.CreateMFALoginEnforcement(MFALoginEnforcement{
+		Name:            "ops",
+		MFAMethodIDs:    []string{"m1"},
+		AuthMethodTypes: []string{"userpass"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"mfa_method_ids":["m1"],"auth_method_types":["userpass"]}`, vault.requests[0].Body)
+
+	enforcement, err := vault.LookupMFALoginEnforcement("ops")
+	require.NoError(t, err)
+	require.Equal(t, MFALoginEnforcement{
+		Name:            "ops",
+		MFAMethodIDs:    []string{"m1"},
+		AuthMethodTypes: []string{"userpass"},
+	}, enforcement)
+
+	names, err := vault.ListMFALoginEnforcements()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ops"}, names)
+
+	require.NoError(t, vault.DeleteMFALoginEnforcement("ops"))
+}
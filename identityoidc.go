@@ -0,0 +1,524 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// IdentityTokenConfig is the configuration of identity tokens. The
+// Issuer is the URL used as the iss claim of tokens, which defaults
+// to the api address of vault.
+type IdentityTokenConfig struct {
+	Issuer string `json:"issuer"`
+}
+
+type identityTokenConfigWrapper struct {
+	Data IdentityTokenConfig `json:"data"`
+}
+
+func (c *client) ConfigureIdentityTokens(config IdentityTokenConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	c.logRequest("identity-token-config", "/v1/identity/oidc/config", bs)
+
+	if err := c.post("/v1/identity/oidc/config", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure identity tokens")
+	}
+
+	return nil
+}
+
+func (c *client) LookupIdentityTokenConfig() (IdentityTokenConfig, error) {
+	var wrapper identityTokenConfigWrapper
+	if err := c.get("/v1/identity/oidc/config", &wrapper); err != nil {
+		return IdentityTokenConfig{}, errors.Wrap(err, "failed to read identity token config")
+	}
+	return wrapper.Data, nil
+}
+
+// IdentityTokenKeyOptions are used to define properties of a named key
+// being created or updated, which signs identity tokens. Only roles
+// with a client ID in AllowedClientIDs may use the key, where "*"
+// allows all roles. Durations are expressed as strings that vault
+// understands, such as "24h".
+type IdentityTokenKeyOptions struct {
+	Name             string   `json:"-"`
+	RotationPeriod   string   `json:"rotation_period,omitempty"`
+	VerificationTTL  string   `json:"verification_ttl,omitempty"`
+	AllowedClientIDs []string `json:"allowed_client_ids,omitempty"`
+	Algorithm        string   `json:"algorithm,omitempty"`
+}
+
+func (c *client) CreateIdentityTokenKey(opts IdentityTokenKeyOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling identity token key data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/oidc/key/%s", opts.Name)
+	c.logRequest("identity-token-key-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating identity token key at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpIdentityTokenKeyWrapper struct {
+	Data LookedUpIdentityTokenKey `json:"data"`
+}
+
+// A LookedUpIdentityTokenKey represents information returned from vault
+// after making a request for information about a particular named key.
+type LookedUpIdentityTokenKey struct {
+	RotationPeriod   int      `json:"rotation_period"`
+	VerificationTTL  int      `json:"verification_ttl"`
+	AllowedClientIDs []string `json:"allowed_client_ids"`
+	Algorithm        string   `json:"algorithm"`
+}
+
+func (c *client) LookupIdentityTokenKey(name string) (LookedUpIdentityTokenKey, error) {
+	var wrapper lookedUpIdentityTokenKeyWrapper
+	requestPath := fmt.Sprintf("/v1/identity/oidc/key/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpIdentityTokenKey{}, errors.Wrapf(err, "failed to look up identity token key %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListIdentityTokenKeys() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/oidc/key"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list identity token keys at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+// DeleteIdentityTokenKey deletes the named key, which must not
+// be in use by any role.
+func (c *client) DeleteIdentityTokenKey(name string) error {
+	requestPath := fmt.Sprintf("/v1/identity/oidc/key/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete identity token key %q", name)
+	}
+	return nil
+}
+
+// RotateIdentityTokenKey rotates the named key. The previous version of
+// the key remains available to verify tokens for the verificationTTL,
+// or the configured verification TTL of the key if empty.
+func (c *client) RotateIdentityTokenKey(name, verificationTTL string) error {
+	bs, err := json.Marshal(struct {
+		VerificationTTL string `json:"verification_ttl,omitempty"`
+	}{VerificationTTL: verificationTTL})
+	if err != nil {
+		return err
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/oidc/key/%s/rotate", name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to rotate identity token key %q", name)
+	}
+
+	return nil
+}
+
+// IdentityTokenRoleOptions are used to define properties of a role
+// being created or updated, which generates identity tokens signed by
+// the named Key. The Template is a JSON template of additional claims,
+// e.g. `{"groups": {{identity.entity.groups.names}}}`. Durations are
+// expressed as strings that vault understands, such as "1h".
+type IdentityTokenRoleOptions struct {
+	Name     string `json:"-"`
+	Key      string `json:"key"`
+	Template string `json:"template,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+	TTL      string `json:"ttl,omitempty"`
+}
+
+func (c *client) CreateIdentityTokenRole(opts IdentityTokenRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling identity token role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/oidc/role/%s", opts.Name)
+	c.logRequest("identity-token-role-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating identity token role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpIdentityTokenRoleWrapper struct {
+	Data LookedUpIdentityTokenRole `json:"data"`
+}
+
+// A LookedUpIdentityTokenRole represents information returned from vault
+// after making a request for information about a particular role. The
+// ClientID is the aud claim of tokens generated by the role.
+type LookedUpIdentityTokenRole struct {
+	Key      string `json:"key"`
+	Template string `json:"template"`
+	ClientID string `json:"client_id"`
+	TTL      int    `json:"ttl"`
+}
+
+func (c *client) LookupIdentityTokenRole(name string) (LookedUpIdentityTokenRole, error) {
+	var wrapper lookedUpIdentityTokenRoleWrapper
+	requestPath := fmt.Sprintf("/v1/identity/oidc/role/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpIdentityTokenRole{}, errors.Wrapf(err, "failed to look up identity token role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListIdentityTokenRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/oidc/role"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list identity token roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteIdentityTokenRole(name string) error {
+	requestPath := fmt.Sprintf("/v1/identity/oidc/role/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete identity token role %q", name)
+	}
+	return nil
+}
+
+// An IdentityToken is a signed JWT describing the entity of the token
+// used to generate it.
+type IdentityToken struct {
+	Token    string `json:"token"`
+	ClientID string `json:"client_id"`
+	TTL      int    `json:"ttl"`
+}
+
+type identityTokenWrapper struct {
+	Data IdentityToken `json:"data"`
+}
+
+// GenerateIdentityToken generates an identity token using the
+// named role, for the entity of the token of the client.
+func (c *client) GenerateIdentityToken(role string) (IdentityToken, error) {
+	var wrapper identityTokenWrapper
+	requestPath := fmt.Sprintf("/v1/identity/oidc/token/%s", role)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return IdentityToken{}, errors.Wrapf(err, "failed to generate identity token for role %q", role)
+	}
+	return wrapper.Data, nil
+}
+
+// An IntrospectedIdentityToken describes whether an identity token
+// is Active, that is, validly signed and not expired. If not, the
+// Error describes why.
+type IntrospectedIdentityToken struct {
+	Active bool   `json:"active"`
+	Error  string `json:"error"`
+}
+
+// IntrospectIdentityToken verifies the identity token. If the clientID
+// is set, the audience of the token must match it.
+func (c *client) IntrospectIdentityToken(token, clientID string) (IntrospectedIdentityToken, error) {
+	bs, err := json.Marshal(struct {
+		Token    string `json:"token"`
+		ClientID string `json:"client_id,omitempty"`
+	}{Token: token, ClientID: clientID})
+	if err != nil {
+		return IntrospectedIdentityToken{}, err
+	}
+
+	// the response is not wrapped in data
+	var introspected IntrospectedIdentityToken
+	if err := c.post("/v1/identity/oidc/introspect", string(bs), &introspected); err != nil {
+		return IntrospectedIdentityToken{}, errors.Wrap(err, "failed to introspect identity token")
+	}
+
+	return introspected, nil
+}
+
+// An OIDCProvider is an OpenID Connect provider served by vault, which
+// authenticates entities for the clients in AllowedClientIDs, where
+// "*" allows all clients. The ScopesSupported are the names of the
+// scopes a client may request beyond openid.
+type OIDCProvider struct {
+	Name             string   `json:"-"`
+	Issuer           string   `json:"issuer,omitempty"`
+	AllowedClientIDs []string `json:"allowed_client_ids,omitempty"`
+	ScopesSupported  []string `json:"scopes_supported,omitempty"`
+}
+
+type oidcProviderWrapper struct {
+	Data OIDCProvider `json:"data"`
+}
+
+func (c *client) CreateOIDCProvider(provider OIDCProvider) error {
+	bs, err := json.Marshal(provider)
+	if err != nil {
+		return errors.Wrap(err, "marshalling oidc provider data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/oidc/provider/%s", provider.Name)
+	c.logRequest("oidc-provider-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating oidc provider at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (c *client) LookupOIDCProvider(name string) (OIDCProvider, error) {
+	var wrapper oidcProviderWrapper
+	requestPath := fmt.Sprintf("/v1/identity/oidc/provider/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return OIDCProvider{}, errors.Wrapf(err, "failed to look up oidc provider %q", name)
+	}
+	wrapper.Data.Name = name
+	return wrapper.Data, nil
+}
+
+func (c *client) ListOIDCProviders() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/oidc/provider"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list oidc providers at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteOIDCProvider(name string) error {
+	requestPath := fmt.Sprintf("/v1/identity/oidc/provider/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete oidc provider %q", name)
+	}
+	return nil
+}
+
+// OIDCDiscovery is the OpenID Connect discovery document of an
+// OIDCProvider, which relying parties use to find its endpoints.
+type OIDCDiscovery struct {
+	Issuer                 string   `json:"issuer"`
+	JWKSURI                string   `json:"jwks_uri"`
+	AuthorizationEndpoint  string   `json:"authorization_endpoint"`
+	TokenEndpoint          string   `json:"token_endpoint"`
+	UserinfoEndpoint       string   `json:"userinfo_endpoint"`
+	ScopesSupported        []string `json:"scopes_supported"`
+	ResponseTypesSupported []string `json:"response_types_supported"`
+	SigningAlgorithms      []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// OIDCProviderDiscovery reads the discovery document of the
+// named provider, which is served without authentication.
+func (c *client) OIDCProviderDiscovery(name string) (OIDCDiscovery, error) {
+	// the response is not wrapped in data
+	var discovery OIDCDiscovery
+	requestPath := fmt.Sprintf("/v1/identity/oidc/provider/%s/.well-known/openid-configuration", name)
+	if err := c.get(requestPath, &discovery); err != nil {
+		return OIDCDiscovery{}, errors.Wrapf(err, "failed to read discovery document of oidc provider %q", name)
+	}
+	return discovery, nil
+}
+
+// OIDCClientOptions are used to define properties of an OIDC client
+// being created or updated, which is a relying party of the providers
+// that allow its client ID. Only entities of the named Assignments may
+// authenticate with the client, where "allow_all" allows all entities.
+// ClientType is either "confidential" (the default) or "public".
+// Durations are expressed as strings that vault understands, such as "1h".
+type OIDCClientOptions struct {
+	Name           string   `json:"-"`
+	Key            string   `json:"key,omitempty"`
+	RedirectURIs   []string `json:"redirect_uris,omitempty"`
+	Assignments    []string `json:"assignments,omitempty"`
+	ClientType     string   `json:"client_type,omitempty"`
+	IDTokenTTL     string   `json:"id_token_ttl,omitempty"`
+	AccessTokenTTL string   `json:"access_token_ttl,omitempty"`
+}
+
+func (c *client) CreateOIDCClient(opts OIDCClientOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling oidc client data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/oidc/client/%s", opts.Name)
+	c.logRequest("oidc-client-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating oidc client at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpOIDCClientWrapper struct {
+	Data LookedUpOIDCClient `json:"data"`
+}
+
+// A LookedUpOIDCClient represents information returned from vault after
+// making a request for information about a particular OIDC client,
+// including the generated ClientID and ClientSecret used by the
+// relying party. Public clients have no ClientSecret.
+type LookedUpOIDCClient struct {
+	Key            string   `json:"key"`
+	RedirectURIs   []string `json:"redirect_uris"`
+	Assignments    []string `json:"assignments"`
+	ClientType     string   `json:"client_type"`
+	IDTokenTTL     int      `json:"id_token_ttl"`
+	AccessTokenTTL int      `json:"access_token_ttl"`
+	ClientID       string   `json:"client_id"`
+	ClientSecret   string   `json:"client_secret"`
+}
+
+func (c *client) LookupOIDCClient(name string) (LookedUpOIDCClient, error) {
+	var wrapper lookedUpOIDCClientWrapper
+	requestPath := fmt.Sprintf("/v1/identity/oidc/client/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpOIDCClient{}, errors.Wrapf(err, "failed to look up oidc client %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListOIDCClients() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/oidc/client"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list oidc clients at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteOIDCClient(name string) error {
+	requestPath := fmt.Sprintf("/v1/identity/oidc/client/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete oidc client %q", name)
+	}
+	return nil
+}
+
+// An OIDCScope is a set of claims an OIDC client may request. The
+// Template is a JSON template of the claims, in the same form as the
+// template of an identity token role.
+type OIDCScope struct {
+	Name        string `json:"-"`
+	Template    string `json:"template,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type oidcScopeWrapper struct {
+	Data OIDCScope `json:"data"`
+}
+
+func (c *client) CreateOIDCScope(scope OIDCScope) error {
+	bs, err := json.Marshal(scope)
+	if err != nil {
+		return errors.Wrap(err, "marshalling oidc scope data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/oidc/scope/%s", scope.Name)
+	c.logRequest("oidc-scope-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating oidc scope at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (c *client) LookupOIDCScope(name string) (OIDCScope, error) {
+	var wrapper oidcScopeWrapper
+	requestPath := fmt.Sprintf("/v1/identity/oidc/scope/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return OIDCScope{}, errors.Wrapf(err, "failed to look up oidc scope %q", name)
+	}
+	wrapper.Data.Name = name
+	return wrapper.Data, nil
+}
+
+func (c *client) ListOIDCScopes() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/oidc/scope"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list oidc scopes at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteOIDCScope(name string) error {
+	requestPath := fmt.Sprintf("/v1/identity/oidc/scope/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete oidc scope %q", name)
+	}
+	return nil
+}
+
+// An OIDCAssignment is a set of entities and groups which are
+// allowed to authenticate with the OIDC clients using it.
+type OIDCAssignment struct {
+	Name      string   `json:"-"`
+	EntityIDs []string `json:"entity_ids,omitempty"`
+	GroupIDs  []string `json:"group_ids,omitempty"`
+}
+
+type oidcAssignmentWrapper struct {
+	Data OIDCAssignment `json:"data"`
+}
+
+func (c *client) CreateOIDCAssignment(assignment OIDCAssignment) error {
+	bs, err := json.Marshal(assignment)
+	if err != nil {
+		return errors.Wrap(err, "marshalling oidc assignment data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/identity/oidc/assignment/%s", assignment.Name)
+	c.logRequest("oidc-assignment-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating oidc assignment at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (c *client) LookupOIDCAssignment(name string) (OIDCAssignment, error) {
+	var wrapper oidcAssignmentWrapper
+	requestPath := fmt.Sprintf("/v1/identity/oidc/assignment/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return OIDCAssignment{}, errors.Wrapf(err, "failed to look up oidc assignment %q", name)
+	}
+	wrapper.Data.Name = name
+	return wrapper.Data, nil
+}
+
+func (c *client) ListOIDCAssignments() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/identity/oidc/assignment"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list oidc assignments at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteOIDCAssignment(name string) error {
+	requestPath := fmt.Sprintf("/v1/identity/oidc/assignment/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete oidc assignment %q", name)
+	}
+	return nil
+}
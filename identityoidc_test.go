@@ -0,0 +1,206 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_IdentityTokenKeysRoles(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	err := client.CreateIdentityTokenKey(IdentityTokenKeyOptions{
+		Name:             "test-oidc-key1",
+		RotationPeriod:   "24h",
+		AllowedClientIDs: []string{"*"},
+	})
+	require.NoError(t, err)
+
+	key, err := client.LookupIdentityTokenKey("test-oidc-key1")
+	require.NoError(t, err)
+	require.Equal(t, 86400, key.RotationPeriod)
+	require.Equal(t, []string{"*"}, key.AllowedClientIDs)
+	require.Equal(t, "RS256", key.Algorithm)
+
+	keys, err := client.ListIdentityTokenKeys()
+	require.NoError(t, err)
+	require.Contains(t, keys, "test-oidc-key1")
+
+	require.NoError(t, client.RotateIdentityTokenKey("test-oidc-key1", ""))
+
+	err = client.CreateIdentityTokenRole(IdentityTokenRoleOptions{
+		Name: "test-oidc-role1",
+		Key:  "test-oidc-key1",
+		TTL:  "1h",
+	})
+	require.NoError(t, err)
+
+	role, err := client.LookupIdentityTokenRole("test-oidc-role1")
+	require.NoError(t, err)
+	require.Equal(t, "test-oidc-key1", role.Key)
+	require.Equal(t, 3600, role.TTL)
+	require.NotEmpty(t, role.ClientID)
+
+	roles, err := client.ListIdentityTokenRoles()
+	require.NoError(t, err)
+	require.Contains(t, roles, "test-oidc-role1")
+
+	// a key cannot be deleted while in use by a role
+	require.Error(t, client.DeleteIdentityTokenKey("test-oidc-key1"))
+	require.NoError(t, client.DeleteIdentityTokenRole("test-oidc-role1"))
+	require.NoError(t, client.DeleteIdentityTokenKey("test-oidc-key1"))
+
+	_, err = client.LookupIdentityTokenKey("test-oidc-key1")
+	require.True(t, errors.Is(err, ErrPathNotFound))
+}
+
+func Test_client_IdentityTokens(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/identity/oidc/key/signer/rotate": "",
+		"GET /v1/identity/oidc/token/web":          `{"data":{"token":"eyJ.payload.sig","client_id":"c1","ttl":3600}}`,
+		"POST /v1/identity/oidc/introspect":        `{"active":false,"error":"token is expired"}`,
+	})
+
+	require.NoError(t, vault.RotateIdentityTokenKey("signer", "1h"))
+	require.Equal(t, `{"verification_ttl":"1h"}`, vault.requests[0].Body)
+
+	token, err := vault.GenerateIdentityToken("web")
+	require.NoError(t, err)
+	require.Equal(t, IdentityToken{
+		Token:    "eyJ.payload.sig",
+		ClientID: "c1",
+		TTL:      3600,
+	}, token)
+
+	// the introspection is not wrapped in data
+	introspected, err := vault.IntrospectIdentityToken("eyJ.payload.sig", "")
+	require.NoError(t, err)
+	require.Equal(t, IntrospectedIdentityToken{Error: "token is expired"}, introspected)
+	require.Equal(t, `{"token":"eyJ.payload.sig"}`, vault.requests[2].Body)
+}
+
+func Test_client_OIDCProviders(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/identity/oidc/provider/sso":   "",
+		"GET /v1/identity/oidc/provider/sso":    `{"data":{"issuer":"https://vault.example.com/v1/identity/oidc/provider/sso","allowed_client_ids":["*"],"scopes_supported":["groups"]}}`,
+		"LIST /v1/identity/oidc/provider":       `{"data":{"keys":["sso","default"]}}`,
+		"DELETE /v1/identity/oidc/provider/sso": "",
+
+		"GET /v1/identity/oidc/provider/sso/.well-known/openid-configuration": `{
+			"issuer": "https://vault.example.com/v1/identity/oidc/provider/sso",
+			"jwks_uri": "https://vault.example.com/v1/identity/oidc/provider/sso/.well-known/keys",
+			"scopes_supported": ["openid", "groups"],
+			"id_token_signing_alg_values_supported": ["RS256"]
+		}`,
+	})
+
+	err := vault.CreateOIDCProvider(OIDCProvider{
+		Name:             "sso",
+		AllowedClientIDs: []string{"*"},
+		ScopesSupported:  []string{"groups"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"allowed_client_ids":["*"],"scopes_supported":["groups"]}`, vault.requests[0].Body)
+
+	provider, err := vault.LookupOIDCProvider("sso")
+	require.NoError(t, err)
+	require.Equal(t, OIDCProvider{
+		Name:             "sso",
+		Issuer:           "https://vault.example.com/v1/identity/oidc/provider/sso",
+		AllowedClientIDs: []string{"*"},
+		ScopesSupported:  []string{"groups"},
+	}, provider)
+
+	providers, err := vault.ListOIDCProviders()
+	require.NoError(t, err)
+	require.Equal(t, []string{"default", "sso"}, providers)
+
+	// the discovery document is not wrapped in data
+	discovery, err := vault.OIDCProviderDiscovery("sso")
+	require.NoError(t, err)
+	require.Equal(t, OIDCDiscovery{
+		Issuer:            "https://vault.example.com/v1/identity/oidc/provider/sso",
+		JWKSURI:           "https://vault.example.com/v1/identity/oidc/provider/sso/.well-known/keys",
+		ScopesSupported:   []string{"openid", "groups"},
+		SigningAlgorithms: []string{"RS256"},
+	}, discovery)
+
+	require.NoError(t, vault.DeleteOIDCProvider("sso"))
+}
+
+func Test_client_OIDCClientsScopesAssignments(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/identity/oidc/client/app":       "",
+		"GET /v1/identity/oidc/client/app":        `{"data":{"key":"default","redirect_uris":["https://app.example.com/callback"],"assignments":["ops"],"client_type":"confidential","id_token_ttl":86400,"access_token_ttl":86400,"client_id":"c1","client_secret":"hvo_secret"}}`,
+		"LIST /v1/identity/oidc/client":           `{"data":{"keys":["app"]}}`,
+		"DELETE /v1/identity/oidc/client/app":     "",
+		"POST /v1/identity/oidc/scope/groups":     "",
+		"GET /v1/identity/oidc/scope/groups":      `{"data":{"template":"{\"groups\":{{identity.entity.groups.names}}}","description":"groups"}}`,
+		"LIST /v1/identity/oidc/scope":            `{"data":{"keys":["groups"]}}`,
+		"DELETE /v1/identity/oidc/scope/groups":   "",
+		"POST /v1/identity/oidc/assignment/ops":   "",
+		"GET /v1/identity/oidc/assignment/ops":    `{"data":{"entity_ids":["e1"],"group_ids":["g1"]}}`,
+		"LIST /v1/identity/oidc/assignment":       `{"data":{"keys":["ops"]}}`,
+		"DELETE /v1/identity/oidc/assignment/ops": "",
+	})
+
+	err := vault.CreateOIDCClient(OIDCClientOptions{
+		Name:         "app",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Assignments:  []string{"ops"},
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"redirect_uris":["https://app.example.com/callback"],"assignments":["ops"]}`,
+		vault.requests[0].Body,
+	)
+
+	client, err := vault.LookupOIDCClient("app")
+	require.NoError(t, err)
+	require.Equal(t, "c1", client.ClientID)
+	require.Equal(t, "hvo_secret", client.ClientSecret)
+	require.Equal(t, 86400, client.IDTokenTTL)
+
+	clients, err := vault.ListOIDCClients()
+	require.NoError(t, err)
+	require.Equal(t, []string{"app"}, clients)
+
+	err = vault.CreateOIDCScope(OIDCScope{
+		Name:     "groups",
+		Template: `{"groups":{{identity.entity.groups.names}}}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"template":"{\"groups\":{{identity.entity.groups.names}}}"}`, vault.requests[3].Body)
+
+	scope, err := vault.LookupOIDCScope("groups")
+	require.NoError(t, err)
+	require.Equal(t, `{"groups":{{identity.entity.groups.names}}}`, scope.Template)
+
+	scopes, err := vault.ListOIDCScopes()
+	require.NoError(t, err)
+	require.Equal(t, []string{"groups"}, scopes)
+
+	err = vault.CreateOIDCAssignment(OIDCAssignment{
+		Name:      "ops",
+		EntityIDs: []string{"e1"},
+		GroupIDs:  []string{"g1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"entity_ids":["e1"],"group_ids":["g1"]}`, vault.requests[6].Body)
+
+	assignment, err := vault.LookupOIDCAssignment("ops")
+	require.NoError(t, err)
+	require.Equal(t, []string{"e1"}, assignment.EntityIDs)
+	require.Equal(t, []string{"g1"}, assignment.GroupIDs)
+
+	assignments, err := vault.ListOIDCAssignments()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ops"}, assignments)
+
+	require.NoError(t, vault.DeleteOIDCAssignment("ops"))
+	require.NoError(t, vault.DeleteOIDCScope("groups"))
+	require.NoError(t, vault.DeleteOIDCClient("app"))
+}
@@ -0,0 +1,69 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestMetrics describe a request made by a Client, and are given to
+// the Metrics function of ClientOptions once the request completes. A
+// request which is made to each of the servers of the Client in turn
+// is described once per server.
+type RequestMetrics struct {
+	// Method is the HTTP method of the request, e.g. "GET" or "LIST".
+	Method string
+
+	// Mount is the mount the request was made to, e.g. "secret" for
+	// "/v1/secret/foo", or "auth/approle" for "/v1/auth/approle/login".
+	// The rest of the path is left out, as it is unbounded and may
+	// contain the names of secrets; of a mount at a nested path, only
+	// the first segment is included.
+	Mount string
+
+	// StatusCode is the status code of the response, which is zero if
+	// the request failed without a response.
+	StatusCode int
+
+	// Duration is how long the request took, including retries.
+	Duration time.Duration
+
+	// Retries is the number of times the request was retried.
+	Retries int
+
+	// Err is the error with which the request failed without a
+	// response, if any.
+	Err error
+}
+
+// observe reports the RequestMetrics of the request to the Metrics
+// function of the client, if set.
+func (c *client) observe(request *http.Request, response *http.Response, retries int, duration time.Duration, err error) {
+	if c.opts.Metrics == nil {
+		return
+	}
+
+	metrics := RequestMetrics{
+		Method:   request.Method,
+		Mount:    metricsMount(request.URL.Path),
+		Duration: duration,
+		Retries:  retries,
+		Err:      err,
+	}
+	if response != nil {
+		metrics.StatusCode = response.StatusCode
+	}
+	c.opts.Metrics(metrics)
+}
+
+// metricsMount returns the mount of path, which is the first segment
+// after the version prefix, or the first two segments of auth paths.
+func metricsMount(path string) string {
+	segments := strings.SplitN(strings.TrimPrefix(path, "/v1/"), "/", 3)
+	if segments[0] == "auth" && len(segments) > 1 {
+		return "auth/" + segments[1]
+	}
+	return segments[0]
+}
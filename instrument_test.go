@@ -0,0 +1,67 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_client_Metrics(t *testing.T) {
+	var metrics []RequestMetrics
+	var attempts int
+
+	opts := devOpts()
+	opts.Retry = RetryOptions{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	opts.Metrics = func(m RequestMetrics) {
+		metrics = append(metrics, m)
+	}
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		attempts++
+		code := http.StatusOK
+		if attempts == 1 {
+			code = http.StatusServiceUnavailable
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"data":{"value":"abc123"}}`)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	_, err = client.Get("/foo")
+	require.NoError(t, err)
+
+	require.Len(t, metrics, 1)
+	require.Equal(t, http.MethodGet, metrics[0].Method)
+	require.Equal(t, "secret", metrics[0].Mount)
+	require.Equal(t, http.StatusOK, metrics[0].StatusCode)
+	require.Equal(t, 1, metrics[0].Retries)
+	require.True(t, metrics[0].Duration > 0)
+	require.NoError(t, metrics[0].Err)
+}
+
+func Test_metricsMount(t *testing.T) {
+	tests := []struct {
+		path string
+		exp  string
+	}{
+		{path: "/v1/secret/foo/bar", exp: "secret"},
+		{path: "/v1/secret", exp: "secret"},
+		{path: "/v1/sys/leases/lookup/database/creds/app", exp: "sys"},
+		{path: "/v1/auth/approle/role/deploy/secret-id", exp: "auth/approle"},
+		{path: "/v1/auth/token/lookup-self", exp: "auth/token"},
+		{path: "/v1/auth", exp: "auth"},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.exp, metricsMount(test.path), test.path)
+	}
+}
@@ -0,0 +1,132 @@
+// Author hoenig
+
+// Package watch provides the waiting and emitting of events shared by
+// the types which renew leases and certificates in the background.
+package watch
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultJitter is the fraction of time by which renewals are
+	// randomly moved earlier, unless configured otherwise.
+	DefaultJitter = 0.1
+
+	// DefaultRetryDelay is how long to wait after a failed renewal,
+	// unless configured otherwise.
+	DefaultRetryDelay = 5 * time.Second
+
+	// the fraction of the lease duration after which a renewal is made
+	renewFraction = 2.0 / 3.0
+)
+
+// RenewDelay computes how long to wait before renewing a lease,
+// moved earlier by up to the jitter fraction.
+func RenewDelay(lease time.Duration, jitter float64) time.Duration {
+	delay := float64(lease) * renewFraction
+	delay -= delay * jitter * rand.Float64()
+	return time.Duration(delay)
+}
+
+// Sleep waits for duration, unless stop is closed first, in which
+// case it returns false.
+func Sleep(duration time.Duration, stop <-chan struct{}) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// A Watcher runs a renewal loop in the background, emitting an event
+// of type E after each attempt, until it is stopped or the lease runs
+// out. The events are closed once the loop returns.
+type Watcher[E any] struct {
+	retryDelay time.Duration
+	expired    E
+	events     chan E
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// New creates a Watcher which waits for retryDelay after each failed
+// attempt, and emits expired once the lease runs out.
+func New[E any](retryDelay time.Duration, expired E) *Watcher[E] {
+	return &Watcher[E]{
+		retryDelay: retryDelay,
+		expired:    expired,
+		events:     make(chan E),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Events returns the channel on which events are emitted, which is
+// closed once the loop returns.
+func (w *Watcher[E]) Events() <-chan E {
+	return w.events
+}
+
+// Start runs loop in the background, closing the events once it returns.
+func (w *Watcher[E]) Start(loop func()) {
+	go func() {
+		defer close(w.events)
+		loop()
+	}()
+}
+
+// Stop stops the loop. It is safe to call Stop more than once.
+func (w *Watcher[E]) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+}
+
+// Emit emits event, unless the Watcher is stopped first, in which
+// case it returns false.
+func (w *Watcher[E]) Emit(event E) bool {
+	select {
+	case w.events <- event:
+		return true
+	case <-w.stop:
+		return false
+	}
+}
+
+// Sleep waits for duration, unless the Watcher is stopped first, in
+// which case it returns false.
+func (w *Watcher[E]) Sleep(duration time.Duration) bool {
+	return Sleep(duration, w.stop)
+}
+
+// Expire waits until the lease expires, and then emits the expired
+// event. No more events follow an expiration.
+func (w *Watcher[E]) Expire(expires time.Time) {
+	if w.Sleep(time.Until(expires)) {
+		w.Emit(w.expired)
+	}
+}
+
+// Retry emits failed, and then waits for the retry delay before the
+// next attempt. If the lease expires before then, Retry waits for the
+// expiration instead and returns false, as it does if the Watcher is
+// stopped. A zero expires means the expiration is not yet known.
+func (w *Watcher[E]) Retry(failed E, expires time.Time) bool {
+	if !w.Emit(failed) {
+		return false
+	}
+
+	if !expires.IsZero() && !time.Now().Add(w.retryDelay).Before(expires) {
+		w.Expire(expires)
+		return false
+	}
+
+	return w.Sleep(w.retryDelay)
+}
@@ -0,0 +1,80 @@
+// Author hoenig
+
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RenewDelay(t *testing.T) {
+	require.Equal(t, 40*time.Second, RenewDelay(time.Minute, 0))
+
+	for i := 0; i < 100; i++ {
+		delay := RenewDelay(time.Minute, 0.1)
+		require.True(t, delay > 36*time.Second)
+		require.True(t, delay <= 40*time.Second)
+	}
+}
+
+func Test_Sleep(t *testing.T) {
+	stop := make(chan struct{})
+	require.True(t, Sleep(time.Millisecond, stop))
+
+	close(stop)
+	require.False(t, Sleep(time.Hour, stop))
+}
+
+func Test_Watcher_Retry(t *testing.T) {
+	w := New(10*time.Millisecond, "expired")
+	w.Start(func() {
+		// the expiration is not known yet, so retry
+		if !w.Retry("failed", time.Time{}) {
+			return
+		}
+		// the lease expires before the retry delay
+		if w.Retry("failed", time.Now().Add(5*time.Millisecond)) {
+			w.Emit("unexpected")
+		}
+	})
+	defer w.Stop()
+
+	var events []string
+	for event := range w.Events() {
+		events = append(events, event)
+	}
+	require.Equal(t, []string{"failed", "failed", "expired"}, events)
+}
+
+func Test_Watcher_Expire(t *testing.T) {
+	w := New(time.Hour, "expired")
+	w.Start(func() {
+		w.Expire(time.Now().Add(5 * time.Millisecond))
+	})
+	defer w.Stop()
+
+	// no more events follow an expiration
+	var events []string
+	for event := range w.Events() {
+		events = append(events, event)
+	}
+	require.Equal(t, []string{"expired"}, events)
+}
+
+func Test_Watcher_Stop(t *testing.T) {
+	w := New(time.Hour, "expired")
+	w.Start(func() {
+		for w.Emit("renewed") {
+		}
+	})
+
+	require.Equal(t, "renewed", <-w.Events())
+	w.Stop()
+	w.Stop()
+
+	// drain any event emitted before the stop was noticed
+	for range w.Events() {
+	}
+}
@@ -0,0 +1,76 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// UIMounts are the secrets engine and auth method mounts visible to the
+// token of the Client, which are those on which the token has any
+// capability, or which are configured to be listed without a token.
+type UIMounts struct {
+	Secret Mounts `json:"secret"`
+	Auth   Mounts `json:"auth"`
+}
+
+// ListUIMounts returns the mounts visible to the token of the
+// Client, as shown by the web UI of vault.
+func (c *client) ListUIMounts() (UIMounts, error) {
+	var wrapper struct {
+		Data UIMounts `json:"data"`
+	}
+	if err := c.get("/v1/sys/internal/ui/mounts", &wrapper); err != nil {
+		return UIMounts{}, errors.Wrap(err, "failed to read ui mounts")
+	}
+	return wrapper.Data, nil
+}
+
+// A UIMount is a Mount visible to the token of the Client,
+// along with the Path at which it is mounted.
+type UIMount struct {
+	Mount
+	Path string `json:"path"`
+}
+
+// LookupUIMount returns the mount which contains path, if the
+// mount is visible to the token of the Client.
+func (c *client) LookupUIMount(path string) (UIMount, error) {
+	var wrapper struct {
+		Data UIMount `json:"data"`
+	}
+	requestPath := fmt.Sprintf("/v1/sys/internal/ui/mounts/%s", strings.Trim(path, "/"))
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return UIMount{}, errors.Wrapf(err, "failed to look up ui mount %q", path)
+	}
+	return wrapper.Data, nil
+}
+
+// A ResultantACL is the combination of the policies of the token of the
+// Client. The ExactPaths and GlobPaths are keyed by the path of each
+// rule, where the glob paths end with a "*" and match any path with that
+// prefix. A Root token may do anything.
+type ResultantACL struct {
+	ExactPaths map[string]ACLRule `json:"exact_paths"`
+	GlobPaths  map[string]ACLRule `json:"glob_paths"`
+	Root       bool               `json:"root"`
+}
+
+// An ACLRule is the combined capabilities of the rules of the
+// policies of a token on a path.
+type ACLRule struct {
+	Capabilities []string `json:"capabilities"`
+}
+
+func (c *client) ResultantACL() (ResultantACL, error) {
+	var wrapper struct {
+		Data ResultantACL `json:"data"`
+	}
+	if err := c.get("/v1/sys/internal/ui/resultant-acl", &wrapper); err != nil {
+		return ResultantACL{}, errors.Wrap(err, "failed to read resultant acl")
+	}
+	return wrapper.Data, nil
+}
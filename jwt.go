@@ -0,0 +1,353 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultOIDCListenAddress = "localhost:8250"
+	defaultOIDCCallbackPath  = "/oidc/callback"
+	defaultOIDCTimeout       = 2 * time.Minute
+)
+
+// JWTAuth provides a way to authenticate with vault by using a
+// signed JWT, or interactively by using the OIDC authorization
+// code flow through a web browser, as well as a way to manage the
+// configuration and roles of the jwt auth backend.
+//
+// The jwt backend must be enabled at the default path of
+// auth/jwt for this API to work.
+//
+// More information about the jwt auth backend can be found here:
+// https://www.vaultproject.io/docs/auth/jwt.html
+type JWTAuth interface {
+	LoginJWT(role, jwt string) (CreatedToken, error)
+	LoginOIDC(role string, opts OIDCLoginOptions) (CreatedToken, error)
+	ConfigureJWT(config JWTConfig) error
+	LookupJWTConfig() (JWTConfig, error)
+	CreateJWTRole(opts JWTRoleOptions) error
+	LookupJWTRole(name string) (LookedUpJWTRole, error)
+	ListJWTRoles() ([]string, error)
+	DeleteJWTRole(name string) error
+}
+
+type jwtLogin struct {
+	Role string `json:"role,omitempty"`
+	JWT  string `json:"jwt"`
+}
+
+func (c *client) LoginJWT(role, jwt string) (CreatedToken, error) {
+	return c.login("jwt", "/v1/auth/jwt/login", jwtLogin{Role: role, JWT: jwt})
+}
+
+// OIDCLoginOptions are used to configure the interactive
+// OIDC login flow performed by LoginOIDC.
+type OIDCLoginOptions struct {
+	// ListenAddress is the address of the local listener which
+	// receives the callback from the OIDC provider. The resulting
+	// redirect URI must be in the allowed_redirect_uris of the role.
+	// By default, this value is localhost:8250.
+	ListenAddress string
+
+	// OpenURL is called with the authorization URL the user must
+	// visit to login. By default, the URL is opened with the web
+	// browser of the operating system.
+	OpenURL func(authURL string) error
+
+	// Timeout configures how long to wait for the user to complete
+	// login in the web browser. By default, this value is 2 minutes.
+	Timeout time.Duration
+}
+
+type oidcAuthURLRequest struct {
+	Role        string `json:"role"`
+	RedirectURI string `json:"redirect_uri"`
+	ClientNonce string `json:"client_nonce"`
+}
+
+type oidcAuthURLWrapper struct {
+	Data struct {
+		AuthURL string `json:"auth_url"`
+	} `json:"data"`
+}
+
+type oidcCallback struct {
+	state string
+	code  string
+	err   error
+}
+
+// LoginOIDC performs the OIDC authorization code flow the same way
+// the vault CLI does: a local listener is started to receive the
+// callback, the user is sent to the authorization URL of the OIDC
+// provider, and the resulting code is exchanged for a vault token.
+func (c *client) LoginOIDC(role string, opts OIDCLoginOptions) (CreatedToken, error) {
+	if opts.ListenAddress == "" {
+		opts.ListenAddress = defaultOIDCListenAddress
+	}
+
+	if opts.OpenURL == nil {
+		opts.OpenURL = openBrowser
+	}
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultOIDCTimeout
+	}
+
+	nonce, err := randomNonce()
+	if err != nil {
+		return CreatedToken{}, err
+	}
+
+	listener, err := net.Listen("tcp", opts.ListenAddress)
+	if err != nil {
+		return CreatedToken{}, errors.Wrap(err, "failed to start oidc callback listener")
+	}
+
+	callbacks := make(chan oidcCallback, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(defaultOIDCCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		callback := oidcCallback{state: query.Get("state"), code: query.Get("code")}
+		if message := oidcCallbackError(query); message != "" {
+			callback.err = errors.Errorf("oidc provider returned error: %s", message)
+			fmt.Fprintln(w, "Vault login failed. You may close this window.")
+		} else {
+			fmt.Fprintln(w, "Vault login successful. You may close this window.")
+		}
+		select {
+		case callbacks <- callback:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	redirectURI := "http://" + opts.ListenAddress + defaultOIDCCallbackPath
+	bs, err := json.Marshal(oidcAuthURLRequest{
+		Role:        role,
+		RedirectURI: redirectURI,
+		ClientNonce: nonce,
+	})
+	if err != nil {
+		return CreatedToken{}, err
+	}
+
+	var authURL oidcAuthURLWrapper
+	if err := c.post("/v1/auth/jwt/oidc/auth_url", string(bs), &authURL); err != nil {
+		return CreatedToken{}, errors.Wrapf(err, "failed to get oidc auth url for role %q", role)
+	}
+
+	if authURL.Data.AuthURL == "" {
+		// vault returns an empty url if the redirect uri is not allowed
+		return CreatedToken{}, errors.Errorf("no oidc auth url returned, check %q is an allowed redirect uri", redirectURI)
+	}
+
+	if err := opts.OpenURL(authURL.Data.AuthURL); err != nil {
+		return CreatedToken{}, errors.Wrap(err, "failed to open oidc auth url")
+	}
+
+	var callback oidcCallback
+	select {
+	case callback = <-callbacks:
+		if callback.err != nil {
+			return CreatedToken{}, callback.err
+		}
+	case <-time.After(opts.Timeout):
+		return CreatedToken{}, errors.New("timed out waiting for oidc login")
+	}
+
+	requestPath := fixup("/v1/auth/jwt", "oidc/callback",
+		[2]string{"state", callback.state},
+		[2]string{"code", callback.code},
+		[2]string{"client_nonce", nonce},
+	)
+
+	var ct createdToken
+	if err := c.get(requestPath, &ct); err != nil {
+		return CreatedToken{}, errors.Wrap(err, "failed to login with oidc")
+	}
+
+	if ct.Data.ID == "" {
+		return CreatedToken{}, errors.Errorf("oidc login returned empty token id")
+	}
+
+	return ct.Data, nil
+}
+
+// oidcCallbackError returns the error of a failed callback, which the
+// provider must set, along with an optional description of it
+func oidcCallbackError(query url.Values) string {
+	code, description := query.Get("error"), query.Get("error_description")
+	switch {
+	case code != "" && description != "":
+		return code + ": " + description
+	case code != "":
+		return code
+	default:
+		return description
+	}
+}
+
+func randomNonce() (string, error) {
+	bs := make([]byte, 16)
+	if _, err := rand.Read(bs); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+	return hex.EncodeToString(bs), nil
+}
+
+// openBrowser opens authURL with the web browser of the
+// operating system, like the vault CLI does
+func openBrowser(authURL string) error {
+	if _, err := url.Parse(authURL); err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", authURL)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", authURL)
+	default:
+		cmd = exec.Command("xdg-open", authURL)
+	}
+	return cmd.Start()
+}
+
+// JWTConfig is the configuration of the jwt auth backend. Exactly
+// one of OIDCDiscoveryURL, JWKSURL, or JWTValidationPubKeys should
+// be set, which determines how vault validates JWTs. The OIDC client
+// options are only needed for the interactive OIDC login flow.
+type JWTConfig struct {
+	OIDCDiscoveryURL     string   `json:"oidc_discovery_url,omitempty"`
+	OIDCDiscoveryCAPEM   string   `json:"oidc_discovery_ca_pem,omitempty"`
+	OIDCClientID         string   `json:"oidc_client_id,omitempty"`
+	OIDCClientSecret     string   `json:"oidc_client_secret,omitempty"`
+	JWKSURL              string   `json:"jwks_url,omitempty"`
+	JWTValidationPubKeys []string `json:"jwt_validation_pubkeys,omitempty"`
+	BoundIssuer          string   `json:"bound_issuer,omitempty"`
+	DefaultRole          string   `json:"default_role,omitempty"`
+}
+
+func (c *client) ConfigureJWT(config JWTConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling jwt config to JSON request body")
+	}
+
+	if err := c.post("/v1/auth/jwt/config", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure jwt auth")
+	}
+
+	return nil
+}
+
+type jwtConfigWrapper struct {
+	Data JWTConfig `json:"data"`
+}
+
+func (c *client) LookupJWTConfig() (JWTConfig, error) {
+	var wrapper jwtConfigWrapper
+	if err := c.get("/v1/auth/jwt/config", &wrapper); err != nil {
+		return JWTConfig{}, errors.Wrap(err, "failed to read jwt auth config")
+	}
+	return wrapper.Data, nil
+}
+
+// JWTRoleOptions are used to define properties of a jwt role being
+// created or updated. RoleType is either "jwt" or "oidc". Durations
+// are expressed as strings that vault understands, such as "1h".
+type JWTRoleOptions struct {
+	Name                string            `json:"-"`
+	RoleType            string            `json:"role_type,omitempty"`
+	UserClaim           string            `json:"user_claim"`
+	BoundAudiences      []string          `json:"bound_audiences,omitempty"`
+	BoundSubject        string            `json:"bound_subject,omitempty"`
+	BoundClaims         map[string]string `json:"bound_claims,omitempty"`
+	GroupsClaim         string            `json:"groups_claim,omitempty"`
+	AllowedRedirectURIs []string          `json:"allowed_redirect_uris,omitempty"`
+	OIDCScopes          []string          `json:"oidc_scopes,omitempty"`
+	Policies            []string          `json:"policies,omitempty"`
+	TTL                 string            `json:"ttl,omitempty"`
+	MaxTTL              string            `json:"max_ttl,omitempty"`
+}
+
+func (c *client) CreateJWTRole(opts JWTRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling jwt role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/jwt/role/%s", opts.Name)
+	c.logRequest("jwt-role-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating jwt role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpJWTRoleWrapper struct {
+	Data LookedUpJWTRole `json:"data"`
+}
+
+// A LookedUpJWTRole represents information returned from vault
+// after making a request for information about a particular
+// jwt role.
+type LookedUpJWTRole struct {
+	RoleType            string            `json:"role_type"`
+	UserClaim           string            `json:"user_claim"`
+	BoundAudiences      []string          `json:"bound_audiences"`
+	BoundSubject        string            `json:"bound_subject"`
+	BoundClaims         map[string]string `json:"bound_claims"`
+	GroupsClaim         string            `json:"groups_claim"`
+	AllowedRedirectURIs []string          `json:"allowed_redirect_uris"`
+	OIDCScopes          []string          `json:"oidc_scopes"`
+	Policies            []string          `json:"policies"`
+	TTL                 int               `json:"ttl"`
+	MaxTTL              int               `json:"max_ttl"`
+}
+
+func (c *client) LookupJWTRole(name string) (LookedUpJWTRole, error) {
+	var wrapper lookedUpJWTRoleWrapper
+	requestPath := fmt.Sprintf("/v1/auth/jwt/role/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpJWTRole{}, errors.Wrapf(err, "failed to look up jwt role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListJWTRoles() ([]string, error) {
+	var wrapper rolesWrapper
+	requestPath := "/v1/auth/jwt/role"
+	if err := c.list(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list jwt roles at %q", requestPath)
+	}
+	sort.Strings(wrapper.Data.Keys)
+	return wrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteJWTRole(name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/jwt/role/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete jwt role %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// oidcVault fakes the oidc endpoints of the jwt auth backend, sending
+// the user to the provider at providerURL
+func oidcVault(t *testing.T, providerURL string) *httptest.Server {
+	var request oidcAuthURLRequest
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/jwt/oidc/auth_url":
+			require.Equal(t, http.MethodPost, r.Method)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			fmt.Fprintf(w, `{"data":{"auth_url":%q}}`, providerURL+"?redirect_uri="+url.QueryEscape(request.RedirectURI))
+		case "/v1/auth/jwt/oidc/callback":
+			query := r.URL.Query()
+			require.Equal(t, "state1", query.Get("state"))
+			require.Equal(t, "code1", query.Get("code"))
+			require.Equal(t, request.ClientNonce, query.Get("client_nonce"))
+			fmt.Fprint(w, `{"auth":{"client_token":"s.oidc","policies":["default"]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// oidcBrowser returns an OpenURL which acts as the user completing
+// login with the provider, which then redirects to the callback
+// with the given query
+func oidcBrowser(t *testing.T, callback url.Values) func(string) error {
+	return func(authURL string) error {
+		u, err := url.Parse(authURL)
+		require.NoError(t, err)
+		require.Equal(t, "/authorize", u.Path)
+
+		response, err := http.Get(u.Query().Get("redirect_uri") + "?" + callback.Encode())
+		require.NoError(t, err)
+		return response.Body.Close()
+	}
+}
+
+// freeAddress returns a local address on which to receive callbacks
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func Test_client_LoginOIDC(t *testing.T) {
+	server := oidcVault(t, "https://provider.example.com/authorize")
+	defer server.Close()
+
+	opts := devOpts()
+	opts.Servers = []string{server.URL}
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	token, err := client.LoginOIDC("dev", OIDCLoginOptions{
+		ListenAddress: freeAddress(t),
+		OpenURL: oidcBrowser(t, url.Values{
+			"state": []string{"state1"},
+			"code":  []string{"code1"},
+		}),
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "s.oidc", token.ID)
+	require.Equal(t, []string{"default"}, token.Policies)
+}
+
+func Test_client_LoginOIDC_error(t *testing.T) {
+	tests := []struct {
+		name     string
+		callback url.Values
+		exp      string
+	}{
+		{
+			name:     "error only",
+			callback: url.Values{"error": []string{"access_denied"}},
+			exp:      "oidc provider returned error: access_denied",
+		},
+		{
+			name: "error with description",
+			callback: url.Values{
+				"error":             []string{"access_denied"},
+				"error_description": []string{"user cancelled login"},
+			},
+			exp: "oidc provider returned error: access_denied: user cancelled login",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := oidcVault(t, "https://provider.example.com/authorize")
+			defer server.Close()
+
+			opts := devOpts()
+			opts.Servers = []string{server.URL}
+			client, err := New(opts, NewStaticToken("token"))
+			require.NoError(t, err)
+
+			_, err = client.LoginOIDC("dev", OIDCLoginOptions{
+				ListenAddress: freeAddress(t),
+				OpenURL:       oidcBrowser(t, test.callback),
+				Timeout:       5 * time.Second,
+			})
+			require.EqualError(t, err, test.exp)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// KerberosAuth provides a way for users and services to authenticate
+// with vault by using their Kerberos identity through SPNEGO, and to
+// manage the configuration of the kerberos auth backend along with the
+// mappings of LDAP groups onto vault policies.
+//
+// The kerberos backend must be enabled at the default path of
+// auth/kerberos for this API to work.
+//
+// The SPNEGO token of LoginKerberos is negotiated from a keytab or
+// credential cache by the kerberos subpackage, e.g. kerberos.Login.
+//
+// More information about the kerberos auth backend can be found here:
+// https://www.vaultproject.io/docs/auth/kerberos.html
+type KerberosAuth interface {
+	LoginKerberos(spnegoToken []byte) (CreatedToken, error)
+	ConfigureKerberos(config KerberosConfig) error
+	LookupKerberosConfig() (KerberosConfig, error)
+	ConfigureKerberosLDAP(config LDAPConfig) error
+	LookupKerberosLDAPConfig() (LDAPConfig, error)
+	SetKerberosGroup(name string, policies []string) error
+	LookupKerberosGroup(name string) (LDAPGroup, error)
+	ListKerberosGroups() ([]string, error)
+	DeleteKerberosGroup(name string) error
+}
+
+// LoginKerberos will login by sending the raw SPNEGO token in
+// the Authorization header, as required by the kerberos backend.
+func (c *client) LoginKerberos(spnegoToken []byte) (CreatedToken, error) {
+	header := make(http.Header)
+	header.Set("Authorization", "Negotiate "+base64.StdEncoding.EncodeToString(spnegoToken))
+
+	var ct createdToken
+	if err := c.postHeaders("/v1/auth/kerberos/login", "", header, &ct); err != nil {
+		// do not provide the spnego token anywhere
+		return CreatedToken{}, errors.Wrap(err, "failed to login with kerberos")
+	}
+
+	// a login which requires mfa has no token until it is validated
+	if ct.Data.ID == "" && ct.Data.MFARequirement == nil {
+		return CreatedToken{}, errors.Errorf("kerberos login returned empty token id")
+	}
+
+	return ct.Data, nil
+}
+
+// KerberosConfig is the configuration of the kerberos auth backend.
+// The Keytab is the raw keytab of ServiceAccount, which vault uses
+// to verify SPNEGO tokens. The keytab is never returned by vault.
+type KerberosConfig struct {
+	Keytab         []byte `json:"keytab,omitempty"`
+	ServiceAccount string `json:"service_account"`
+}
+
+func (c *client) ConfigureKerberos(config KerberosConfig) error {
+	// []byte is marshalled as base64, which is what vault expects
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling kerberos config to JSON request body")
+	}
+
+	if err := c.post("/v1/auth/kerberos/config", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure kerberos auth")
+	}
+
+	return nil
+}
+
+type kerberosConfigWrapper struct {
+	Data KerberosConfig `json:"data"`
+}
+
+func (c *client) LookupKerberosConfig() (KerberosConfig, error) {
+	var wrapper kerberosConfigWrapper
+	if err := c.get("/v1/auth/kerberos/config", &wrapper); err != nil {
+		return KerberosConfig{}, errors.Wrap(err, "failed to read kerberos auth config")
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ConfigureKerberosLDAP(config LDAPConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling kerberos ldap config to JSON request body")
+	}
+
+	if err := c.post("/v1/auth/kerberos/config/ldap", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure kerberos ldap")
+	}
+
+	return nil
+}
+
+func (c *client) LookupKerberosLDAPConfig() (LDAPConfig, error) {
+	var wrapper ldapConfigWrapper
+	if err := c.get("/v1/auth/kerberos/config/ldap", &wrapper); err != nil {
+		return LDAPConfig{}, errors.Wrap(err, "failed to read kerberos ldap config")
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) SetKerberosGroup(name string, policies []string) error {
+	bs, err := json.Marshal(LDAPGroup{Policies: policies})
+	if err != nil {
+		return errors.Wrap(err, "marshalling kerberos group to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/kerberos/groups/%s", name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to set kerberos group %q", name)
+	}
+
+	return nil
+}
+
+func (c *client) LookupKerberosGroup(name string) (LDAPGroup, error) {
+	var wrapper ldapGroupWrapper
+	requestPath := fmt.Sprintf("/v1/auth/kerberos/groups/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LDAPGroup{}, errors.Wrapf(err, "failed to look up kerberos group %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListKerberosGroups() ([]string, error) {
+	var wrapper rolesWrapper
+	requestPath := "/v1/auth/kerberos/groups"
+	if err := c.list(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list kerberos groups at %q", requestPath)
+	}
+	sort.Strings(wrapper.Data.Keys)
+	return wrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteKerberosGroup(name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/kerberos/groups/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete kerberos group %q", name)
+	}
+	return nil
+}
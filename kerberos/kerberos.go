@@ -0,0 +1,154 @@
+// Author hoenig
+
+// Package kerberos negotiates the SPNEGO token with which to login
+// with the kerberos auth backend of vault, from a keytab or from the
+// credential cache of a user who has already run kinit, e.g.
+//
+//	token, err := kerberos.Login(client, kerberos.Options{
+//		Username:         "svc-deploy",
+//		Realm:            "EXAMPLE.COM",
+//		KeytabPath:       "/etc/deploy.keytab",
+//		ServicePrincipal: "HTTP/vault.example.com",
+//	})
+package kerberos
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/pkg/errors"
+	"github.com/shoenig/vaultapi"
+)
+
+const defaultKrb5Conf = "/etc/krb5.conf"
+
+// Options are used to negotiate a SPNEGO token.
+type Options struct {
+	// Username and Realm are the principal to login as, which must
+	// be set when logging in with a keytab.
+	Username string
+	Realm    string
+
+	// KeytabPath is the path of the keytab of Username. If empty, the
+	// tickets of the credential cache at CCachePath are used instead.
+	KeytabPath string
+
+	// CCachePath is the path of the credential cache to use if there
+	// is no keytab. By default, it is the file of the KRB5CCNAME
+	// environment variable, or /tmp/krb5cc_<uid>.
+	CCachePath string
+
+	// Krb5ConfPath is the path of the Kerberos configuration. By
+	// default, it is the KRB5_CONFIG environment variable, or
+	// /etc/krb5.conf.
+	Krb5ConfPath string
+
+	// ServicePrincipal is the service principal of vault, which the
+	// kerberos backend is configured with, e.g. "HTTP/vault.example.com".
+	ServicePrincipal string
+
+	// DisableFASTNegotiation disables the PA-FX-FAST pre-authentication
+	// of the login to the KDC, which Active Directory does not support.
+	DisableFASTNegotiation bool
+}
+
+// Login negotiates a SPNEGO token with opts, and uses it to login
+// with the kerberos auth backend of auth.
+func Login(auth vaultapi.KerberosAuth, opts Options) (vaultapi.CreatedToken, error) {
+	token, err := SPNEGOToken(opts)
+	if err != nil {
+		return vaultapi.CreatedToken{}, err
+	}
+	return auth.LoginKerberos(token)
+}
+
+// SPNEGOToken negotiates a SPNEGO token for the service principal of
+// vault, by logging into the KDC with the keytab or credential cache
+// of opts and requesting a service ticket.
+func SPNEGOToken(opts Options) ([]byte, error) {
+	if opts.ServicePrincipal == "" {
+		return nil, errors.New("kerberos service principal must be set")
+	}
+
+	conf, err := config.Load(krb5ConfPath(opts))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load kerberos config")
+	}
+
+	cl, err := newClient(opts, conf)
+	if err != nil {
+		return nil, err
+	}
+	defer cl.Destroy()
+
+	negotiator := spnego.SPNEGOClient(cl, opts.ServicePrincipal)
+	if err := negotiator.AcquireCred(); err != nil {
+		return nil, errors.Wrap(err, "failed to login to kerberos KDC")
+	}
+
+	token, err := negotiator.InitSecContext()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get kerberos service ticket for %q", opts.ServicePrincipal)
+	}
+
+	bs, err := token.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal SPNEGO token")
+	}
+	return bs, nil
+}
+
+func newClient(opts Options, conf *config.Config) (*client.Client, error) {
+	settings := client.DisablePAFXFAST(opts.DisableFASTNegotiation)
+
+	if opts.KeytabPath != "" {
+		if opts.Username == "" || opts.Realm == "" {
+			return nil, errors.New("kerberos username and realm must be set to login with a keytab")
+		}
+
+		kt, err := keytab.Load(opts.KeytabPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load kerberos keytab %q", opts.KeytabPath)
+		}
+		return client.NewWithKeytab(opts.Username, opts.Realm, kt, conf, settings), nil
+	}
+
+	path := ccachePath(opts)
+	ccache, err := credentials.LoadCCache(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load kerberos credential cache %q", path)
+	}
+
+	cl, err := client.NewFromCCache(ccache, conf, settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to use kerberos credential cache %q", path)
+	}
+	return cl, nil
+}
+
+func krb5ConfPath(opts Options) string {
+	if opts.Krb5ConfPath != "" {
+		return opts.Krb5ConfPath
+	}
+	if path := os.Getenv("KRB5_CONFIG"); path != "" {
+		return path
+	}
+	return defaultKrb5Conf
+}
+
+func ccachePath(opts Options) string {
+	if opts.CCachePath != "" {
+		return opts.CCachePath
+	}
+	if name := os.Getenv("KRB5CCNAME"); name != "" {
+		// only caches of the FILE type can be loaded
+		return strings.TrimPrefix(name, "FILE:")
+	}
+	return fmt.Sprintf("/tmp/krb5cc_%d", os.Getuid())
+}
@@ -0,0 +1,80 @@
+// Author hoenig
+
+package kerberos
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const krb5Conf = `[libdefaults]
+  default_realm = EXAMPLE.COM
+
+[realms]
+  EXAMPLE.COM = {
+    kdc = 127.0.0.1:88
+  }
+`
+
+func writeKrb5Conf(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "kerberos")
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "krb5.conf")
+	require.NoError(t, ioutil.WriteFile(path, []byte(krb5Conf), 0600))
+	return path
+}
+
+func Test_SPNEGOToken_invalid(t *testing.T) {
+	conf := writeKrb5Conf(t)
+	defer os.RemoveAll(filepath.Dir(conf))
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{
+			name: "no service principal",
+			opts: Options{Krb5ConfPath: conf, KeytabPath: "/etc/deploy.keytab"},
+		},
+		{
+			name: "no krb5.conf",
+			opts: Options{Krb5ConfPath: "/does/not/exist", ServicePrincipal: "HTTP/vault"},
+		},
+		{
+			name: "keytab without username",
+			opts: Options{Krb5ConfPath: conf, ServicePrincipal: "HTTP/vault", KeytabPath: "/etc/deploy.keytab", Realm: "EXAMPLE.COM"},
+		},
+		{
+			name: "missing keytab",
+			opts: Options{Krb5ConfPath: conf, ServicePrincipal: "HTTP/vault", KeytabPath: "/does/not/exist", Username: "deploy", Realm: "EXAMPLE.COM"},
+		},
+		{
+			name: "missing ccache",
+			opts: Options{Krb5ConfPath: conf, ServicePrincipal: "HTTP/vault", CCachePath: "/does/not/exist"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := SPNEGOToken(test.opts)
+			require.Error(t, err)
+		})
+	}
+}
+
+func Test_ccachePath(t *testing.T) {
+	os.Unsetenv("KRB5CCNAME")
+	require.Equal(t, fmt.Sprintf("/tmp/krb5cc_%d", os.Getuid()), ccachePath(Options{}))
+
+	os.Setenv("KRB5CCNAME", "FILE:/run/user/krb5cc")
+	defer os.Unsetenv("KRB5CCNAME")
+	require.Equal(t, "/run/user/krb5cc", ccachePath(Options{}))
+
+	require.Equal(t, "/tmp/cache", ccachePath(Options{CCachePath: "/tmp/cache"}))
+}
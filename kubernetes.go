@@ -0,0 +1,178 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ServiceAccountTokenFile is the location at which kubernetes
+// mounts the service account JWT into every pod by default.
+const ServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// KubernetesAuth provides a way for pods running in kubernetes
+// to authenticate with vault by using their service account
+// JWT, and to manage the configuration and roles of the
+// kubernetes auth backend.
+//
+// The kubernetes backend must be enabled at the default
+// path of auth/kubernetes for this API to work.
+//
+// More information about the kubernetes auth backend
+// can be found here:
+// https://www.vaultproject.io/docs/auth/kubernetes.html
+type KubernetesAuth interface {
+	LoginKubernetes(role, jwt string) (CreatedToken, error)
+	ConfigureKubernetes(config KubernetesConfig) error
+	LookupKubernetesConfig() (KubernetesConfig, error)
+	CreateKubernetesRole(opts KubernetesRoleOptions) error
+	LookupKubernetesRole(name string) (LookedUpKubernetesRole, error)
+	ListKubernetesRoles() ([]string, error)
+	DeleteKubernetesRole(name string) error
+}
+
+// ServiceAccountJWT reads the service account JWT of the
+// pod from the default location of ServiceAccountTokenFile.
+func ServiceAccountJWT() (string, error) {
+	bs, err := ioutil.ReadFile(ServiceAccountTokenFile)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read service account jwt")
+	}
+	return strings.TrimSpace(string(bs)), nil
+}
+
+type kubernetesLogin struct {
+	Role string `json:"role"`
+	JWT  string `json:"jwt"`
+}
+
+// LoginKubernetes will login using the given service account jwt.
+// If jwt is empty, the JWT of the service account of the pod is
+// read from ServiceAccountTokenFile.
+func (c *client) LoginKubernetes(role, jwt string) (CreatedToken, error) {
+	if jwt == "" {
+		saJWT, err := ServiceAccountJWT()
+		if err != nil {
+			return CreatedToken{}, err
+		}
+		jwt = saJWT
+	}
+
+	return c.login("kubernetes", "/v1/auth/kubernetes/login", kubernetesLogin{
+		Role: role,
+		JWT:  jwt,
+	})
+}
+
+// KubernetesConfig is the configuration of the kubernetes auth
+// backend, describing how vault communicates with the kubernetes
+// API server to validate service account JWTs.
+type KubernetesConfig struct {
+	Host             string   `json:"kubernetes_host"`
+	CACert           string   `json:"kubernetes_ca_cert,omitempty"`
+	TokenReviewerJWT string   `json:"token_reviewer_jwt,omitempty"`
+	PEMKeys          []string `json:"pem_keys,omitempty"`
+}
+
+func (c *client) ConfigureKubernetes(config KubernetesConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling kubernetes config to JSON request body")
+	}
+
+	if err := c.post("/v1/auth/kubernetes/config", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure kubernetes auth")
+	}
+
+	return nil
+}
+
+type kubernetesConfigWrapper struct {
+	Data KubernetesConfig `json:"data"`
+}
+
+func (c *client) LookupKubernetesConfig() (KubernetesConfig, error) {
+	var wrapper kubernetesConfigWrapper
+	if err := c.get("/v1/auth/kubernetes/config", &wrapper); err != nil {
+		return KubernetesConfig{}, errors.Wrap(err, "failed to read kubernetes auth config")
+	}
+	return wrapper.Data, nil
+}
+
+// KubernetesRoleOptions are used to define properties of a
+// kubernetes role being created or updated. A role binds service
+// accounts in namespaces to a set of policies. Durations are
+// expressed as strings that vault understands, such as "1h".
+type KubernetesRoleOptions struct {
+	Name                     string   `json:"-"`
+	BoundServiceAccountNames []string `json:"bound_service_account_names"`
+	BoundNamespaces          []string `json:"bound_service_account_namespaces"`
+	Policies                 []string `json:"policies,omitempty"`
+	TTL                      string   `json:"ttl,omitempty"`
+	MaxTTL                   string   `json:"max_ttl,omitempty"`
+	Period                   string   `json:"period,omitempty"`
+}
+
+func (c *client) CreateKubernetesRole(opts KubernetesRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling kubernetes role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/kubernetes/role/%s", opts.Name)
+	c.logRequest("kubernetes-role-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating kubernetes role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpKubernetesRoleWrapper struct {
+	Data LookedUpKubernetesRole `json:"data"`
+}
+
+// A LookedUpKubernetesRole represents information returned from
+// vault after making a request for information about a particular
+// kubernetes role.
+type LookedUpKubernetesRole struct {
+	BoundServiceAccountNames []string `json:"bound_service_account_names"`
+	BoundNamespaces          []string `json:"bound_service_account_namespaces"`
+	Policies                 []string `json:"policies"`
+	TTL                      int      `json:"ttl"`
+	MaxTTL                   int      `json:"max_ttl"`
+	Period                   int      `json:"period"`
+}
+
+func (c *client) LookupKubernetesRole(name string) (LookedUpKubernetesRole, error) {
+	var wrapper lookedUpKubernetesRoleWrapper
+	requestPath := fmt.Sprintf("/v1/auth/kubernetes/role/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpKubernetesRole{}, errors.Wrapf(err, "failed to look up kubernetes role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListKubernetesRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/auth/kubernetes/role"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list kubernetes roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteKubernetesRole(name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/kubernetes/role/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete kubernetes role %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_client_LoginKubernetes(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/auth/kubernetes/login": `{"auth":{"client_token":"s.k8s","policies":["web"]}}`,
+	})
+
+	token, err := vault.LoginKubernetes("web", "eyJhbGciOi")
+	require.NoError(t, err)
+	require.Equal(t, "s.k8s", token.ID)
+	require.Equal(t, []string{"web"}, token.Policies)
+
+	require.Equal(t, []fakeRequest{{
+		Method: "POST",
+		URI:    "/v1/auth/kubernetes/login",
+		Body:   `{"role":"web","jwt":"eyJhbGciOi"}`,
+	}}, vault.requests)
+}
+
+func Test_client_KubernetesConfig(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/auth/kubernetes/config": "",
+		"GET /v1/auth/kubernetes/config":  `{"data":{"kubernetes_host":"https://10.0.0.1:443","pem_keys":["key"]}}`,
+	})
+
+	err := vault.ConfigureKubernetes(KubernetesConfig{Host: "https://10.0.0.1:443"})
+	require.NoError(t, err)
+	require.Equal(t, `{"kubernetes_host":"https://10.0.0.1:443"}`, vault.requests[0].Body)
+
+	config, err := vault.LookupKubernetesConfig()
+	require.NoError(t, err)
+	require.Equal(t, KubernetesConfig{
+		Host:    "https://10.0.0.1:443",
+		PEMKeys: []string{"key"},
+	}, config)
+}
+
+func Test_client_KubernetesRoles(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/auth/kubernetes/role/web":   "",
+		"GET /v1/auth/kubernetes/role/web":    `{"data":{"bound_service_account_names":["web"],"bound_service_account_namespaces":["prod"],"policies":["web"],"ttl":3600}}`,
+		"LIST /v1/auth/kubernetes/role":       `{"data":{"keys":["web","api"]}}`,
+		"DELETE /v1/auth/kubernetes/role/web": "",
+	})
+
+	err := vault.CreateKubernetesRole(KubernetesRoleOptions{
+		Name:                     "web",
+		BoundServiceAccountNames: []string{"web"},
+		BoundNamespaces:          []string{"prod"},
+		Policies:                 []string{"web"},
+		TTL:                      "1h",
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"bound_service_account_names":["web"],"bound_service_account_namespaces":["prod"],"policies":["web"],"ttl":"1h"}`,
+		vault.requests[0].Body,
+	)
+
+	role, err := vault.LookupKubernetesRole("web")
+	require.NoError(t, err)
+	require.Equal(t, LookedUpKubernetesRole{
+		BoundServiceAccountNames: []string{"web"},
+		BoundNamespaces:          []string{"prod"},
+		Policies:                 []string{"web"},
+		TTL:                      3600,
+	}, role)
+
+	roles, err := vault.ListKubernetesRoles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"api", "web"}, roles)
+
+	require.NoError(t, vault.DeleteKubernetesRole("web"))
+
+	_, err = vault.LookupKubernetesRole("api")
+	require.Error(t, err)
+}
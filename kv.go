@@ -5,6 +5,7 @@ package vaultapi
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -16,12 +17,17 @@ import (
 // helps simplify code for clients, the 99% use case for which
 // is writing secret passwords and other stringy information
 // into vault for safe keeping.
+//
+// The KV methods of a Client operate on the kv store mounted at
+// secret/, which exists by default. Use KVMount to operate on a
+// kv store mounted elsewhere.
 type KV interface {
 	// Get will return the value defined at path.
 	Get(path string) (string, error)
 	// Put will set value at path.
 	Put(path, value string) error
-	// Delete will remove the value at path.
+	// Delete will remove the value at path. If path ends with
+	// a trailing slash, all values under path are removed.
 	Delete(path string) error
 	// Keys will list all of the subpaths under path in asciibetical
 	// order. The returned paths may be terminal (ie, the value is
@@ -34,10 +40,44 @@ var (
 	ErrNoValue = errors.New("no value defined for given path")
 )
 
+const defaultKVMount = "secret"
+
 func (c *client) Get(path string) (string, error) {
-	fullpath := fixup("/v1/secret", path, [2]string{"list", "false"})
+	return c.KVMount(defaultKVMount).Get(path)
+}
+
+func (c *client) Put(path, value string) error {
+	return c.KVMount(defaultKVMount).Put(path, value)
+}
+
+func (c *client) Delete(path string) error {
+	return c.KVMount(defaultKVMount).Delete(path)
+}
+
+func (c *client) Keys(path string) ([]string, error) {
+	return c.KVMount(defaultKVMount).Keys(path)
+}
+
+func (c *client) KVMount(mount string) KV {
+	return &kv{
+		client: c,
+		prefix: "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+func (c *client) Cubbyhole() KV {
+	return c.KVMount("cubbyhole")
+}
+
+type kv struct {
+	client *client
+	prefix string
+}
+
+func (k *kv) Get(path string) (string, error) {
+	fullpath := fixup(k.prefix, path, [2]string{"list", "false"})
 	var data keyData
-	err := c.get(fullpath, &data)
+	err := k.client.get(fullpath, &data)
 	if err != nil {
 		return "", err
 	}
@@ -50,21 +90,46 @@ func (c *client) Get(path string) (string, error) {
 	return value, nil
 }
 
-func (c *client) Put(path, value string) error {
-	fullpath := fixup("/v1/secret", path, [2]string{})
+func (k *kv) Put(path, value string) error {
+	fullpath := fixup(k.prefix, path, [2]string{})
 	body := fmt.Sprintf(`{%q:%q}`, "value", value)
-	return c.post(fullpath, body, nil)
+	return k.client.post(fullpath, body, nil)
 }
 
-func (c *client) Delete(path string) error {
-	fullpath := fixup("/v1/secret", path, [2]string{})
-	return c.delete(fullpath)
+// we have to implement recursion ourselves - which will
+// be the case for paths that end in a trailing slash
+// see: https://github.com/hashicorp/vault/issues/885
+func (k *kv) Delete(path string) error {
+	fullpath := fixup(k.prefix, path, [2]string{})
+	k.client.opts.Logger.Debugf("delete %q", fullpath)
+
+	// recursively descend if this path is a directory
+	if strings.HasSuffix(fullpath, "/") {
+		keys, err := k.Keys(path)
+		if err != nil {
+			k.client.opts.Logger.Errorf("delete recursion error: %v", err)
+			return err
+		}
+		k.client.opts.Logger.Debugf("recursive keys: %v", keys)
+		// call delete on every key under this path
+		for _, subpath := range keys {
+			if err := k.Delete(strings.TrimSuffix(path, "/") + "/" + subpath); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+	// base case: actually delete this path, which is a concrete
+	// key and not a directory
+	k.client.opts.Logger.Debugf("delete concrete path: %q", fullpath)
+	return k.client.delete(fullpath)
 }
 
-func (c *client) Keys(path string) ([]string, error) {
-	fullpath := fixup("/v1/secret", path, [2]string{"list", "true"})
+func (k *kv) Keys(path string) ([]string, error) {
+	fullpath := fixup(k.prefix, path, [2]string{"list", "true"})
 	var data keysData
-	err := c.get(fullpath, &data)
+	err := k.client.get(fullpath, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,312 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A KV2 represents the versioned key-value store (version 2 of the
+// kv secrets engine) built into vault.
+//
+// Like KV, this library assumes all keys and values are strings,
+// though each secret of a KV2 may hold more than one value. Every
+// write of a secret creates a new version, and older versions can
+// still be read until they are deleted or pruned.
+//
+// More information about the versioned kv store can be found here:
+// https://www.vaultproject.io/docs/secrets/kv/kv-v2.html
+type KV2 interface {
+	// Get will return the latest version of the secret at path.
+	Get(path string) (KV2Secret, error)
+	// GetVersion will return a specific version of the secret at path.
+	GetVersion(path string, version int) (KV2Secret, error)
+	// Put will write data as a new version of the secret at path.
+	Put(path string, data map[string]string) (KV2Version, error)
+	// PutCAS will write data as a new version of the secret at path
+	// only if cas is the current version of the secret. A cas of 0
+	// means the write is only allowed if the secret does not exist.
+	PutCAS(path string, data map[string]string, cas int) (KV2Version, error)
+	// Delete will soft delete the latest version of the secret at path.
+	Delete(path string) error
+	// DeleteVersions will soft delete the given versions of the
+	// secret at path.
+	DeleteVersions(path string, versions []int) error
+	// Keys will list all of the subpaths under path in asciibetical
+	// order, the same as KV.
+	Keys(path string) ([]string, error)
+
+	// GetMetadata will return the metadata of the secret at path,
+	// including the metadata of every version of the secret.
+	GetMetadata(path string) (KV2Metadata, error)
+	// PutMetadata will set the configurable metadata of the secret
+	// at path, which need not exist yet.
+	PutMetadata(path string, opts KV2MetadataOptions) error
+
+	// Undelete will restore the given soft deleted versions of
+	// the secret at path.
+	Undelete(path string, versions []int) error
+	// Destroy will permanently remove the data of the given versions
+	// of the secret at path. Destroyed versions cannot be restored.
+	Destroy(path string, versions []int) error
+	// DestroyAllVersions will permanently remove the metadata and
+	// every version of the secret at path.
+	DestroyAllVersions(path string) error
+
+	// Subkeys will return the structure of the keys of the given
+	// version of the secret at path, without any of the values. A
+	// version of 0 means the latest version. Every leaf is nil, and
+	// every nested object is a map[string]interface{}.
+	Subkeys(path string, version int) (map[string]interface{}, error)
+}
+
+// A KV2Secret is one version of a secret in a KV2, along with
+// the metadata of that version.
+type KV2Secret struct {
+	Data     map[string]string `json:"data"`
+	Metadata KV2Version        `json:"metadata"`
+}
+
+// A KV2Version is the metadata of one version of a secret in a KV2.
+type KV2Version struct {
+	Version      int    `json:"version"`
+	CreatedTime  string `json:"created_time"`
+	DeletionTime string `json:"deletion_time"`
+	Destroyed    bool   `json:"destroyed"`
+}
+
+func (c *client) KV2Mount(mount string) KV2 {
+	return &kv2{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type kv2 struct {
+	client *client
+	mount  string
+}
+
+// prefix returns the path prefix of the given kind of
+// endpoint (data, metadata, delete, etc.) in the mount
+func (k *kv2) prefix(kind string) string {
+	return k.mount + "/" + kind
+}
+
+type kv2SecretWrapper struct {
+	Data KV2Secret `json:"data"`
+}
+
+func (k *kv2) Get(path string) (KV2Secret, error) {
+	return k.GetVersion(path, 0)
+}
+
+func versionParam(version int) [2]string {
+	if version > 0 {
+		return [2]string{"version", strconv.Itoa(version)}
+	}
+	return [2]string{}
+}
+
+func (k *kv2) GetVersion(path string, version int) (KV2Secret, error) {
+	fullpath := fixup(k.prefix("data"), path, versionParam(version))
+
+	var wrapper kv2SecretWrapper
+	if err := k.client.get(fullpath, &wrapper); err != nil {
+		return KV2Secret{}, err
+	}
+
+	if wrapper.Data.Data == nil {
+		// a deleted or destroyed version has no data
+		return KV2Secret{}, ErrNoValue
+	}
+
+	return wrapper.Data, nil
+}
+
+type kv2Write struct {
+	Options map[string]int    `json:"options,omitempty"`
+	Data    map[string]string `json:"data"`
+}
+
+type kv2VersionWrapper struct {
+	Data KV2Version `json:"data"`
+}
+
+func (k *kv2) Put(path string, data map[string]string) (KV2Version, error) {
+	return k.put(path, kv2Write{Data: data})
+}
+
+func (k *kv2) PutCAS(path string, data map[string]string, cas int) (KV2Version, error) {
+	return k.put(path, kv2Write{
+		Options: map[string]int{"cas": cas},
+		Data:    data,
+	})
+}
+
+func (k *kv2) put(path string, write kv2Write) (KV2Version, error) {
+	bs, err := json.Marshal(write)
+	if err != nil {
+		return KV2Version{}, err
+	}
+
+	fullpath := fixup(k.prefix("data"), path)
+	var wrapper kv2VersionWrapper
+	if err := k.client.post(fullpath, string(bs), &wrapper); err != nil {
+		return KV2Version{}, errors.Wrapf(err, "failed to write secret to %q", fullpath)
+	}
+
+	return wrapper.Data, nil
+}
+
+func (k *kv2) Delete(path string) error {
+	fullpath := fixup(k.prefix("data"), path)
+	return k.client.delete(fullpath)
+}
+
+type kv2Versions struct {
+	Versions []int `json:"versions"`
+}
+
+func (k *kv2) DeleteVersions(path string, versions []int) error {
+	bs, err := json.Marshal(kv2Versions{Versions: versions})
+	if err != nil {
+		return err
+	}
+
+	fullpath := fixup(k.prefix("delete"), path)
+	if err := k.client.post(fullpath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to delete versions %v of %q", versions, fullpath)
+	}
+
+	return nil
+}
+
+func (k *kv2) Keys(path string) ([]string, error) {
+	fullpath := fixup(k.prefix("metadata"), path)
+	var data keysData
+	if err := k.client.list(fullpath, &data); err != nil {
+		return nil, err
+	}
+	keys := data.Data["keys"]
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// KV2Metadata is the metadata of a secret in a KV2, which applies
+// across all versions of the secret.
+type KV2Metadata struct {
+	CASRequired        bool               `json:"cas_required"`
+	CreatedTime        string             `json:"created_time"`
+	UpdatedTime        string             `json:"updated_time"`
+	CurrentVersion     int                `json:"current_version"`
+	OldestVersion      int                `json:"oldest_version"`
+	MaxVersions        int                `json:"max_versions"`
+	DeleteVersionAfter string             `json:"delete_version_after"`
+	CustomMetadata     map[string]string  `json:"custom_metadata"`
+	Versions           map[int]KV2Version `json:"versions"`
+}
+
+// KV2MetadataOptions are the configurable parts of the metadata
+// of a secret in a KV2. DeleteVersionAfter is expressed as a
+// duration string that vault understands, such as "768h". Options
+// which are not set are left unchanged; use Int and Bool to set
+// MaxVersions and CASRequired, e.g. to Int(0) to reset MaxVersions
+// to the default of the mount.
+type KV2MetadataOptions struct {
+	MaxVersions        *int              `json:"max_versions,omitempty"`
+	CASRequired        *bool             `json:"cas_required,omitempty"`
+	DeleteVersionAfter string            `json:"delete_version_after,omitempty"`
+	CustomMetadata     map[string]string `json:"custom_metadata,omitempty"`
+}
+
+type kv2MetadataWrapper struct {
+	Data KV2Metadata `json:"data"`
+}
+
+func (k *kv2) GetMetadata(path string) (KV2Metadata, error) {
+	fullpath := fixup(k.prefix("metadata"), path)
+	var wrapper kv2MetadataWrapper
+	if err := k.client.get(fullpath, &wrapper); err != nil {
+		return KV2Metadata{}, err
+	}
+
+	// the version number is only the key of each version
+	for number, version := range wrapper.Data.Versions {
+		version.Version = number
+		wrapper.Data.Versions[number] = version
+	}
+
+	return wrapper.Data, nil
+}
+
+func (k *kv2) PutMetadata(path string, opts KV2MetadataOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+
+	fullpath := fixup(k.prefix("metadata"), path)
+	if err := k.client.post(fullpath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to write metadata to %q", fullpath)
+	}
+
+	return nil
+}
+
+func (k *kv2) Undelete(path string, versions []int) error {
+	bs, err := json.Marshal(kv2Versions{Versions: versions})
+	if err != nil {
+		return err
+	}
+
+	fullpath := fixup(k.prefix("undelete"), path)
+	if err := k.client.post(fullpath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to undelete versions %v of %q", versions, fullpath)
+	}
+
+	return nil
+}
+
+func (k *kv2) Destroy(path string, versions []int) error {
+	bs, err := json.Marshal(kv2Versions{Versions: versions})
+	if err != nil {
+		return err
+	}
+
+	fullpath := fixup(k.prefix("destroy"), path)
+	if err := k.client.put(fullpath, string(bs)); err != nil {
+		return errors.Wrapf(err, "failed to destroy versions %v of %q", versions, fullpath)
+	}
+
+	return nil
+}
+
+// deleting the metadata of a secret is what destroys every version
+func (k *kv2) DestroyAllVersions(path string) error {
+	fullpath := fixup(k.prefix("metadata"), path)
+	if err := k.client.delete(fullpath); err != nil {
+		return errors.Wrapf(err, "failed to destroy all versions of %q", fullpath)
+	}
+	return nil
+}
+
+type kv2SubkeysWrapper struct {
+	Data struct {
+		Subkeys map[string]interface{} `json:"subkeys"`
+	} `json:"data"`
+}
+
+func (k *kv2) Subkeys(path string, version int) (map[string]interface{}, error) {
+	fullpath := fixup(k.prefix("subkeys"), path, versionParam(version))
+	var wrapper kv2SubkeysWrapper
+	if err := k.client.get(fullpath, &wrapper); err != nil {
+		return nil, err
+	}
+	return wrapper.Data.Subkeys, nil
+}
@@ -0,0 +1,172 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_kv2_PutMetadata(t *testing.T) {
+	var bodies []string
+
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(request.Body)
+		bodies = append(bodies, string(body))
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+	kv2 := client.KV2Mount("secret")
+
+	// options which are not set are not sent, leaving them unchanged
+	err = kv2.PutMetadata("foo", KV2MetadataOptions{
+		CustomMetadata: map[string]string{"owner": "ops"},
+	})
+	require.NoError(t, err)
+
+	// options set to their zero values are still sent
+	err = kv2.PutMetadata("foo", KV2MetadataOptions{
+		MaxVersions: Int(0),
+		CASRequired: Bool(false),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		`{"custom_metadata":{"owner":"ops"}}`,
+		`{"max_versions":0,"cas_required":false}`,
+	}, bodies)
+}
+
+func Test_Client_KV2_CAS(t *testing.T) {
+	client := getClient(t, rootTokener)
+	kv2 := client.KV2Mount("kv2")
+	defer func() {
+		require.NoError(t, kv2.DestroyAllVersions("/foo/bar"))
+	}()
+
+	// a cas of 0 only writes a secret which does not exist yet
+	version, err := kv2.PutCAS("/foo/bar", map[string]string{"value": "one"}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, version.Version)
+
+	_, err = kv2.PutCAS("/foo/bar", map[string]string{"value": "two"}, 0)
+	require.Error(t, err)
+
+	version, err = kv2.PutCAS("/foo/bar", map[string]string{"value": "two"}, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, version.Version)
+
+	// a stale cas is rejected
+	_, err = kv2.PutCAS("/foo/bar", map[string]string{"value": "three"}, 1)
+	require.Error(t, err)
+
+	secret, err := kv2.Get("/foo/bar")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"value": "two"}, secret.Data)
+	require.Equal(t, 2, secret.Metadata.Version)
+
+	secret, err = kv2.GetVersion("/foo/bar", 1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"value": "one"}, secret.Data)
+
+	// once cas is required, writes without a cas are rejected
+	err = kv2.PutMetadata("/foo/bar", KV2MetadataOptions{CASRequired: Bool(true)})
+	require.NoError(t, err)
+
+	_, err = kv2.Put("/foo/bar", map[string]string{"value": "three"})
+	require.Error(t, err)
+
+	version, err = kv2.PutCAS("/foo/bar", map[string]string{"value": "three"}, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, version.Version)
+
+	keys, err := kv2.Keys("/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo/"}, keys)
+}
+
+func Test_Client_KV2_versions(t *testing.T) {
+	client := getClient(t, rootTokener)
+	kv2 := client.KV2Mount("kv2")
+	defer func() {
+		require.NoError(t, kv2.DestroyAllVersions("/lifecycle"))
+		_, err := kv2.GetMetadata("/lifecycle")
+		require.Error(t, err)
+	}()
+
+	for _, value := range []string{"one", "two", "three"} {
+		_, err := kv2.Put("/lifecycle", map[string]string{"value": value})
+		require.NoError(t, err)
+	}
+
+	// soft deleting the latest version leaves the others readable
+	require.NoError(t, kv2.Delete("/lifecycle"))
+	_, err := kv2.Get("/lifecycle")
+	require.Error(t, err)
+
+	secret, err := kv2.GetVersion("/lifecycle", 2)
+	require.NoError(t, err)
+	require.Equal(t, "two", secret.Data["value"])
+
+	require.NoError(t, kv2.DeleteVersions("/lifecycle", []int{1}))
+	_, err = kv2.GetVersion("/lifecycle", 1)
+	require.Error(t, err)
+
+	// soft deleted versions can be restored
+	require.NoError(t, kv2.Undelete("/lifecycle", []int{1, 3}))
+
+	secret, err = kv2.GetVersion("/lifecycle", 1)
+	require.NoError(t, err)
+	require.Equal(t, "one", secret.Data["value"])
+
+	secret, err = kv2.Get("/lifecycle")
+	require.NoError(t, err)
+	require.Equal(t, "three", secret.Data["value"])
+
+	// destroyed versions cannot be restored
+	require.NoError(t, kv2.Destroy("/lifecycle", []int{2}))
+	require.NoError(t, kv2.Undelete("/lifecycle", []int{2}))
+	_, err = kv2.GetVersion("/lifecycle", 2)
+	require.Error(t, err)
+
+	metadata, err := kv2.GetMetadata("/lifecycle")
+	require.NoError(t, err)
+	require.Equal(t, 3, metadata.CurrentVersion)
+	require.Len(t, metadata.Versions, 3)
+	require.True(t, metadata.Versions[2].Destroyed)
+	require.False(t, metadata.Versions[3].Destroyed)
+	require.Empty(t, metadata.Versions[3].DeletionTime)
+}
+
+func Test_Client_KV2_Subkeys(t *testing.T) {
+	client := getClient(t, rootTokener)
+	kv2 := client.KV2Mount("kv2")
+	defer func() {
+		require.NoError(t, kv2.DestroyAllVersions("/subkeys"))
+	}()
+
+	_, err := kv2.Put("/subkeys", map[string]string{"user": "admin", "password": "hunter2"})
+	require.NoError(t, err)
+
+	_, err = kv2.Put("/subkeys", map[string]string{"user": "admin"})
+	require.NoError(t, err)
+
+	// the structure of the keys, without any of the values
+	subkeys, err := kv2.Subkeys("/subkeys", 0)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"user": nil}, subkeys)
+
+	subkeys, err = kv2.Subkeys("/subkeys", 1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"user": nil, "password": nil}, subkeys)
+}
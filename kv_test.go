@@ -48,3 +48,57 @@ func Test_Client_KV(t *testing.T) {
 	t.Log("del error:", err)
 	require.Error(t, err)
 }
+
+func Test_Client_KVMount(t *testing.T) {
+	client := getClient(t, rootTokener)
+	kv := client.KVMount("kv1")
+
+	err := kv.Put("/foo/bar", "baz")
+	require.NoError(t, err)
+
+	value, err := kv.Get("/foo/bar")
+	require.NoError(t, err)
+	require.Equal(t, "baz", value)
+
+	// the default secret mount is not affected
+	_, err = client.Get("/foo/bar")
+	require.Error(t, err)
+
+	keys, err := kv.Keys("/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo/"}, keys)
+
+	// recursively delete everything in the mount
+	err = kv.Delete("/")
+	require.NoError(t, err)
+
+	_, err = kv.Get("/foo/bar")
+	require.Error(t, err)
+}
+
+func Test_Client_Cubbyhole(t *testing.T) {
+	client := getClient(t, rootTokener)
+	cubbyhole := client.Cubbyhole()
+
+	err := cubbyhole.Put("/intro/secret", "s3cr3t")
+	require.NoError(t, err)
+
+	value, err := cubbyhole.Get("/intro/secret")
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", value)
+
+	keys, err := cubbyhole.Keys("/intro")
+	require.NoError(t, err)
+	require.Equal(t, []string{"secret"}, keys)
+
+	// the cubbyhole of another token is separate
+	other := getClient(t, renewableTokener)
+	_, err = other.Cubbyhole().Get("/intro/secret")
+	require.Error(t, err)
+
+	err = cubbyhole.Delete("/intro/secret")
+	require.NoError(t, err)
+
+	_, err = cubbyhole.Get("/intro/secret")
+	require.Error(t, err)
+}
@@ -0,0 +1,195 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// LDAPAuth provides a way for users to authenticate with vault
+// by using their LDAP (or Active Directory) credentials, and to
+// manage the configuration of the ldap auth backend along with
+// the mappings of LDAP groups and users onto vault policies.
+//
+// The ldap backend must be enabled at the default path of
+// auth/ldap for this API to work.
+//
+// More information about the ldap auth backend can be found here:
+// https://www.vaultproject.io/docs/auth/ldap.html
+type LDAPAuth interface {
+	LoginLDAP(username, password string) (CreatedToken, error)
+	ConfigureLDAP(config LDAPConfig) error
+	LookupLDAPConfig() (LDAPConfig, error)
+	SetLDAPGroup(name string, policies []string) error
+	LookupLDAPGroup(name string) (LDAPGroup, error)
+	ListLDAPGroups() ([]string, error)
+	DeleteLDAPGroup(name string) error
+	SetLDAPUser(name string, groups, policies []string) error
+	LookupLDAPUser(name string) (LDAPUser, error)
+	ListLDAPUsers() ([]string, error)
+	DeleteLDAPUser(name string) error
+}
+
+type passwordLogin struct {
+	Password string `json:"password"`
+}
+
+func (c *client) LoginLDAP(username, password string) (CreatedToken, error) {
+	requestPath := fmt.Sprintf("/v1/auth/ldap/login/%s", username)
+	return c.login("ldap", requestPath, passwordLogin{Password: password})
+}
+
+// LDAPConfig is the configuration of the ldap auth backend,
+// describing how vault connects to the LDAP server and how users
+// and groups are found. More information about each of the options
+// can be found in the ldap auth backend API documentation at:
+// https://www.vaultproject.io/api/auth/ldap/index.html
+type LDAPConfig struct {
+	URL          string `json:"url"`
+	UserDN       string `json:"userdn,omitempty"`
+	UserAttr     string `json:"userattr,omitempty"`
+	DiscoverDN   bool   `json:"discoverdn"`
+	UPNDomain    string `json:"upndomain,omitempty"`
+	GroupDN      string `json:"groupdn,omitempty"`
+	GroupFilter  string `json:"groupfilter,omitempty"`
+	GroupAttr    string `json:"groupattr,omitempty"`
+	BindDN       string `json:"binddn,omitempty"`
+	BindPassword string `json:"bindpass,omitempty"`
+	Certificate  string `json:"certificate,omitempty"`
+	InsecureTLS  bool   `json:"insecure_tls"`
+	StartTLS     bool   `json:"starttls"`
+	DenyNullBind bool   `json:"deny_null_bind"`
+}
+
+func (c *client) ConfigureLDAP(config LDAPConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling ldap config to JSON request body")
+	}
+
+	if err := c.post("/v1/auth/ldap/config", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure ldap auth")
+	}
+
+	return nil
+}
+
+type ldapConfigWrapper struct {
+	Data LDAPConfig `json:"data"`
+}
+
+func (c *client) LookupLDAPConfig() (LDAPConfig, error) {
+	var wrapper ldapConfigWrapper
+	if err := c.get("/v1/auth/ldap/config", &wrapper); err != nil {
+		return LDAPConfig{}, errors.Wrap(err, "failed to read ldap auth config")
+	}
+	return wrapper.Data, nil
+}
+
+// An LDAPGroup is a mapping of an LDAP group onto the vault
+// policies that members of the group are granted upon login.
+type LDAPGroup struct {
+	Policies []string `json:"policies"`
+}
+
+type ldapGroupWrapper struct {
+	Data LDAPGroup `json:"data"`
+}
+
+func (c *client) SetLDAPGroup(name string, policies []string) error {
+	bs, err := json.Marshal(LDAPGroup{Policies: policies})
+	if err != nil {
+		return errors.Wrap(err, "marshalling ldap group to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/ldap/groups/%s", name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to set ldap group %q", name)
+	}
+
+	return nil
+}
+
+func (c *client) LookupLDAPGroup(name string) (LDAPGroup, error) {
+	var wrapper ldapGroupWrapper
+	requestPath := fmt.Sprintf("/v1/auth/ldap/groups/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LDAPGroup{}, errors.Wrapf(err, "failed to look up ldap group %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListLDAPGroups() ([]string, error) {
+	var wrapper rolesWrapper
+	requestPath := "/v1/auth/ldap/groups"
+	if err := c.list(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list ldap groups at %q", requestPath)
+	}
+	sort.Strings(wrapper.Data.Keys)
+	return wrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteLDAPGroup(name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/ldap/groups/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete ldap group %q", name)
+	}
+	return nil
+}
+
+// An LDAPUser is a mapping of an LDAP user onto vault policies
+// and onto LDAP groups, in addition to any groups the user is a
+// member of in the LDAP server itself.
+type LDAPUser struct {
+	Groups   []string `json:"groups"`
+	Policies []string `json:"policies"`
+}
+
+type ldapUserWrapper struct {
+	Data LDAPUser `json:"data"`
+}
+
+func (c *client) SetLDAPUser(name string, groups, policies []string) error {
+	bs, err := json.Marshal(LDAPUser{Groups: groups, Policies: policies})
+	if err != nil {
+		return errors.Wrap(err, "marshalling ldap user to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/ldap/users/%s", name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to set ldap user %q", name)
+	}
+
+	return nil
+}
+
+func (c *client) LookupLDAPUser(name string) (LDAPUser, error) {
+	var wrapper ldapUserWrapper
+	requestPath := fmt.Sprintf("/v1/auth/ldap/users/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LDAPUser{}, errors.Wrapf(err, "failed to look up ldap user %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListLDAPUsers() ([]string, error) {
+	var wrapper rolesWrapper
+	requestPath := "/v1/auth/ldap/users"
+	if err := c.list(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list ldap users at %q", requestPath)
+	}
+	sort.Strings(wrapper.Data.Keys)
+	return wrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteLDAPUser(name string) error {
+	requestPath := fmt.Sprintf("/v1/auth/ldap/users/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete ldap user %q", name)
+	}
+	return nil
+}
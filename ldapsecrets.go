@@ -0,0 +1,388 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// An LDAPSecrets represents an ldap secrets engine, which manages the
+// passwords of accounts in an LDAP directory such as OpenLDAP or
+// Active Directory. This is unrelated to the LDAPAuth method, which
+// logs in to vault with LDAP accounts.
+//
+// More information about the ldap secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/ldap/index.html
+type LDAPSecrets interface {
+	// Configuration
+	Configure(config LDAPSecretsConfig) error
+	LookupConfig() (LDAPSecretsConfig, error)
+	RotateRoot() error
+
+	// Static roles
+	CreateStaticRole(opts LDAPStaticRoleOptions) error
+	LookupStaticRole(name string) (LookedUpLDAPStaticRole, error)
+	ListStaticRoles() ([]string, error)
+	DeleteStaticRole(name string) error
+	StaticCredentials(name string) (LDAPStaticCredentials, error)
+	RotateStaticRole(name string) error
+
+	// Service account libraries
+	CreateLibrary(opts LDAPLibraryOptions) error
+	LookupLibrary(name string) (LookedUpLDAPLibrary, error)
+	ListLibraries() ([]string, error)
+	DeleteLibrary(name string) error
+	CheckOut(library, ttl string) (LDAPCheckOut, error)
+	CheckIn(library string, accounts []string) ([]string, error)
+	ForceCheckIn(library string, accounts []string) ([]string, error)
+	LibraryStatus(library string) (map[string]LDAPAccountStatus, error)
+}
+
+func (c *client) LDAPSecretsMount(mount string) LDAPSecrets {
+	return &ldapSecrets{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type ldapSecrets struct {
+	client *client
+	mount  string
+}
+
+// LDAPSecretsConfig is the configuration of how vault connects to the
+// LDAP directory. Schema is one of "openldap" (the default), "ad", or
+// "racf". The BindPass is never returned when looking up the config.
+type LDAPSecretsConfig struct {
+	URL            string `json:"url"`
+	BindDN         string `json:"binddn"`
+	BindPass       string `json:"bindpass,omitempty"`
+	UserDN         string `json:"userdn,omitempty"`
+	Schema         string `json:"schema,omitempty"`
+	PasswordPolicy string `json:"password_policy,omitempty"`
+	Certificate    string `json:"certificate,omitempty"`
+	InsecureTLS    bool   `json:"insecure_tls,omitempty"`
+	StartTLS       bool   `json:"starttls,omitempty"`
+}
+
+type ldapSecretsConfigWrapper struct {
+	Data LDAPSecretsConfig `json:"data"`
+}
+
+func (l *ldapSecrets) Configure(config LDAPSecretsConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// do not log the request, which contains the bind password
+	requestPath := l.mount + "/config"
+	if err := l.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "configuring ldap secrets at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (l *ldapSecrets) LookupConfig() (LDAPSecretsConfig, error) {
+	var wrapper ldapSecretsConfigWrapper
+	if err := l.client.get(l.mount+"/config", &wrapper); err != nil {
+		return LDAPSecretsConfig{}, errors.Wrap(err, "failed to read ldap secrets config")
+	}
+	return wrapper.Data, nil
+}
+
+// RotateRoot rotates the password of the BindDN used by vault.
+// Afterwards, the password is only known to vault.
+func (l *ldapSecrets) RotateRoot() error {
+	if err := l.client.post(l.mount+"/rotate-root", "", nil); err != nil {
+		return errors.Wrap(err, "failed to rotate ldap root credentials")
+	}
+	return nil
+}
+
+// LDAPStaticRoleOptions are used to define properties of an ldap static
+// role being created or updated. A static role maps to an existing
+// account, whose password is rotated by vault every RotationPeriod,
+// expressed as a string that vault understands, such as "24h".
+type LDAPStaticRoleOptions struct {
+	Name           string `json:"-"`
+	Username       string `json:"username"`
+	DN             string `json:"dn,omitempty"`
+	RotationPeriod string `json:"rotation_period"`
+}
+
+func (l *ldapSecrets) CreateStaticRole(opts LDAPStaticRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling ldap static role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/static-role/%s", l.mount, opts.Name)
+	l.client.logRequest("ldap-static-role-create", requestPath, bs)
+	if err := l.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating ldap static role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpLDAPStaticRoleWrapper struct {
+	Data LookedUpLDAPStaticRole `json:"data"`
+}
+
+// A LookedUpLDAPStaticRole represents information returned from vault
+// after making a request for information about a particular ldap
+// static role.
+type LookedUpLDAPStaticRole struct {
+	Username          string `json:"username"`
+	DN                string `json:"dn"`
+	RotationPeriod    int    `json:"rotation_period"`
+	LastVaultRotation string `json:"last_vault_rotation"`
+}
+
+func (l *ldapSecrets) LookupStaticRole(name string) (LookedUpLDAPStaticRole, error) {
+	var wrapper lookedUpLDAPStaticRoleWrapper
+	requestPath := fmt.Sprintf("%s/static-role/%s", l.mount, name)
+	if err := l.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpLDAPStaticRole{}, errors.Wrapf(err, "failed to look up ldap static role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (l *ldapSecrets) ListStaticRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := l.mount + "/static-role"
+	if err := l.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list ldap static roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (l *ldapSecrets) DeleteStaticRole(name string) error {
+	requestPath := fmt.Sprintf("%s/static-role/%s", l.mount, name)
+	if err := l.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete ldap static role %q", name)
+	}
+	return nil
+}
+
+// LDAPStaticCredentials are the current credentials of the account of
+// a static role. TTL is the number of seconds until the password is
+// next rotated. LastPassword is the password before the last rotation.
+type LDAPStaticCredentials struct {
+	Username          string `json:"username"`
+	DN                string `json:"dn"`
+	Password          string `json:"password"`
+	LastPassword      string `json:"last_password"`
+	LastVaultRotation string `json:"last_vault_rotation"`
+	RotationPeriod    int    `json:"rotation_period"`
+	TTL               int    `json:"ttl"`
+}
+
+type ldapStaticCredentialsWrapper struct {
+	Data LDAPStaticCredentials `json:"data"`
+}
+
+func (l *ldapSecrets) StaticCredentials(name string) (LDAPStaticCredentials, error) {
+	var wrapper ldapStaticCredentialsWrapper
+	requestPath := fmt.Sprintf("%s/static-cred/%s", l.mount, name)
+	if err := l.client.get(requestPath, &wrapper); err != nil {
+		return LDAPStaticCredentials{}, errors.Wrapf(err, "failed to read credentials of ldap static role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+// RotateStaticRole immediately rotates the password of the account of
+// the named static role, and resets its rotation period.
+func (l *ldapSecrets) RotateStaticRole(name string) error {
+	requestPath := fmt.Sprintf("%s/rotate-role/%s", l.mount, name)
+	if err := l.client.post(requestPath, "", nil); err != nil {
+		return errors.Wrapf(err, "failed to rotate ldap static role %q", name)
+	}
+	return nil
+}
+
+// LDAPLibraryOptions are used to define properties of a library of
+// service accounts being created or updated. Each account can be
+// checked out by one client at a time, and its password is rotated
+// when it is checked back in. Durations are expressed as strings that
+// vault understands, such as "10h". Unless check in enforcement is
+// disabled, only the client which checked out an account may check it
+// back in.
+type LDAPLibraryOptions struct {
+	Name                      string   `json:"-"`
+	ServiceAccountNames       []string `json:"service_account_names"`
+	TTL                       string   `json:"ttl,omitempty"`
+	MaxTTL                    string   `json:"max_ttl,omitempty"`
+	DisableCheckInEnforcement bool     `json:"disable_check_in_enforcement,omitempty"`
+}
+
+func (l *ldapSecrets) CreateLibrary(opts LDAPLibraryOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling ldap library data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/library/%s", l.mount, opts.Name)
+	l.client.logRequest("ldap-library-create", requestPath, bs)
+	if err := l.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating ldap library at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpLDAPLibraryWrapper struct {
+	Data LookedUpLDAPLibrary `json:"data"`
+}
+
+// A LookedUpLDAPLibrary represents information returned from vault
+// after making a request for information about a particular library
+// of service accounts.
+type LookedUpLDAPLibrary struct {
+	ServiceAccountNames       []string `json:"service_account_names"`
+	TTL                       int      `json:"ttl"`
+	MaxTTL                    int      `json:"max_ttl"`
+	DisableCheckInEnforcement bool     `json:"disable_check_in_enforcement"`
+}
+
+func (l *ldapSecrets) LookupLibrary(name string) (LookedUpLDAPLibrary, error) {
+	var wrapper lookedUpLDAPLibraryWrapper
+	requestPath := fmt.Sprintf("%s/library/%s", l.mount, name)
+	if err := l.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpLDAPLibrary{}, errors.Wrapf(err, "failed to look up ldap library %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (l *ldapSecrets) ListLibraries() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := l.mount + "/library"
+	if err := l.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list ldap libraries at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (l *ldapSecrets) DeleteLibrary(name string) error {
+	requestPath := fmt.Sprintf("%s/library/%s", l.mount, name)
+	if err := l.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete ldap library %q", name)
+	}
+	return nil
+}
+
+// An LDAPCheckOut is a service account checked out of a library,
+// along with the lease of the check out. The account is checked back
+// in when the lease expires, or is revoked.
+type LDAPCheckOut struct {
+	ServiceAccountName string
+	Password           string
+	LeaseID            string
+	LeaseDuration      int
+	Renewable          bool
+}
+
+type ldapCheckOutWrapper struct {
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+	Data          struct {
+		ServiceAccountName string `json:"service_account_name"`
+		Password           string `json:"password"`
+	} `json:"data"`
+}
+
+// CheckOut checks out an available service account of the library,
+// for the given ttl (e.g. "1h"), or the TTL of the library if empty.
+func (l *ldapSecrets) CheckOut(library, ttl string) (LDAPCheckOut, error) {
+	bs, err := json.Marshal(struct {
+		TTL string `json:"ttl,omitempty"`
+	}{TTL: ttl})
+	if err != nil {
+		return LDAPCheckOut{}, err
+	}
+
+	var wrapper ldapCheckOutWrapper
+	requestPath := fmt.Sprintf("%s/library/%s/check-out", l.mount, library)
+	if err := l.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return LDAPCheckOut{}, errors.Wrapf(err, "failed to check out service account of ldap library %q", library)
+	}
+
+	return LDAPCheckOut{
+		ServiceAccountName: wrapper.Data.ServiceAccountName,
+		Password:           wrapper.Data.Password,
+		LeaseID:            wrapper.LeaseID,
+		LeaseDuration:      wrapper.LeaseDuration,
+		Renewable:          wrapper.Renewable,
+	}, nil
+}
+
+type ldapCheckInWrapper struct {
+	Data struct {
+		CheckIns []string `json:"check_ins"`
+	} `json:"data"`
+}
+
+func (l *ldapSecrets) checkIn(requestPath, library string, accounts []string) ([]string, error) {
+	bs, err := json.Marshal(struct {
+		ServiceAccountNames []string `json:"service_account_names,omitempty"`
+	}{ServiceAccountNames: accounts})
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapper ldapCheckInWrapper
+	if err := l.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to check in service accounts of ldap library %q", library)
+	}
+
+	sort.Strings(wrapper.Data.CheckIns)
+	return wrapper.Data.CheckIns, nil
+}
+
+// CheckIn checks the given service accounts back in to the library,
+// and returns the names of the accounts checked in. If no accounts
+// are given, the one account checked out by the client is checked in.
+func (l *ldapSecrets) CheckIn(library string, accounts []string) ([]string, error) {
+	requestPath := fmt.Sprintf("%s/library/%s/check-in", l.mount, library)
+	return l.checkIn(requestPath, library, accounts)
+}
+
+// ForceCheckIn is like CheckIn, but also checks in accounts which
+// were checked out by other clients.
+func (l *ldapSecrets) ForceCheckIn(library string, accounts []string) ([]string, error) {
+	requestPath := fmt.Sprintf("%s/library/manage/%s/check-in", l.mount, library)
+	return l.checkIn(requestPath, library, accounts)
+}
+
+// An LDAPAccountStatus describes whether a service account of a
+// library is available, and if not, who has it checked out.
+type LDAPAccountStatus struct {
+	Available           bool   `json:"available"`
+	BorrowerClientToken string `json:"borrower_client_token"`
+	BorrowerEntityID    string `json:"borrower_entity_id"`
+}
+
+type ldapLibraryStatusWrapper struct {
+	Data map[string]LDAPAccountStatus `json:"data"`
+}
+
+// LibraryStatus returns the status of each service account of the
+// library, keyed by the name of the account.
+func (l *ldapSecrets) LibraryStatus(library string) (map[string]LDAPAccountStatus, error) {
+	var wrapper ldapLibraryStatusWrapper
+	requestPath := fmt.Sprintf("%s/library/%s/status", l.mount, library)
+	if err := l.client.get(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to read status of ldap library %q", library)
+	}
+	return wrapper.Data, nil
+}
@@ -0,0 +1,154 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ldapSecrets_Config(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/ldap/config":      "",
+		"GET /v1/ldap/config":       `{"data":{"url":"ldaps://ldap.example.com","binddn":"cn=vault,dc=example,dc=com","schema":"openldap"}}`,
+		"POST /v1/ldap/rotate-root": "",
+	})
+	ldap := vault.LDAPSecretsMount("ldap")
+
+	err := ldap.Configure(LDAPSecretsConfig{
+		URL:      "ldaps://ldap.example.com",
+		BindDN:   "cn=vault,dc=example,dc=com",
+		BindPass: "secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"url":"ldaps://ldap.example.com","binddn":"cn=vault,dc=example,dc=com","bindpass":"secret"}`,
+		vault.requests[0].Body,
+	)
+
+	config, err := ldap.LookupConfig()
+	require.NoError(t, err)
+	require.Equal(t, LDAPSecretsConfig{
+		URL:    "ldaps://ldap.example.com",
+		BindDN: "cn=vault,dc=example,dc=com",
+		Schema: "openldap",
+	}, config)
+
+	require.NoError(t, ldap.RotateRoot())
+}
+
+func Test_ldapSecrets_StaticRoles(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/ldap/static-role/app":   "",
+		"GET /v1/ldap/static-role/app":    `{"data":{"username":"app","dn":"cn=app,dc=example,dc=com","rotation_period":86400}}`,
+		"LIST /v1/ldap/static-role":       `{"data":{"keys":["web","app"]}}`,
+		"DELETE /v1/ldap/static-role/app": "",
+		"GET /v1/ldap/static-cred/app":    `{"data":{"username":"app","password":"new","last_password":"old","ttl":3600}}`,
+		"POST /v1/ldap/rotate-role/app":   "",
+	})
+	ldap := vault.LDAPSecretsMount("ldap")
+
+	err := ldap.CreateStaticRole(LDAPStaticRoleOptions{
+		Name:           "app",
+		Username:       "app",
+		RotationPeriod: "24h",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"username":"app","rotation_period":"24h"}`, vault.requests[0].Body)
+
+	role, err := ldap.LookupStaticRole("app")
+	require.NoError(t, err)
+	require.Equal(t, LookedUpLDAPStaticRole{
+		Username:       "app",
+		DN:             "cn=app,dc=example,dc=com",
+		RotationPeriod: 86400,
+	}, role)
+
+	roles, err := ldap.ListStaticRoles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"app", "web"}, roles)
+
+	creds, err := ldap.StaticCredentials("app")
+	require.NoError(t, err)
+	require.Equal(t, LDAPStaticCredentials{
+		Username:     "app",
+		Password:     "new",
+		LastPassword: "old",
+		TTL:          3600,
+	}, creds)
+
+	require.NoError(t, ldap.RotateStaticRole("app"))
+	require.NoError(t, ldap.DeleteStaticRole("app"))
+}
+
+func Test_ldapSecrets_Libraries(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/ldap/library/ops":   "",
+		"GET /v1/ldap/library/ops":    `{"data":{"service_account_names":["svc1","svc2"],"ttl":36000,"max_ttl":86400}}`,
+		"LIST /v1/ldap/library":       `{"data":{"keys":["ops"]}}`,
+		"DELETE /v1/ldap/library/ops": "",
+	})
+	ldap := vault.LDAPSecretsMount("ldap")
+
+	err := ldap.CreateLibrary(LDAPLibraryOptions{
+		Name:                "ops",
+		ServiceAccountNames: []string{"svc1", "svc2"},
+		TTL:                 "10h",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"service_account_names":["svc1","svc2"],"ttl":"10h"}`, vault.requests[0].Body)
+
+	library, err := ldap.LookupLibrary("ops")
+	require.NoError(t, err)
+	require.Equal(t, LookedUpLDAPLibrary{
+		ServiceAccountNames: []string{"svc1", "svc2"},
+		TTL:                 36000,
+		MaxTTL:              86400,
+	}, library)
+
+	libraries, err := ldap.ListLibraries()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ops"}, libraries)
+
+	require.NoError(t, ldap.DeleteLibrary("ops"))
+}
+
+func Test_ldapSecrets_CheckOutCheckIn(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/ldap/library/ops/check-out":       `{"lease_id":"ldap/library/ops/check-out/abc","lease_duration":3600,"renewable":true,"data":{"service_account_name":"svc1","password":"secret"}}`,
+		"POST /v1/ldap/library/ops/check-in":        `{"data":{"check_ins":["svc1"]}}`,
+		"POST /v1/ldap/library/manage/ops/check-in": `{"data":{"check_ins":["svc2","svc1"]}}`,
+		"GET /v1/ldap/library/ops/status":           `{"data":{"svc1":{"available":true},"svc2":{"available":false,"borrower_client_token":"b1"}}}`,
+	})
+	ldap := vault.LDAPSecretsMount("ldap")
+
+	checkOut, err := ldap.CheckOut("ops", "1h")
+	require.NoError(t, err)
+	require.Equal(t, LDAPCheckOut{
+		ServiceAccountName: "svc1",
+		Password:           "secret",
+		LeaseID:            "ldap/library/ops/check-out/abc",
+		LeaseDuration:      3600,
+		Renewable:          true,
+	}, checkOut)
+	require.Equal(t, `{"ttl":"1h"}`, vault.requests[0].Body)
+
+	// without accounts, the one checked out by the client is checked in
+	checkIns, err := ldap.CheckIn("ops", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"svc1"}, checkIns)
+	require.Equal(t, `{}`, vault.requests[1].Body)
+
+	checkIns, err = ldap.ForceCheckIn("ops", []string{"svc1", "svc2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"svc1", "svc2"}, checkIns)
+	require.Equal(t, `{"service_account_names":["svc1","svc2"]}`, vault.requests[2].Body)
+
+	status, err := ldap.LibraryStatus("ops")
+	require.NoError(t, err)
+	require.Equal(t, map[string]LDAPAccountStatus{
+		"svc1": {Available: true},
+		"svc2": {BorrowerClientToken: "b1"},
+	}, status)
+}
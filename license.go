@@ -0,0 +1,46 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"github.com/pkg/errors"
+)
+
+// A License describes a vault enterprise license. The times are in
+// RFC3339 format. Vault stops serving requests at the TerminationTime,
+// which may be later than the ExpirationTime.
+type License struct {
+	LicenseID               string   `json:"license_id"`
+	CustomerID              string   `json:"customer_id"`
+	InstallationID          string   `json:"installation_id"`
+	IssueTime               string   `json:"issue_time"`
+	StartTime               string   `json:"start_time"`
+	ExpirationTime          string   `json:"expiration_time"`
+	TerminationTime         string   `json:"termination_time"`
+	Flags                   []string `json:"flags"`
+	Features                []string `json:"features"`
+	PerformanceStandbyCount int      `json:"performance_standby_count"`
+}
+
+// A LicenseStatus describes the license of a vault enterprise server.
+// If AutoloadingUsed, the Autoloaded license was loaded from the
+// configuration or environment of vault, and the PersistedAutoload is
+// the license stored in vault, if any.
+//
+// More information about licensing can be found here:
+// https://www.vaultproject.io/docs/enterprise/license
+type LicenseStatus struct {
+	AutoloadingUsed   bool     `json:"autoloading_used"`
+	Autoloaded        *License `json:"autoloaded"`
+	PersistedAutoload *License `json:"persisted_autoload"`
+}
+
+func (c *client) LicenseStatus() (LicenseStatus, error) {
+	var wrapper struct {
+		Data LicenseStatus `json:"data"`
+	}
+	if err := c.get("/v1/sys/license/status", &wrapper); err != nil {
+		return LicenseStatus{}, errors.Wrap(err, "failed to read license status")
+	}
+	return wrapper.Data, nil
+}
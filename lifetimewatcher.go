@@ -0,0 +1,152 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"time"
+
+	"github.com/shoenig/vaultapi/internal/watch"
+)
+
+// LifetimeWatcherOptions are used to configure a LifetimeWatcher.
+type LifetimeWatcherOptions struct {
+	// Increment is the lease extension requested upon each renewal.
+	// Vault may grant less, e.g. when the lease nears its max TTL.
+	// By default, the lease is renewed by its default TTL.
+	Increment time.Duration
+
+	// Jitter is the fraction of time by which renewals are randomly
+	// moved earlier, so that many secrets created at once are not all
+	// renewed at once. By default, this value is 0.1.
+	Jitter float64
+
+	// RetryDelay configures how long to wait after a failed renewal
+	// before trying again. By default, this value is 5 seconds.
+	RetryDelay time.Duration
+}
+
+// A LifetimeEvent is emitted by a LifetimeWatcher after each attempt
+// to renew the lease. Exactly one of Renewed, Err, or Expired is set.
+type LifetimeEvent struct {
+	// Renewed is the result of a successful renewal.
+	Renewed *RenewedLease
+
+	// Err is the reason a renewal failed. The renewal will be
+	// retried until the lease expires.
+	Err error
+
+	// Expired is set once the lease can no longer be renewed and
+	// has run out, at which point the secret must be replaced. No
+	// more events follow an expiration.
+	Expired bool
+}
+
+// A LifetimeWatcher renews the lease of a secret in the background,
+// such as database or aws credentials, before the lease expires. It
+// emits a LifetimeEvent on the Events channel after each attempt.
+//
+// Unlike a TokenWatcher, the first renewal is made only once some of
+// the lease has passed, since the secret was just created.
+type LifetimeWatcher struct {
+	client  Client
+	lease   Lease
+	opts    LifetimeWatcherOptions
+	watcher *watch.Watcher[LifetimeEvent]
+}
+
+// NewLifetimeWatcher creates a LifetimeWatcher that will renew the
+// lease of a secret. The lease need only have its ID, TTL, and
+// Renewable set, e.g. from the LeaseID, LeaseDuration, and Renewable
+// of a set of credentials. Call Start to begin renewing, and Stop
+// to stop.
+func NewLifetimeWatcher(client Client, lease Lease, opts LifetimeWatcherOptions) *LifetimeWatcher {
+	if opts.Jitter <= 0 || opts.Jitter >= 1 {
+		opts.Jitter = watch.DefaultJitter
+	}
+
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = watch.DefaultRetryDelay
+	}
+
+	return &LifetimeWatcher{
+		client:  client,
+		lease:   lease,
+		opts:    opts,
+		watcher: watch.New(opts.RetryDelay, LifetimeEvent{Expired: true}),
+	}
+}
+
+// Events returns the channel on which a LifetimeEvent is emitted after
+// each renewal attempt. The channel must be read from, as renewals
+// wait on events being received. The channel is closed once the
+// LifetimeWatcher is done, because it was stopped, the lease expired,
+// or the lease does not expire.
+func (w *LifetimeWatcher) Events() <-chan LifetimeEvent {
+	return w.watcher.Events()
+}
+
+// Start begins renewing the lease in the background.
+func (w *LifetimeWatcher) Start() {
+	w.watcher.Start(w.run)
+}
+
+// Stop stops renewing the lease. It is safe to call Stop
+// more than once.
+func (w *LifetimeWatcher) Stop() {
+	w.watcher.Stop()
+}
+
+func (w *LifetimeWatcher) run() {
+	lease := time.Duration(w.lease.TTL) * time.Second
+	renewable := w.lease.Renewable
+
+	for {
+		if lease <= 0 {
+			// the secret does not expire, nothing to do
+			return
+		}
+		expires := time.Now().Add(lease)
+
+		if !renewable {
+			w.watcher.Expire(expires)
+			return
+		}
+
+		if !w.watcher.Sleep(watch.RenewDelay(lease, w.opts.Jitter)) {
+			return
+		}
+
+		renewed, ok := w.renew(expires)
+		if !ok {
+			return
+		}
+
+		if !w.watcher.Emit(LifetimeEvent{Renewed: &renewed}) {
+			return
+		}
+
+		lease = time.Duration(renewed.LeaseDuration) * time.Second
+		renewable = renewed.Renewable
+
+		// vault grants less than requested once the lease reaches its
+		// max TTL, at which point renewing is no longer useful
+		if w.opts.Increment > 0 && lease < w.opts.Increment/2 {
+			w.watcher.Expire(time.Now().Add(lease))
+			return
+		}
+	}
+}
+
+// renew renews the lease, retrying failures until the lease expires
+func (w *LifetimeWatcher) renew(expires time.Time) (RenewedLease, bool) {
+	for {
+		renewed, err := w.client.RenewLease(w.lease.ID, w.opts.Increment)
+		if err == nil {
+			return renewed, true
+		}
+
+		if !w.watcher.Retry(LifetimeEvent{Err: err}, expires) {
+			return RenewedLease{}, false
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// leaseRenewer is a Client which only implements RenewLease. The
+// retrying and expiring of failed renewals is shared with the
+// TokenWatcher, and tested by the watch package.
+type leaseRenewer struct {
+	Client
+	renewed RenewedLease
+}
+
+func (r *leaseRenewer) RenewLease(id string, _ time.Duration) (RenewedLease, error) {
+	renewed := r.renewed
+	renewed.ID = id
+	return renewed, nil
+}
+
+func Test_LifetimeWatcher_renew(t *testing.T) {
+	client := &leaseRenewer{
+		renewed: RenewedLease{LeaseDuration: 1, Renewable: true},
+	}
+
+	watcher := NewLifetimeWatcher(client, Lease{
+		ID:        "database/creds/app/abc",
+		TTL:       1,
+		Renewable: true,
+	}, LifetimeWatcherOptions{})
+	watcher.Start()
+	defer watcher.Stop()
+
+	// the lease being watched is the one renewed
+	for i := 0; i < 2; i++ {
+		event := <-watcher.Events()
+		require.NoError(t, event.Err)
+		require.False(t, event.Expired)
+		require.Equal(t, "database/creds/app/abc", event.Renewed.ID)
+		require.Equal(t, 1, event.Renewed.LeaseDuration)
+	}
+}
+
+func Test_LifetimeWatcher_notRenewable(t *testing.T) {
+	client := &leaseRenewer{}
+
+	watcher := NewLifetimeWatcher(client, Lease{
+		ID:  "aws/creds/app/abc",
+		TTL: 1,
+	}, LifetimeWatcherOptions{})
+	watcher.Start()
+	defer watcher.Stop()
+
+	// no renewals are made, the lease just runs out
+	event := <-watcher.Events()
+	require.True(t, event.Expired)
+
+	_, open := <-watcher.Events()
+	require.False(t, open)
+}
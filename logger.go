@@ -0,0 +1,94 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+// A Logger receives the log output of a Client at the level of each
+// message. Requests and their responses are logged at the debug level,
+// failed attempts which are tried again at the warn level, and failures
+// which the Client cannot recover from at the error level.
+//
+// The loggers of common structured logging libraries, such as
+// *zap.SugaredLogger and *logrus.Logger, are Loggers as is. Use
+// NewStdLogger or NewSlogLogger to adapt the loggers of the standard
+// library.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// NewStdLogger creates a Logger which writes every message to logger,
+// prefixed with the level of the message.
+func NewStdLogger(logger *log.Logger) Logger {
+	return &stdLogger{logger: logger}
+}
+
+type stdLogger struct {
+	logger *log.Logger
+}
+
+func (l *stdLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Printf("[DEBUG] "+format, args...)
+}
+
+func (l *stdLogger) Infof(format string, args ...interface{}) {
+	l.logger.Printf("[INFO] "+format, args...)
+}
+
+func (l *stdLogger) Warnf(format string, args ...interface{}) {
+	l.logger.Printf("[WARN] "+format, args...)
+}
+
+func (l *stdLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Printf("[ERROR] "+format, args...)
+}
+
+// NewSlogLogger creates a Logger which writes every message to logger,
+// at the corresponding slog.Level.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return &slogLogger{logger: logger}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l *slogLogger) log(level slog.Level, format string, args ...interface{}) {
+	// avoid formatting messages which are not logged anyway
+	if !l.logger.Enabled(context.Background(), level) {
+		return
+	}
+	l.logger.Log(context.Background(), level, fmt.Sprintf(format, args...))
+}
+
+func (l *slogLogger) Debugf(format string, args ...interface{}) {
+	l.log(slog.LevelDebug, format, args...)
+}
+
+func (l *slogLogger) Infof(format string, args ...interface{}) {
+	l.log(slog.LevelInfo, format, args...)
+}
+
+func (l *slogLogger) Warnf(format string, args ...interface{}) {
+	l.log(slog.LevelWarn, format, args...)
+}
+
+func (l *slogLogger) Errorf(format string, args ...interface{}) {
+	l.log(slog.LevelError, format, args...)
+}
+
+// nopLogger is the Logger of a Client which is not configured with one.
+type nopLogger struct{}
+
+func (nopLogger) Debugf(string, ...interface{}) {}
+func (nopLogger) Infof(string, ...interface{})  {}
+func (nopLogger) Warnf(string, ...interface{})  {}
+func (nopLogger) Errorf(string, ...interface{}) {}
@@ -0,0 +1,39 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0))
+
+	logger.Debugf("a %d", 1)
+	logger.Warnf("b %s", "two")
+	require.Equal(t, "[DEBUG] a 1\n[WARN] b two\n", buf.String())
+}
+
+func Test_NewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	logger := NewSlogLogger(slog.New(handler))
+
+	logger.Debugf("a %d", 1)
+	logger.Errorf("b %s", "two")
+	require.Equal(t, "level=ERROR msg=\"b two\"\n", buf.String())
+}
@@ -0,0 +1,79 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Loggers returns the log level of each logger of the
+// vault server, keyed by the name of the logger.
+func (c *client) Loggers() (map[string]string, error) {
+	var wrapper struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := c.get("/v1/sys/loggers", &wrapper); err != nil {
+		return nil, errors.Wrap(err, "failed to read loggers")
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) LookupLogger(name string) (string, error) {
+	var wrapper struct {
+		Data map[string]string `json:"data"`
+	}
+	requestPath := fmt.Sprintf("/v1/sys/loggers/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return "", errors.Wrapf(err, "failed to look up logger %q", name)
+	}
+	return wrapper.Data[name], nil
+}
+
+// SetLogLevel sets the log level of every logger of the vault server,
+// which is one of "trace", "debug", "info", "warn", or "error". The
+// level lasts until it is reset, or until the server is restarted.
+func (c *client) SetLogLevel(level string) error {
+	return c.setLogLevel("/v1/sys/loggers", level)
+}
+
+// SetLoggerLevel sets the log level of the named logger of the
+// vault server, e.g. "core" or "expiration".
+func (c *client) SetLoggerLevel(name, level string) error {
+	return c.setLogLevel(fmt.Sprintf("/v1/sys/loggers/%s", name), level)
+}
+
+func (c *client) setLogLevel(requestPath, level string) error {
+	bs, err := json.Marshal(struct {
+		Level string `json:"level"`
+	}{Level: level})
+	if err != nil {
+		return err
+	}
+
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to set log level at %q", requestPath)
+	}
+	return nil
+}
+
+// ResetLogLevels resets the log level of every logger of the
+// vault server to the level of the configuration of the server.
+func (c *client) ResetLogLevels() error {
+	if err := c.delete("/v1/sys/loggers"); err != nil {
+		return errors.Wrap(err, "failed to reset log levels")
+	}
+	return nil
+}
+
+// ResetLoggerLevel resets the log level of the named logger to
+// the level of the configuration of the server.
+func (c *client) ResetLoggerLevel(name string) error {
+	requestPath := fmt.Sprintf("/v1/sys/loggers/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to reset log level of logger %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// A Logical makes requests to arbitrary paths of vault, with arbitrary
+// data, for endpoints which this library does not otherwise provide,
+// such as those of custom plugins. Each path is relative to /v1/, e.g.
+// "secret/foo", and may include a query, e.g. "kv/data/foo?version=2".
+//
+// More information about the HTTP API of vault can be found here:
+// https://www.vaultproject.io/api-docs
+type Logical interface {
+	// Read reads the secret at path.
+	Read(path string) (Secret, error)
+	// Write writes data to path, and returns the secret of the
+	// response, which is empty if vault responds with no content.
+	Write(path string, data map[string]interface{}) (Secret, error)
+	// List lists the keys under path, which are the "keys" of the
+	// Data of the secret.
+	List(path string) (Secret, error)
+	// Delete deletes the secret at path.
+	Delete(path string) error
+}
+
+// A Secret is a generic response of vault. The Data is set for most
+// responses, the Auth for responses which create tokens, e.g. logins,
+// and the WrapInfo for responses which are wrapped, including those
+// wrapped by vault to enforce a control group, which Logical does not
+// return as a ControlGroupError.
+type Secret struct {
+	RequestID     string                 `json:"request_id"`
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Warnings      []string               `json:"warnings"`
+	Auth          *CreatedToken          `json:"auth"`
+	WrapInfo      *WrapInfo              `json:"wrap_info"`
+}
+
+func (c *client) Logical() Logical {
+	return &logical{client: c}
+}
+
+type logical struct {
+	client *client
+}
+
+func (l *logical) Read(path string) (Secret, error) {
+	var secret Secret
+	if err := l.client.get(fixup("/v1", path), &secret); err != nil {
+		return Secret{}, errors.Wrapf(err, "failed to read %q", path)
+	}
+	return secret, nil
+}
+
+func (l *logical) Write(path string, data map[string]interface{}) (Secret, error) {
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return Secret{}, errors.Wrap(err, "marshalling logical data to JSON request body")
+	}
+	// do not log the request, which may contain any secret
+
+	var secret Secret
+	if err := l.client.post(fixup("/v1", path), string(bs), &secret); err != nil {
+		return Secret{}, errors.Wrapf(err, "failed to write %q", path)
+	}
+	return secret, nil
+}
+
+func (l *logical) List(path string) (Secret, error) {
+	var secret Secret
+	if err := l.client.list(fixup("/v1", path), &secret); err != nil {
+		return Secret{}, errors.Wrapf(err, "failed to list %q", path)
+	}
+	return secret, nil
+}
+
+func (l *logical) Delete(path string) error {
+	if err := l.client.delete(fixup("/v1", path)); err != nil {
+		return errors.Wrapf(err, "failed to delete %q", path)
+	}
+	return nil
+}
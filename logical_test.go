@@ -0,0 +1,103 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_client_Logical(t *testing.T) {
+	type sent struct {
+		method, path, body string
+	}
+	var requests []sent
+
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		var body []byte
+		if request.Body != nil {
+			body, _ = ioutil.ReadAll(request.Body)
+		}
+		requests = append(requests, sent{request.Method, request.URL.RequestURI(), string(body)})
+
+		code, response := http.StatusOK, ""
+		switch {
+		case request.URL.Path == "/v1/plugin/missing":
+			code = http.StatusNotFound
+		case request.Method == http.MethodGet:
+			response = `{"request_id":"abc","lease_duration":60,"data":{"value":"abc123","count":3}}`
+		case request.Method == "LIST":
+			response = `{"data":{"keys":["a","b/"]}}`
+		default:
+			code = http.StatusNoContent
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(strings.NewReader(response)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+	logical := client.Logical()
+
+	secret, err := logical.Read("plugin/foo?version=2")
+	require.NoError(t, err)
+	require.Equal(t, "abc", secret.RequestID)
+	require.Equal(t, 60, secret.LeaseDuration)
+	require.Equal(t, map[string]interface{}{"value": "abc123", "count": float64(3)}, secret.Data)
+
+	secret, err = logical.Write("/plugin/foo", map[string]interface{}{"value": "abc123"})
+	require.NoError(t, err)
+	require.Equal(t, Secret{}, secret)
+
+	secret, err = logical.List("plugin")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", "b/"}, secret.Data["keys"])
+
+	err = logical.Delete("plugin/foo")
+	require.NoError(t, err)
+
+	_, err = logical.Read("plugin/missing")
+	require.Equal(t, ErrPathNotFound, errors.Cause(err))
+
+	require.Equal(t, []sent{
+		{http.MethodGet, "/v1/plugin/foo?version=2", ""},
+		{http.MethodPost, "/v1/plugin/foo", `{"value":"abc123"}`},
+		{"LIST", "/v1/plugin", ""},
+		{http.MethodDelete, "/v1/plugin/foo", ""},
+		{http.MethodGet, "/v1/plugin/missing", ""},
+	}, requests)
+}
+
+func Test_client_Logical_wrapped(t *testing.T) {
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		response := `{"wrap_info":{"token":"s.wrapped","accessor":"abc","ttl":300,"creation_path":"sys/wrapping/wrap"}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(response)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	// a wrapped response is not mistaken for a control group
+	secret, err := client.Logical().Write("sys/wrapping/wrap", map[string]interface{}{"value": "abc123"})
+	require.NoError(t, err)
+	require.NotNil(t, secret.WrapInfo)
+	require.Equal(t, "s.wrapped", secret.WrapInfo.Token)
+	require.Equal(t, "sys/wrapping/wrap", secret.WrapInfo.CreationPath)
+
+	secret, err = client.Logical().Read("secret/controlled")
+	require.NoError(t, err)
+	require.NotNil(t, secret.WrapInfo)
+	require.Nil(t, secret.Data)
+}
@@ -0,0 +1,90 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// The types of managed keys, which are keys of an external key
+// management system, such as an HSM, used by vault enterprise.
+const (
+	ManagedKeyPKCS11        = "pkcs11"
+	ManagedKeyAWSKMS        = "awskms"
+	ManagedKeyAzureKeyVault = "azurekeyvault"
+	ManagedKeyGCPCKMS       = "gcpckms"
+)
+
+// CreateManagedKey creates or updates the managed key of the given type
+// and name. The params are specific to the type of the key, e.g. the
+// library, slot, pin, and key_label of a pkcs11 key.
+//
+// More information about managed keys can be found here:
+// https://www.vaultproject.io/docs/enterprise/managed-keys
+func (c *client) CreateManagedKey(keyType, name string, params map[string]interface{}) error {
+	bs, err := json.Marshal(params)
+	if err != nil {
+		return errors.Wrap(err, "marshalling managed key data to JSON request body")
+	}
+
+	// do not log the request, which contains credentials of the key
+	requestPath := fmt.Sprintf("/v1/sys/managed-keys/%s/%s", keyType, name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating managed key at %q", requestPath)
+	}
+	return nil
+}
+
+// LookupManagedKey returns the configuration of the managed key of the
+// given type and name, whose parameters are specific to the type of
+// the key.
+func (c *client) LookupManagedKey(keyType, name string) (map[string]interface{}, error) {
+	var wrapper struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	requestPath := fmt.Sprintf("/v1/sys/managed-keys/%s/%s", keyType, name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to look up managed key %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListManagedKeys(keyType string) ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := fmt.Sprintf("/v1/sys/managed-keys/%s", keyType)
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list managed keys at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteManagedKey(keyType, name string) error {
+	requestPath := fmt.Sprintf("/v1/sys/managed-keys/%s/%s", keyType, name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete managed key %q", name)
+	}
+	return nil
+}
+
+// TestManagedKeySign verifies that the managed key of the given type
+// and name is usable, by signing and verifying a random payload with
+// it. The hashAlgorithm is e.g. "sha2-256", or the default if empty.
+func (c *client) TestManagedKeySign(keyType, name, hashAlgorithm string) error {
+	bs, err := json.Marshal(struct {
+		HashAlgorithm string `json:"hash_algorithm,omitempty"`
+	}{HashAlgorithm: hashAlgorithm})
+	if err != nil {
+		return err
+	}
+
+	requestPath := fmt.Sprintf("/v1/sys/managed-keys/%s/%s/test/sign", keyType, name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to test managed key %q", name)
+	}
+	return nil
+}
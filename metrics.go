@@ -0,0 +1,76 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"github.com/pkg/errors"
+)
+
+// The formats in which the telemetry metrics of vault can be read.
+const (
+	MetricsFormatJSON       = ""
+	MetricsFormatPrometheus = "prometheus"
+)
+
+// Metrics are the telemetry metrics of vault, as of Timestamp. When read
+// in MetricsFormatPrometheus, only the Text is set, which is the metrics
+// in the Prometheus exposition format. Vault serves metrics in the
+// Prometheus format only if its telemetry sets prometheus_retention_time.
+//
+// More information about telemetry can be found here:
+// https://www.vaultproject.io/docs/internals/telemetry.html
+type Metrics struct {
+	Timestamp string          `json:"Timestamp"`
+	Gauges    []MetricsGauge  `json:"Gauges"`
+	Points    []MetricsPoint  `json:"Points"`
+	Counters  []MetricsSample `json:"Counters"`
+	Samples   []MetricsSample `json:"Samples"`
+	Text      []byte          `json:"-"`
+}
+
+// A MetricsGauge is the current Value of a gauge metric.
+type MetricsGauge struct {
+	Name   string            `json:"Name"`
+	Value  float64           `json:"Value"`
+	Labels map[string]string `json:"Labels"`
+}
+
+// A MetricsPoint is the Points recorded for a metric.
+type MetricsPoint struct {
+	Name   string    `json:"Name"`
+	Points []float64 `json:"Points"`
+}
+
+// A MetricsSample summarizes the values recorded for a counter or
+// sample metric during the current telemetry interval.
+type MetricsSample struct {
+	Name   string            `json:"Name"`
+	Count  int               `json:"Count"`
+	Rate   float64           `json:"Rate"`
+	Sum    float64           `json:"Sum"`
+	Min    float64           `json:"Min"`
+	Max    float64           `json:"Max"`
+	Mean   float64           `json:"Mean"`
+	Stddev float64           `json:"Stddev"`
+	Labels map[string]string `json:"Labels"`
+}
+
+// Metrics reads the telemetry metrics of vault in the given
+// format, which is MetricsFormatJSON or MetricsFormatPrometheus.
+func (c *client) Metrics(format string) (Metrics, error) {
+	requestPath := fixup("/v1/sys", "/metrics", [2]string{"format", format})
+
+	if format == MetricsFormatPrometheus {
+		var text []byte
+		if err := c.get(requestPath, &text); err != nil {
+			return Metrics{}, errors.Wrap(err, "failed to read prometheus metrics")
+		}
+		return Metrics{Text: text}, nil
+	}
+
+	var metrics Metrics
+	if err := c.get(requestPath, &metrics); err != nil {
+		return Metrics{}, errors.Wrap(err, "failed to read metrics")
+	}
+	return metrics, nil
+}
@@ -0,0 +1,36 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"net/http"
+)
+
+// A Doer executes HTTP requests, as does an *http.Client.
+type Doer interface {
+	Do(request *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to use an ordinary function as a Doer.
+type DoerFunc func(request *http.Request) (*http.Response, error)
+
+// Do calls f(request).
+func (f DoerFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// A Middleware wraps the Doer which executes the requests of a Client,
+// e.g. to set custom headers, audit requests, or inject failures. The
+// Middleware may modify the request, return a response of its own, or
+// call next to execute the request. Each attempt of a request which is
+// retried passes through the Middleware.
+type Middleware func(next Doer) Doer
+
+// chain wraps doer with middleware, such that the first
+// middleware is the outermost.
+func chain(doer Doer, middleware []Middleware) Doer {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		doer = middleware[i](doer)
+	}
+	return doer
+}
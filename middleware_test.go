@@ -0,0 +1,65 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_client_Middleware(t *testing.T) {
+	var order []string
+	var requests []*http.Request
+
+	named := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(request *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				request.Header.Set("X-Middleware", name)
+				return next.Do(request)
+			})
+		}
+	}
+
+	// answers requests for /v1/secret/cached without vault
+	cache := func(next Doer) Doer {
+		return DoerFunc(func(request *http.Request) (*http.Response, error) {
+			if request.URL.Path == "/v1/secret/cached" {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"data":{"value":"cached"}}`)),
+				}, nil
+			}
+			return next.Do(request)
+		})
+	}
+
+	opts := devOpts()
+	opts.Middleware = []Middleware{named("outer"), cache, named("inner")}
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		requests = append(requests, request)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"data":{"value":"abc123"}}`)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	value, err := client.Get("/foo")
+	require.NoError(t, err)
+	require.Equal(t, "abc123", value)
+	require.Equal(t, []string{"outer", "inner"}, order)
+	require.Equal(t, "inner", requests[0].Header.Get("X-Middleware"))
+
+	value, err = client.Get("/cached")
+	require.NoError(t, err)
+	require.Equal(t, "cached", value)
+	require.Equal(t, []string{"outer", "inner", "outer"}, order)
+	require.Len(t, requests, 1)
+}
@@ -0,0 +1,183 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shoenig/toolkit"
+)
+
+// The formats in which vault can stream its logs.
+const (
+	LogFormatStandard = "standard"
+	LogFormatJSON     = "json"
+)
+
+// MonitorOptions are used to configure the logs streamed by Monitor.
+// The LogLevel is one of "trace", "debug", "info", "warn", or "error",
+// and is "info" by default. The LogFormat is LogFormatStandard or
+// LogFormatJSON, and is LogFormatStandard by default.
+type MonitorOptions struct {
+	LogLevel  string
+	LogFormat string
+}
+
+// A LogEntry is a log line of the vault server. The Timestamp, Level,
+// Module and Message are parsed from the Line, if possible. The Fields
+// are the additional key-value pairs of the entry, and are only set for
+// entries in LogFormatJSON.
+type LogEntry struct {
+	Timestamp string
+	Level     string
+	Module    string
+	Message   string
+	Fields    map[string]interface{}
+	Line      string
+}
+
+// Monitor streams the logs of the vault server, which requires a root
+// token, or sudo capability on sys/monitor. The returned channel is
+// closed once ctx is done, or if the stream fails, e.g. because the
+// server shuts down.
+func (c *client) Monitor(ctx context.Context, opts MonitorOptions) (<-chan LogEntry, error) {
+	requestPath := fixup("/v1/sys", "/monitor",
+		[2]string{"log_level", opts.LogLevel},
+		[2]string{"log_format", opts.LogFormat},
+	)
+
+	var last error
+	servers := c.servers()
+	for _, address := range servers {
+		response, err := c.singleStream(ctx, address, requestPath)
+		if errors.Is(err, ErrNotFound) {
+			c.opts.Logger.Debugf("GET request for unknown path: %q", requestPath)
+			return nil, err
+		} else if clientError(err) {
+			return nil, err
+		} else if err != nil {
+			c.opts.Logger.Warnf("GET request failed: %v", err)
+			last = err
+			continue
+		}
+
+		entries := make(chan LogEntry)
+		go c.monitor(ctx, response, entries)
+		return entries, nil
+	}
+	return nil, allFailed("GET", servers, last)
+}
+
+// singleStream is like singleGet, but leaves the response body for the
+// caller to read for as long as ctx allows, ignoring the HTTPTimeout.
+func (c *client) singleStream(ctx context.Context, address, path string) (*http.Response, error) {
+	url := address + path
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build GET request to %q", url)
+	}
+
+	token, err := c.token()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get token for request")
+	}
+
+	request.Header.Set(headerVaultToken, token)
+	c.setHeaders(request)
+	request.Header.Set(headerContentType, mimeText)
+
+	streaming := *c.httpClient
+	streaming.Timeout = 0
+
+	response, err := chain(&streaming, c.opts.Middleware).Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute GET request to %q", url)
+	}
+
+	if response.StatusCode >= 300 {
+		defer toolkit.Drain(response.Body)
+		return nil, newAPIError(request, response)
+	}
+
+	return response, nil
+}
+
+func (c *client) monitor(ctx context.Context, response *http.Response, entries chan<- LogEntry) {
+	defer close(entries)
+	defer toolkit.Drain(response.Body)
+
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		select {
+		case entries <- parseLogLine(line):
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		c.opts.Logger.Errorf("monitor stream failed: %v", err)
+	}
+}
+
+// parseLogLine parses a log line in either format, which looks like
+//
+//	2019-03-01T12:00:00.000Z [INFO]  core: vault is unsealed
+//
+// in the standard format, or like
+//
+//	{"@level":"info","@message":"vault is unsealed","@module":"core","@timestamp":"2019-03-01T12:00:00.000Z"}
+//
+// in the json format.
+func parseLogLine(line string) LogEntry {
+	entry := LogEntry{Line: line}
+
+	if strings.HasPrefix(line, "{") {
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &fields); err == nil {
+			entry.Timestamp, _ = fields["@timestamp"].(string)
+			entry.Level, _ = fields["@level"].(string)
+			entry.Module, _ = fields["@module"].(string)
+			entry.Message, _ = fields["@message"].(string)
+			for _, key := range []string{"@timestamp", "@level", "@module", "@message"} {
+				delete(fields, key)
+			}
+			entry.Fields = fields
+			return entry
+		}
+	}
+
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) < 2 {
+		entry.Message = line
+		return entry
+	}
+	rest := strings.TrimSpace(parts[1])
+
+	if strings.HasPrefix(rest, "[") {
+		if end := strings.Index(rest, "]"); end > 0 {
+			entry.Timestamp = parts[0]
+			entry.Level = strings.ToLower(rest[1:end])
+			rest = strings.TrimSpace(rest[end+1:])
+		}
+	}
+
+	if colon := strings.Index(rest, ": "); colon > 0 && !strings.Contains(rest[:colon], " ") {
+		entry.Module = rest[:colon]
+		rest = rest[colon+2:]
+	}
+
+	entry.Message = rest
+	return entry
+}
@@ -0,0 +1,46 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseLogLine_standard(t *testing.T) {
+	line := "2019-03-01T12:00:00.000Z [INFO]  core: vault is unsealed"
+	entry := parseLogLine(line)
+	require.Equal(t, LogEntry{
+		Timestamp: "2019-03-01T12:00:00.000Z",
+		Level:     "info",
+		Module:    "core",
+		Message:   "vault is unsealed",
+		Line:      line,
+	}, entry)
+
+	// not every entry has a module
+	line = "2019-03-01T12:00:00.000Z [WARN]  no `api_addr` value specified in config"
+	entry = parseLogLine(line)
+	require.Equal(t, "warn", entry.Level)
+	require.Equal(t, "", entry.Module)
+	require.Equal(t, "no `api_addr` value specified in config", entry.Message)
+
+	// lines which do not parse are still returned
+	entry = parseLogLine("garbage")
+	require.Equal(t, "garbage", entry.Message)
+	require.Equal(t, "garbage", entry.Line)
+}
+
+func Test_parseLogLine_json(t *testing.T) {
+	line := `{"@level":"info","@message":"mount tuning of leases successful","@module":"core","@timestamp":"2019-03-01T12:00:00.000Z","path":"secret/"}`
+	entry := parseLogLine(line)
+	require.Equal(t, LogEntry{
+		Timestamp: "2019-03-01T12:00:00.000Z",
+		Level:     "info",
+		Module:    "core",
+		Message:   "mount tuning of leases successful",
+		Fields:    map[string]interface{}{"path": "secret/"},
+		Line:      line,
+	}, entry)
+}
@@ -0,0 +1,79 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A Namespace is an isolated environment within vault enterprise, with
+// its own policies, mounts, and tokens. The Path of a namespace is
+// relative to the namespace of the Client which looked it up, and ends
+// with a "/".
+//
+// More information about namespaces can be found here:
+// https://www.vaultproject.io/docs/enterprise/namespaces
+type Namespace struct {
+	ID             string            `json:"id"`
+	Path           string            `json:"path"`
+	CustomMetadata map[string]string `json:"custom_metadata"`
+}
+
+type namespaceWrapper struct {
+	Data Namespace `json:"data"`
+}
+
+// CreateNamespace creates the namespace at path, which is relative to
+// the namespace of the Client. The customMetadata may be nil.
+func (c *client) CreateNamespace(path string, customMetadata map[string]string) (Namespace, error) {
+	bs, err := json.Marshal(struct {
+		CustomMetadata map[string]string `json:"custom_metadata,omitempty"`
+	}{CustomMetadata: customMetadata})
+	if err != nil {
+		return Namespace{}, errors.Wrap(err, "marshalling namespace data to JSON request body")
+	}
+
+	var wrapper namespaceWrapper
+	requestPath := fmt.Sprintf("/v1/sys/namespaces/%s", strings.Trim(path, "/"))
+	c.logRequest("namespace-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return Namespace{}, errors.Wrapf(err, "creating namespace at %q", path)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) LookupNamespace(path string) (Namespace, error) {
+	var wrapper namespaceWrapper
+	requestPath := fmt.Sprintf("/v1/sys/namespaces/%s", strings.Trim(path, "/"))
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return Namespace{}, errors.Wrapf(err, "failed to look up namespace %q", path)
+	}
+	return wrapper.Data, nil
+}
+
+// ListNamespaces returns the paths of the namespaces which are
+// direct children of the namespace of the Client.
+func (c *client) ListNamespaces() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/sys/namespaces"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list namespaces at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+// DeleteNamespace deletes the namespace at path, which must
+// not have any child namespaces of its own.
+func (c *client) DeleteNamespace(path string) error {
+	requestPath := fmt.Sprintf("/v1/sys/namespaces/%s", strings.Trim(path, "/"))
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete namespace %q", path)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A NomadSecrets represents a nomad secrets engine, which generates
+// Nomad ACL tokens.
+//
+// More information about the nomad secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/nomad/index.html
+type NomadSecrets interface {
+	// Configuration
+	ConfigureAccess(config NomadAccessConfig) error
+	LookupAccessConfig() (NomadAccessConfig, error)
+	DeleteAccessConfig() error
+
+	// Roles
+	CreateRole(opts NomadRoleOptions) error
+	LookupRole(name string) (LookedUpNomadRole, error)
+	ListRoles() ([]string, error)
+	DeleteRole(name string) error
+
+	// Credentials
+	Credentials(role string) (NomadCredentials, error)
+}
+
+func (c *client) NomadSecretsMount(mount string) NomadSecrets {
+	return &nomadSecrets{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type nomadSecrets struct {
+	client *client
+	mount  string
+}
+
+// NomadAccessConfig is the configuration of how vault connects to
+// Nomad, using a management Token to create other tokens. The Token
+// and ClientKey are never returned when looking up the configuration.
+type NomadAccessConfig struct {
+	Address            string `json:"address"`
+	Token              string `json:"token,omitempty"`
+	MaxTokenNameLength int    `json:"max_token_name_length,omitempty"`
+	CACert             string `json:"ca_cert,omitempty"`
+	ClientCert         string `json:"client_cert,omitempty"`
+	ClientKey          string `json:"client_key,omitempty"`
+}
+
+type nomadAccessConfigWrapper struct {
+	Data NomadAccessConfig `json:"data"`
+}
+
+func (n *nomadSecrets) ConfigureAccess(config NomadAccessConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// do not log the request, which contains the management token
+	requestPath := n.mount + "/config/access"
+	if err := n.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "configuring nomad access at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (n *nomadSecrets) LookupAccessConfig() (NomadAccessConfig, error) {
+	var wrapper nomadAccessConfigWrapper
+	if err := n.client.get(n.mount+"/config/access", &wrapper); err != nil {
+		return NomadAccessConfig{}, errors.Wrap(err, "failed to read nomad access config")
+	}
+	return wrapper.Data, nil
+}
+
+func (n *nomadSecrets) DeleteAccessConfig() error {
+	if err := n.client.delete(n.mount + "/config/access"); err != nil {
+		return errors.Wrap(err, "failed to delete nomad access config")
+	}
+	return nil
+}
+
+// NomadRoleOptions are used to define properties of a nomad role
+// being created or updated. Type is either "client" (the default),
+// which requires Policies, or "management". Global tokens are
+// replicated to all regions.
+type NomadRoleOptions struct {
+	Name     string   `json:"-"`
+	Policies []string `json:"policies,omitempty"`
+	Global   bool     `json:"global,omitempty"`
+	Type     string   `json:"type,omitempty"`
+}
+
+func (n *nomadSecrets) CreateRole(opts NomadRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling nomad role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/role/%s", n.mount, opts.Name)
+	n.client.logRequest("nomad-role-create", requestPath, bs)
+	if err := n.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating nomad role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpNomadRoleWrapper struct {
+	Data LookedUpNomadRole `json:"data"`
+}
+
+// A LookedUpNomadRole represents information returned from vault
+// after making a request for information about a particular nomad role.
+type LookedUpNomadRole struct {
+	Policies []string `json:"policies"`
+	Global   bool     `json:"global"`
+	Type     string   `json:"type"`
+}
+
+func (n *nomadSecrets) LookupRole(name string) (LookedUpNomadRole, error) {
+	var wrapper lookedUpNomadRoleWrapper
+	requestPath := fmt.Sprintf("%s/role/%s", n.mount, name)
+	if err := n.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpNomadRole{}, errors.Wrapf(err, "failed to look up nomad role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (n *nomadSecrets) ListRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := n.mount + "/role"
+	if err := n.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list nomad roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (n *nomadSecrets) DeleteRole(name string) error {
+	requestPath := fmt.Sprintf("%s/role/%s", n.mount, name)
+	if err := n.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete nomad role %q", name)
+	}
+	return nil
+}
+
+// NomadCredentials are a Nomad ACL token generated by vault, along
+// with its lease. The SecretID is the token used with Nomad, and the
+// AccessorID identifies the token.
+type NomadCredentials struct {
+	AccessorID    string
+	SecretID      string
+	LeaseID       string
+	LeaseDuration int
+	Renewable     bool
+}
+
+type nomadCredentialsWrapper struct {
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+	Data          struct {
+		AccessorID string `json:"accessor_id"`
+		SecretID   string `json:"secret_id"`
+	} `json:"data"`
+}
+
+func (n *nomadSecrets) Credentials(role string) (NomadCredentials, error) {
+	var wrapper nomadCredentialsWrapper
+	requestPath := fmt.Sprintf("%s/creds/%s", n.mount, role)
+	if err := n.client.get(requestPath, &wrapper); err != nil {
+		return NomadCredentials{}, errors.Wrapf(err, "failed to generate nomad credentials for role %q", role)
+	}
+
+	return NomadCredentials{
+		AccessorID:    wrapper.Data.AccessorID,
+		SecretID:      wrapper.Data.SecretID,
+		LeaseID:       wrapper.LeaseID,
+		LeaseDuration: wrapper.LeaseDuration,
+		Renewable:     wrapper.Renewable,
+	}, nil
+}
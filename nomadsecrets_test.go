@@ -0,0 +1,82 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_nomadSecrets_AccessConfig(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/nomad/config/access":   "",
+		"GET /v1/nomad/config/access":    `{"data":{"address":"https://nomad.example.com:4646","max_token_name_length":64}}`,
+		"DELETE /v1/nomad/config/access": "",
+	})
+	nomad := vault.NomadSecretsMount("nomad")
+
+	err := nomad.ConfigureAccess(NomadAccessConfig{
+		Address: "https://nomad.example.com:4646",
+		Token:   "management",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"address":"https://nomad.example.com:4646","token":"management"}`, vault.requests[0].Body)
+
+	config, err := nomad.LookupAccessConfig()
+	require.NoError(t, err)
+	require.Equal(t, NomadAccessConfig{
+		Address:            "https://nomad.example.com:4646",
+		MaxTokenNameLength: 64,
+	}, config)
+
+	require.NoError(t, nomad.DeleteAccessConfig())
+}
+
+func Test_nomadSecrets_Roles(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/nomad/role/deploy":   "",
+		"GET /v1/nomad/role/deploy":    `{"data":{"policies":["deploy"],"global":true,"type":"client"}}`,
+		"LIST /v1/nomad/role":          `{"data":{"keys":["ops","deploy"]}}`,
+		"DELETE /v1/nomad/role/deploy": "",
+	})
+	nomad := vault.NomadSecretsMount("nomad")
+
+	err := nomad.CreateRole(NomadRoleOptions{
+		Name:     "deploy",
+		Policies: []string{"deploy"},
+		Global:   true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"policies":["deploy"],"global":true}`, vault.requests[0].Body)
+
+	role, err := nomad.LookupRole("deploy")
+	require.NoError(t, err)
+	require.Equal(t, LookedUpNomadRole{
+		Policies: []string{"deploy"},
+		Global:   true,
+		Type:     "client",
+	}, role)
+
+	roles, err := nomad.ListRoles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"deploy", "ops"}, roles)
+
+	require.NoError(t, nomad.DeleteRole("deploy"))
+}
+
+func Test_nomadSecrets_Credentials(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"GET /v1/nomad/creds/deploy": `{"lease_id":"nomad/creds/deploy/abc","lease_duration":600,"renewable":true,"data":{"accessor_id":"a1","secret_id":"s1"}}`,
+	})
+
+	creds, err := vault.NomadSecretsMount("nomad").Credentials("deploy")
+	require.NoError(t, err)
+	require.Equal(t, NomadCredentials{
+		AccessorID:    "a1",
+		SecretID:      "s1",
+		LeaseID:       "nomad/creds/deploy/abc",
+		LeaseDuration: 600,
+		Renewable:     true,
+	}, creds)
+}
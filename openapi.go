@@ -0,0 +1,31 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// OpenAPI returns the OpenAPI document describing the API of vault, as
+// visible to the token of the Client. The paths of the mounts are the
+// paths at which they are mounted, unless genericMountPaths is set, in
+// which case the paths are templated with the type of each mount, e.g.
+// "/{kv_mount_path}/{path}".
+//
+// More information about the OpenAPI document can be found here:
+// https://www.vaultproject.io/api-docs/system/internal-specs-openapi
+func (c *client) OpenAPI(genericMountPaths bool) ([]byte, error) {
+	var generic string
+	if genericMountPaths {
+		generic = strconv.FormatBool(genericMountPaths)
+	}
+
+	var document []byte
+	requestPath := fixup("/v1/sys/internal/specs", "/openapi", [2]string{"generic_mount_paths", generic})
+	if err := c.get(requestPath, &document); err != nil {
+		return nil, errors.Wrap(err, "failed to read openapi document")
+	}
+	return document, nil
+}
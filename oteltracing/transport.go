@@ -0,0 +1,106 @@
+// Author hoenig
+
+// Package oteltracing creates OpenTelemetry spans for the requests made
+// by a vaultapi.Client, e.g.
+//
+//	opts.Transport = oteltracing.NewTransport(nil, oteltracing.Options{})
+//	client, err := vaultapi.New(opts, tokener)
+//	value, err := client.WithContext(ctx).Get("/foo")
+//
+// Each span is a child of the span of the context given to WithContext,
+// and the span context is propagated to vault in the headers of the
+// request. Every attempt of a request which is retried or redirected is
+// a span of its own.
+package oteltracing
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentation is the name of the tracer of the spans
+const instrumentation = "github.com/shoenig/vaultapi/oteltracing"
+
+// Options are used to configure a Transport.
+type Options struct {
+	// TracerProvider provides the tracer which creates the spans. By
+	// default, the global TracerProvider is used.
+	TracerProvider trace.TracerProvider
+
+	// Propagator injects the span context into the headers of each
+	// request. By default, the global TextMapPropagator is used.
+	Propagator propagation.TextMapPropagator
+}
+
+// A Transport is an http.RoundTripper which creates a span for each
+// request made through its base http.RoundTripper.
+type Transport struct {
+	base       http.RoundTripper
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTransport creates a Transport which makes requests through base,
+// or through http.DefaultTransport if base is nil. As the Transport
+// of vaultapi.ClientOptions replaces the transport configured by its
+// TLS and Proxy options, base must be configured with those instead.
+func NewTransport(base http.RoundTripper, opts Options) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if opts.TracerProvider == nil {
+		opts.TracerProvider = otel.GetTracerProvider()
+	}
+
+	if opts.Propagator == nil {
+		opts.Propagator = otel.GetTextMapPropagator()
+	}
+
+	return &Transport{
+		base:       base,
+		tracer:     opts.TracerProvider.Tracer(instrumentation),
+		propagator: opts.Propagator,
+	}
+}
+
+// RoundTrip makes the request within a span, which records the method,
+// the path without its query, and the status code of the response.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(request.Context(), "vault "+request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", request.Method),
+			attribute.String("url.path", request.URL.Path),
+			attribute.String("server.address", request.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	if port, err := strconv.Atoi(request.URL.Port()); err == nil {
+		span.SetAttributes(attribute.Int("server.port", port))
+	}
+
+	// the request must not be modified, so inject into a clone
+	request = request.Clone(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", response.StatusCode))
+	if response.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(response.StatusCode))
+	}
+	return response, nil
+}
@@ -0,0 +1,65 @@
+// Author hoenig
+
+package oteltracing
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/vaultapi"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func Test_Transport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var traceparent string
+	base := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		traceparent = request.Header.Get("Traceparent")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"data":{"value":"abc123"}}`)),
+		}, nil
+	})
+
+	client, err := vaultapi.New(vaultapi.ClientOptions{
+		Servers: []string{"http://localhost:8200"},
+		Transport: NewTransport(base, Options{
+			TracerProvider: provider,
+			Propagator:     propagation.TraceContext{},
+		}),
+	}, vaultapi.NewStaticToken("token"))
+	require.NoError(t, err)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	value, err := client.WithContext(ctx).Get("/foo")
+	require.NoError(t, err)
+	require.Equal(t, "abc123", value)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+	require.Equal(t, "vault GET", span.Name())
+	require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	require.Equal(t, codes.Unset, span.Status().Code)
+	require.Contains(t, span.Attributes(), attribute.String("url.path", "/v1/secret/foo"))
+	require.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+	require.Contains(t, traceparent, span.SpanContext().SpanID().String())
+}
@@ -0,0 +1,749 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A PKI represents a pki secrets engine, which is a certificate
+// authority issuing X.509 certificates on demand.
+//
+// More information about the pki secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/pki/index.html
+type PKI interface {
+	// Issue will generate a new private key and a certificate for
+	// it, signed by the CA with the parameters of role.
+	Issue(role string, opts PKICertificateOptions) (PKICertificate, error)
+	// Sign will create a certificate for the public key of csr,
+	// signed by the CA with the parameters of role.
+	Sign(role string, csr *x509.CertificateRequest, opts PKICertificateOptions) (PKICertificate, error)
+
+	// Roles
+	CreateRole(opts PKIRoleOptions) error
+	LookupRole(name string) (LookedUpPKIRole, error)
+	ListRoles() ([]string, error)
+	DeleteRole(name string) error
+
+	// Configuration
+	ConfigureURLs(urls PKIURLs) error
+	LookupURLs() (PKIURLs, error)
+	ConfigureCRL(config PKICRLConfig) error
+	LookupCRLConfig() (PKICRLConfig, error)
+
+	// Certificate authorities
+	GenerateRoot(opts PKICAOptions) (PKICertificate, error)
+	GenerateIntermediate(opts PKICAOptions) (PKICertificateRequest, error)
+	SignIntermediate(csr *x509.CertificateRequest, opts PKICAOptions) (PKICertificate, error)
+	SetSignedIntermediate(chain []byte) error
+
+	// Certificates
+	Revoke(serial string) (time.Time, error)
+	ListCertificates() ([]string, error)
+	ReadCertificate(serial string) (PKIStoredCertificate, error)
+	CAPEM() ([]byte, error)
+	CADER() ([]byte, error)
+	CAChainPEM() ([]byte, error)
+	CRLPEM() ([]byte, error)
+	CRLDER() ([]byte, error)
+
+	// Maintenance
+	Tidy(opts PKITidyOptions) error
+	TidyStatus() (PKITidyStatus, error)
+}
+
+// PKICertificateOptions are the parameters of a certificate to be
+// issued or signed. The role determines which values are allowed.
+// When signing, an empty CommonName means the common name of the
+// certificate request is used.
+type PKICertificateOptions struct {
+	CommonName        string   `json:"common_name,omitempty"`
+	AltNames          []string `json:"-"`
+	IPSANs            []string `json:"-"`
+	URISANs           []string `json:"-"`
+	OtherSANs         []string `json:"-"`
+	TTL               string   `json:"ttl,omitempty"`
+	ExcludeCNFromSANs bool     `json:"exclude_cn_from_sans,omitempty"`
+}
+
+// pkiSANs are the subject alternative names of a certificate
+// the way vault expects, as comma separated strings
+type pkiSANs struct {
+	AltNames  string `json:"alt_names,omitempty"`
+	IPSANs    string `json:"ip_sans,omitempty"`
+	URISANs   string `json:"uri_sans,omitempty"`
+	OtherSANs string `json:"other_sans,omitempty"`
+}
+
+func newPKISANs(altNames, ipSANs, uriSANs, otherSANs []string) pkiSANs {
+	return pkiSANs{
+		AltNames:  strings.Join(altNames, ","),
+		IPSANs:    strings.Join(ipSANs, ","),
+		URISANs:   strings.Join(uriSANs, ","),
+		OtherSANs: strings.Join(otherSANs, ","),
+	}
+}
+
+func pemCSR(csr *x509.CertificateRequest) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: csr.Raw,
+	}))
+}
+
+// pkiCertificateBody encodes opts (and the PEM encoded csr, if any)
+// the way vault expects.
+func pkiCertificateBody(opts PKICertificateOptions, csr string) ([]byte, error) {
+	return json.Marshal(struct {
+		PKICertificateOptions
+		pkiSANs
+		CSR string `json:"csr,omitempty"`
+	}{
+		PKICertificateOptions: opts,
+		pkiSANs:               newPKISANs(opts.AltNames, opts.IPSANs, opts.URISANs, opts.OtherSANs),
+		CSR:                   csr,
+	})
+}
+
+// A PKICertificate is a certificate issued or signed by a PKI.
+type PKICertificate struct {
+	// Certificate is the parsed certificate.
+	Certificate *x509.Certificate
+
+	// SerialNumber is the serial number of the certificate in the
+	// colon separated hex form used by vault, e.g. "39:dd:2e:...".
+	SerialNumber string
+
+	// Chain is the PEM encoded certificate, followed by the PEM
+	// encoded certificates of the CA chain which issued it.
+	Chain []byte
+
+	// PrivateKey is the PEM encoded private key of the certificate,
+	// and PrivateKeyType is its type (e.g. "rsa"). These are only
+	// set for issued certificates.
+	PrivateKey     []byte
+	PrivateKeyType string
+}
+
+func (c *client) PKIMount(mount string) PKI {
+	return &pki{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type pki struct {
+	client *client
+	mount  string
+}
+
+type pkiCertificateWrapper struct {
+	Data struct {
+		Certificate    string   `json:"certificate"`
+		IssuingCA      string   `json:"issuing_ca"`
+		CAChain        []string `json:"ca_chain"`
+		PrivateKey     string   `json:"private_key"`
+		PrivateKeyType string   `json:"private_key_type"`
+		SerialNumber   string   `json:"serial_number"`
+	} `json:"data"`
+}
+
+func (w pkiCertificateWrapper) certificate() (PKICertificate, error) {
+	cert, err := parseCertificatePEM([]byte(w.Data.Certificate))
+	if err != nil {
+		return PKICertificate{}, err
+	}
+
+	// older versions of vault only return the issuing ca
+	chain := w.Data.CAChain
+	if len(chain) == 0 && w.Data.IssuingCA != "" {
+		chain = []string{w.Data.IssuingCA}
+	}
+
+	pems := append([]string{w.Data.Certificate}, chain...)
+	for i := range pems {
+		pems[i] = strings.TrimSpace(pems[i])
+	}
+
+	return PKICertificate{
+		Certificate:    cert,
+		SerialNumber:   w.Data.SerialNumber,
+		Chain:          []byte(strings.Join(pems, "\n") + "\n"),
+		PrivateKey:     []byte(w.Data.PrivateKey),
+		PrivateKeyType: w.Data.PrivateKeyType,
+	}, nil
+}
+
+func parseCertificatePEM(bs []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(bs)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse certificate")
+	}
+
+	return cert, nil
+}
+
+func (p *pki) Issue(role string, opts PKICertificateOptions) (PKICertificate, error) {
+	bs, err := pkiCertificateBody(opts, "")
+	if err != nil {
+		return PKICertificate{}, err
+	}
+
+	var wrapper pkiCertificateWrapper
+	requestPath := fmt.Sprintf("%s/issue/%s", p.mount, role)
+	p.client.logRequest("pki-issue", requestPath, bs)
+	if err := p.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return PKICertificate{}, errors.Wrapf(err, "failed to issue certificate for role %q", role)
+	}
+
+	return wrapper.certificate()
+}
+
+func (p *pki) Sign(role string, csr *x509.CertificateRequest, opts PKICertificateOptions) (PKICertificate, error) {
+	bs, err := pkiCertificateBody(opts, pemCSR(csr))
+	if err != nil {
+		return PKICertificate{}, err
+	}
+
+	var wrapper pkiCertificateWrapper
+	requestPath := fmt.Sprintf("%s/sign/%s", p.mount, role)
+	p.client.logRequest("pki-sign", requestPath, bs)
+	if err := p.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return PKICertificate{}, errors.Wrapf(err, "failed to sign certificate for role %q", role)
+	}
+
+	return wrapper.certificate()
+}
+
+// PKIRoleOptions are used to define properties of a pki role being
+// created or updated. A role constrains which certificates may be
+// issued or signed. Durations are expressed as strings that vault
+// understands, such as "72h".
+//
+// The AllowIPSANs, ServerFlag, and ClientFlag fields are left at the
+// defaults of vault, which are true, unless they are set.
+type PKIRoleOptions struct {
+	Name             string   `json:"-"`
+	TTL              string   `json:"ttl,omitempty"`
+	MaxTTL           string   `json:"max_ttl,omitempty"`
+	AllowedDomains   []string `json:"-"`
+	AllowSubdomains  bool     `json:"allow_subdomains,omitempty"`
+	AllowBareDomains bool     `json:"allow_bare_domains,omitempty"`
+	AllowGlobDomains bool     `json:"allow_glob_domains,omitempty"`
+	AllowAnyName     bool     `json:"allow_any_name,omitempty"`
+	AllowIPSANs      *bool    `json:"allow_ip_sans,omitempty"`
+	ServerFlag       *bool    `json:"server_flag,omitempty"`
+	ClientFlag       *bool    `json:"client_flag,omitempty"`
+	KeyType          string   `json:"key_type,omitempty"`
+	KeyBits          int      `json:"key_bits,omitempty"`
+	GenerateLease    bool     `json:"generate_lease,omitempty"`
+	NoStore          bool     `json:"no_store,omitempty"`
+}
+
+func (p *pki) CreateRole(opts PKIRoleOptions) error {
+	bs, err := json.Marshal(struct {
+		PKIRoleOptions
+		AllowedDomains string `json:"allowed_domains,omitempty"`
+	}{
+		PKIRoleOptions: opts,
+		AllowedDomains: strings.Join(opts.AllowedDomains, ","),
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshalling pki role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/roles/%s", p.mount, opts.Name)
+	p.client.logRequest("pki-role-create", requestPath, bs)
+	if err := p.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating pki role at %q", requestPath)
+	}
+
+	return nil
+}
+
+// A LookedUpPKIRole represents information returned from vault
+// after making a request for information about a particular pki role.
+type LookedUpPKIRole struct {
+	TTL              int      `json:"ttl"`
+	MaxTTL           int      `json:"max_ttl"`
+	AllowedDomains   []string `json:"-"`
+	AllowSubdomains  bool     `json:"allow_subdomains"`
+	AllowBareDomains bool     `json:"allow_bare_domains"`
+	AllowGlobDomains bool     `json:"allow_glob_domains"`
+	AllowAnyName     bool     `json:"allow_any_name"`
+	AllowIPSANs      bool     `json:"allow_ip_sans"`
+	ServerFlag       bool     `json:"server_flag"`
+	ClientFlag       bool     `json:"client_flag"`
+	KeyType          string   `json:"key_type"`
+	KeyBits          int      `json:"key_bits"`
+	GenerateLease    bool     `json:"generate_lease"`
+	NoStore          bool     `json:"no_store"`
+}
+
+type lookedUpPKIRoleWrapper struct {
+	Data struct {
+		LookedUpPKIRole
+		// older versions of vault return a comma separated
+		// string rather than a list
+		AllowedDomains json.RawMessage `json:"allowed_domains"`
+	} `json:"data"`
+}
+
+func (p *pki) LookupRole(name string) (LookedUpPKIRole, error) {
+	var wrapper lookedUpPKIRoleWrapper
+	requestPath := fmt.Sprintf("%s/roles/%s", p.mount, name)
+	if err := p.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpPKIRole{}, errors.Wrapf(err, "failed to look up pki role %q", name)
+	}
+
+	role := wrapper.Data.LookedUpPKIRole
+	raw := wrapper.Data.AllowedDomains
+	var domains string
+	switch {
+	case len(raw) == 0:
+	case json.Unmarshal(raw, &domains) == nil:
+		if domains != "" {
+			role.AllowedDomains = strings.Split(domains, ",")
+		}
+	default:
+		if err := json.Unmarshal(raw, &role.AllowedDomains); err != nil {
+			return LookedUpPKIRole{}, errors.Wrapf(err, "failed to decode allowed domains of pki role %q", name)
+		}
+	}
+
+	return role, nil
+}
+
+func (p *pki) ListRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := p.mount + "/roles"
+	if err := p.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list pki roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (p *pki) DeleteRole(name string) error {
+	requestPath := fmt.Sprintf("%s/roles/%s", p.mount, name)
+	if err := p.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete pki role %q", name)
+	}
+	return nil
+}
+
+// PKIURLs are the URLs which are encoded into the certificates
+// issued by a PKI, so that clients can find the issuing CA, the CRL,
+// and OCSP servers.
+type PKIURLs struct {
+	IssuingCertificates   []string `json:"issuing_certificates,omitempty"`
+	CRLDistributionPoints []string `json:"crl_distribution_points,omitempty"`
+	OCSPServers           []string `json:"ocsp_servers,omitempty"`
+}
+
+type pkiURLsWrapper struct {
+	Data PKIURLs `json:"data"`
+}
+
+func (p *pki) ConfigureURLs(urls PKIURLs) error {
+	bs, err := json.Marshal(urls)
+	if err != nil {
+		return errors.Wrap(err, "marshalling pki urls to JSON request body")
+	}
+
+	requestPath := p.mount + "/config/urls"
+	p.client.logRequest("pki-urls-configure", requestPath, bs)
+	if err := p.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "configuring pki urls at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (p *pki) LookupURLs() (PKIURLs, error) {
+	var wrapper pkiURLsWrapper
+	if err := p.client.get(p.mount+"/config/urls", &wrapper); err != nil {
+		return PKIURLs{}, errors.Wrap(err, "failed to read pki urls")
+	}
+	return wrapper.Data, nil
+}
+
+// A PKICRLConfig configures the CRL of a PKI. Expiry is how long
+// the CRL is valid for, expressed as a string that vault understands,
+// such as "72h". Disable turns off the CRL entirely.
+type PKICRLConfig struct {
+	Expiry  string `json:"expiry,omitempty"`
+	Disable bool   `json:"disable"`
+}
+
+type pkiCRLConfigWrapper struct {
+	Data PKICRLConfig `json:"data"`
+}
+
+func (p *pki) ConfigureCRL(config PKICRLConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling pki crl config to JSON request body")
+	}
+
+	requestPath := p.mount + "/config/crl"
+	p.client.logRequest("pki-crl-configure", requestPath, bs)
+	if err := p.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "configuring pki crl at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (p *pki) LookupCRLConfig() (PKICRLConfig, error) {
+	var wrapper pkiCRLConfigWrapper
+	if err := p.client.get(p.mount+"/config/crl", &wrapper); err != nil {
+		return PKICRLConfig{}, errors.Wrap(err, "failed to read pki crl config")
+	}
+	return wrapper.Data, nil
+}
+
+// PKICAOptions are the parameters of a CA certificate being
+// generated or signed.
+//
+// When Exported is set, the private key of a generated CA is returned
+// (and can never be read again), otherwise it never leaves vault.
+// KeyType, KeyBits, and Exported do not apply when signing an
+// intermediate CA, which keeps the key of its request.
+type PKICAOptions struct {
+	CommonName          string   `json:"common_name"`
+	AltNames            []string `json:"-"`
+	IPSANs              []string `json:"-"`
+	URISANs             []string `json:"-"`
+	OtherSANs           []string `json:"-"`
+	TTL                 string   `json:"ttl,omitempty"`
+	KeyType             string   `json:"key_type,omitempty"`
+	KeyBits             int      `json:"key_bits,omitempty"`
+	ExcludeCNFromSANs   bool     `json:"exclude_cn_from_sans,omitempty"`
+	PermittedDNSDomains []string `json:"permitted_dns_domains,omitempty"`
+	Exported            bool     `json:"-"`
+}
+
+// pkiCABody encodes opts (and the PEM encoded csr, if any)
+// the way vault expects.
+func pkiCABody(opts PKICAOptions, csr string) ([]byte, error) {
+	return json.Marshal(struct {
+		PKICAOptions
+		pkiSANs
+		CSR string `json:"csr,omitempty"`
+	}{
+		PKICAOptions: opts,
+		pkiSANs:      newPKISANs(opts.AltNames, opts.IPSANs, opts.URISANs, opts.OtherSANs),
+		CSR:          csr,
+	})
+}
+
+func (opts PKICAOptions) generateType() string {
+	if opts.Exported {
+		return "exported"
+	}
+	return "internal"
+}
+
+func (p *pki) GenerateRoot(opts PKICAOptions) (PKICertificate, error) {
+	bs, err := pkiCABody(opts, "")
+	if err != nil {
+		return PKICertificate{}, err
+	}
+
+	var wrapper pkiCertificateWrapper
+	requestPath := fmt.Sprintf("%s/root/generate/%s", p.mount, opts.generateType())
+	p.client.logRequest("pki-root-generate", requestPath, bs)
+	if err := p.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return PKICertificate{}, errors.Wrapf(err, "generating root CA at %q", requestPath)
+	}
+
+	return wrapper.certificate()
+}
+
+// A PKICertificateRequest is a certificate request generated by
+// a PKI for an intermediate CA, to be signed by another CA.
+type PKICertificateRequest struct {
+	// Request is the parsed certificate request.
+	Request *x509.CertificateRequest
+
+	// PrivateKey is the PEM encoded private key of the request,
+	// and PrivateKeyType is its type (e.g. "rsa"). These are only
+	// set if the key was generated as exported.
+	PrivateKey     []byte
+	PrivateKeyType string
+}
+
+type pkiCertificateRequestWrapper struct {
+	Data struct {
+		CSR            string `json:"csr"`
+		PrivateKey     string `json:"private_key"`
+		PrivateKeyType string `json:"private_key_type"`
+	} `json:"data"`
+}
+
+func (p *pki) GenerateIntermediate(opts PKICAOptions) (PKICertificateRequest, error) {
+	bs, err := pkiCABody(opts, "")
+	if err != nil {
+		return PKICertificateRequest{}, err
+	}
+
+	var wrapper pkiCertificateRequestWrapper
+	requestPath := fmt.Sprintf("%s/intermediate/generate/%s", p.mount, opts.generateType())
+	p.client.logRequest("pki-intermediate-generate", requestPath, bs)
+	if err := p.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return PKICertificateRequest{}, errors.Wrapf(err, "generating intermediate CA at %q", requestPath)
+	}
+
+	block, _ := pem.Decode([]byte(wrapper.Data.CSR))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return PKICertificateRequest{}, errors.New("no PEM encoded certificate request")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return PKICertificateRequest{}, errors.Wrap(err, "failed to parse certificate request")
+	}
+
+	return PKICertificateRequest{
+		Request:        csr,
+		PrivateKey:     []byte(wrapper.Data.PrivateKey),
+		PrivateKeyType: wrapper.Data.PrivateKeyType,
+	}, nil
+}
+
+func (p *pki) SignIntermediate(csr *x509.CertificateRequest, opts PKICAOptions) (PKICertificate, error) {
+	bs, err := pkiCABody(opts, pemCSR(csr))
+	if err != nil {
+		return PKICertificate{}, err
+	}
+
+	var wrapper pkiCertificateWrapper
+	requestPath := p.mount + "/root/sign-intermediate"
+	p.client.logRequest("pki-intermediate-sign", requestPath, bs)
+	if err := p.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return PKICertificate{}, errors.Wrapf(err, "failed to sign intermediate CA %q", opts.CommonName)
+	}
+
+	return wrapper.certificate()
+}
+
+// SetSignedIntermediate sets the PEM encoded certificate of the
+// intermediate CA of the PKI, which is generally the Chain of the
+// result of SignIntermediate.
+func (p *pki) SetSignedIntermediate(chain []byte) error {
+	bs, err := json.Marshal(struct {
+		Certificate string `json:"certificate"`
+	}{Certificate: string(chain)})
+	if err != nil {
+		return err
+	}
+
+	requestPath := p.mount + "/intermediate/set-signed"
+	if err := p.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "setting signed intermediate CA at %q", requestPath)
+	}
+
+	return nil
+}
+
+type pkiRevocationWrapper struct {
+	Data struct {
+		RevocationTime int64 `json:"revocation_time"`
+	} `json:"data"`
+}
+
+// Revoke revokes the certificate with the given serial number, and
+// returns the time at which it was revoked. Revoking a certificate
+// which is already revoked returns the original revocation time.
+func (p *pki) Revoke(serial string) (time.Time, error) {
+	bs, err := json.Marshal(struct {
+		SerialNumber string `json:"serial_number"`
+	}{SerialNumber: serial})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var wrapper pkiRevocationWrapper
+	if err := p.client.post(p.mount+"/revoke", string(bs), &wrapper); err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed to revoke certificate %q", serial)
+	}
+
+	return time.Unix(wrapper.Data.RevocationTime, 0), nil
+}
+
+// ListCertificates lists the serial numbers of the certificates
+// stored by the PKI, which excludes those issued by roles with
+// NoStore set.
+func (p *pki) ListCertificates() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := p.mount + "/certs"
+	if err := p.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list certificates at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+// A PKIStoredCertificate is a certificate stored by a PKI.
+type PKIStoredCertificate struct {
+	// Certificate is the parsed certificate, and PEM is
+	// the PEM encoded certificate.
+	Certificate *x509.Certificate
+	PEM         []byte
+
+	// RevocationTime is the time at which the certificate was
+	// revoked, or the zero time if it has not been revoked.
+	RevocationTime time.Time
+}
+
+type pkiStoredCertificateWrapper struct {
+	Data struct {
+		Certificate    string `json:"certificate"`
+		RevocationTime int64  `json:"revocation_time"`
+	} `json:"data"`
+}
+
+// ReadCertificate reads the certificate with the given serial number.
+// The CA certificate can be read with the serial number "ca".
+func (p *pki) ReadCertificate(serial string) (PKIStoredCertificate, error) {
+	var wrapper pkiStoredCertificateWrapper
+	requestPath := fmt.Sprintf("%s/cert/%s", p.mount, serial)
+	if err := p.client.get(requestPath, &wrapper); err != nil {
+		return PKIStoredCertificate{}, errors.Wrapf(err, "failed to read certificate %q", serial)
+	}
+
+	cert, err := parseCertificatePEM([]byte(wrapper.Data.Certificate))
+	if err != nil {
+		return PKIStoredCertificate{}, err
+	}
+
+	stored := PKIStoredCertificate{
+		Certificate: cert,
+		PEM:         []byte(wrapper.Data.Certificate),
+	}
+	if wrapper.Data.RevocationTime > 0 {
+		stored.RevocationTime = time.Unix(wrapper.Data.RevocationTime, 0)
+	}
+
+	return stored, nil
+}
+
+func (p *pki) raw(path, what string) ([]byte, error) {
+	var bs []byte
+	if err := p.client.get(p.mount+path, &bs); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", what)
+	}
+	return bs, nil
+}
+
+// CAPEM returns the PEM encoded CA certificate of the PKI.
+func (p *pki) CAPEM() ([]byte, error) {
+	return p.raw("/ca/pem", "CA certificate")
+}
+
+// CADER returns the DER encoded CA certificate of the PKI.
+func (p *pki) CADER() ([]byte, error) {
+	return p.raw("/ca", "CA certificate")
+}
+
+// CAChainPEM returns the PEM encoded CA chain of the PKI, which
+// excludes the root CA. The chain of a root CA is empty.
+func (p *pki) CAChainPEM() ([]byte, error) {
+	return p.raw("/ca_chain", "CA chain")
+}
+
+// CRLPEM returns the PEM encoded CRL of the PKI.
+func (p *pki) CRLPEM() ([]byte, error) {
+	return p.raw("/crl/pem", "CRL")
+}
+
+// CRLDER returns the DER encoded CRL of the PKI.
+func (p *pki) CRLDER() ([]byte, error) {
+	return p.raw("/crl", "CRL")
+}
+
+// PKITidyOptions are used to configure which expired certificates
+// are removed by a tidy operation. SafetyBuffer is how long after
+// expiration a certificate is kept, expressed as a string that vault
+// understands, such as "72h" (the default).
+type PKITidyOptions struct {
+	TidyCertStore    bool   `json:"tidy_cert_store,omitempty"`
+	TidyRevokedCerts bool   `json:"tidy_revoked_certs,omitempty"`
+	SafetyBuffer     string `json:"safety_buffer,omitempty"`
+}
+
+// Tidy starts removing expired certificates from the storage of the
+// PKI, and from the CRL. Newer versions of vault tidy in the
+// background, in which case TidyStatus reports on the progress.
+func (p *pki) Tidy(opts PKITidyOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	p.client.logRequest("pki-tidy", p.mount+"/tidy", bs)
+
+	if err := p.client.post(p.mount+"/tidy", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to tidy pki")
+	}
+
+	return nil
+}
+
+const (
+	// PKITidyInactive is the state of a PKI which has
+	// not been tidied since vault started.
+	PKITidyInactive = "Inactive"
+
+	// PKITidyRunning is the state of a PKI being tidied.
+	PKITidyRunning = "Running"
+
+	// PKITidyFinished is the state of a PKI after tidying
+	// completed successfully.
+	PKITidyFinished = "Finished"
+
+	// PKITidyError is the state of a PKI after tidying failed.
+	PKITidyError = "Error"
+)
+
+// A PKITidyStatus describes the progress of the last tidy
+// operation of a PKI.
+type PKITidyStatus struct {
+	State                   string `json:"state"`
+	Error                   string `json:"error"`
+	Message                 string `json:"message"`
+	TimeStarted             string `json:"time_started"`
+	TimeFinished            string `json:"time_finished"`
+	TidyCertStore           bool   `json:"tidy_cert_store"`
+	TidyRevokedCerts        bool   `json:"tidy_revoked_certs"`
+	SafetyBuffer            int    `json:"safety_buffer"`
+	CertStoreDeletedCount   int    `json:"cert_store_deleted_count"`
+	RevokedCertDeletedCount int    `json:"revoked_cert_deleted_count"`
+}
+
+type pkiTidyStatusWrapper struct {
+	Data PKITidyStatus `json:"data"`
+}
+
+// TidyStatus returns the progress of the last tidy operation,
+// which is only available in vault 1.5 and later.
+func (p *pki) TidyStatus() (PKITidyStatus, error) {
+	var wrapper pkiTidyStatusWrapper
+	if err := p.client.get(p.mount+"/tidy-status", &wrapper); err != nil {
+		return PKITidyStatus{}, errors.Wrap(err, "failed to read pki tidy status")
+	}
+	return wrapper.Data, nil
+}
@@ -0,0 +1,256 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PKI_Issue(t *testing.T) {
+	client := getClient(t, rootTokener)
+	pki := client.PKIMount("pki")
+
+	cert, err := pki.Issue("example-dot-com", PKICertificateOptions{
+		CommonName: "www.example.com",
+		AltNames:   []string{"api.example.com"},
+		TTL:        "1h",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "www.example.com", cert.Certificate.Subject.CommonName)
+	require.Contains(t, cert.Certificate.DNSNames, "api.example.com")
+	require.True(t, cert.Certificate.NotAfter.Before(time.Now().Add(2*time.Hour)))
+	require.NotEmpty(t, cert.SerialNumber)
+
+	// the chain and key are usable as is
+	_, err = tls.X509KeyPair(cert.Chain, cert.PrivateKey)
+	require.NoError(t, err)
+
+	// the role does not allow other domains
+	_, err = pki.Issue("example-dot-com", PKICertificateOptions{CommonName: "www.example.org"})
+	require.Error(t, err)
+}
+
+func Test_PKI_Sign(t *testing.T) {
+	client := getClient(t, rootTokener)
+	pki := client.PKIMount("pki")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "signed.example.com"},
+	}, key)
+	require.NoError(t, err)
+
+	csr, err := x509.ParseCertificateRequest(der)
+	require.NoError(t, err)
+
+	cert, err := pki.Sign("example-dot-com", csr, PKICertificateOptions{TTL: "1h"})
+	require.NoError(t, err)
+	require.Equal(t, "signed.example.com", cert.Certificate.Subject.CommonName)
+	require.Equal(t, &key.PublicKey, cert.Certificate.PublicKey)
+	require.Empty(t, cert.PrivateKey)
+}
+
+func Test_PKI_Roles(t *testing.T) {
+	client := getClient(t, rootTokener)
+	pki := client.PKIMount("pki")
+
+	err := pki.CreateRole(PKIRoleOptions{
+		Name:            "test-role1",
+		MaxTTL:          "24h",
+		AllowedDomains:  []string{"example.com", "example.org"},
+		AllowSubdomains: true,
+		ClientFlag:      Bool(false),
+	})
+	require.NoError(t, err)
+
+	role, err := pki.LookupRole("test-role1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com", "example.org"}, role.AllowedDomains)
+	require.Equal(t, 24*60*60, role.MaxTTL)
+	require.True(t, role.AllowSubdomains)
+	// flags which are not set keep the defaults of vault
+	require.True(t, role.ServerFlag)
+	require.True(t, role.AllowIPSANs)
+	require.False(t, role.ClientFlag)
+
+	roles, err := pki.ListRoles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"example-dot-com", "test-role1"}, roles)
+
+	require.NoError(t, pki.DeleteRole("test-role1"))
+	_, err = pki.LookupRole("test-role1")
+	require.Error(t, err)
+}
+
+func Test_PKI_Config(t *testing.T) {
+	client := getClient(t, rootTokener)
+	pki := client.PKIMount("pki")
+
+	urls := PKIURLs{
+		IssuingCertificates:   []string{"http://127.0.0.1:8200/v1/pki/ca"},
+		CRLDistributionPoints: []string{"http://127.0.0.1:8200/v1/pki/crl"},
+	}
+	require.NoError(t, pki.ConfigureURLs(urls))
+
+	lookedUp, err := pki.LookupURLs()
+	require.NoError(t, err)
+	require.Equal(t, urls.IssuingCertificates, lookedUp.IssuingCertificates)
+	require.Equal(t, urls.CRLDistributionPoints, lookedUp.CRLDistributionPoints)
+
+	require.NoError(t, pki.ConfigureCRL(PKICRLConfig{Expiry: "48h"}))
+
+	crl, err := pki.LookupCRLConfig()
+	require.NoError(t, err)
+	require.Equal(t, "48h", crl.Expiry)
+	require.False(t, crl.Disable)
+}
+
+func Test_PKI_CA(t *testing.T) {
+	client := getClient(t, rootTokener)
+	root := client.PKIMount("pki_root")
+	intermediate := client.PKIMount("pki_int")
+
+	rootCA, err := root.GenerateRoot(PKICAOptions{
+		CommonName: "root.example.com",
+		TTL:        "8760h",
+	})
+	require.NoError(t, err)
+	require.True(t, rootCA.Certificate.IsCA)
+	require.Empty(t, rootCA.PrivateKey)
+
+	request, err := intermediate.GenerateIntermediate(PKICAOptions{
+		CommonName: "intermediate.example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "intermediate.example.com", request.Request.Subject.CommonName)
+
+	intermediateCA, err := root.SignIntermediate(request.Request, PKICAOptions{
+		CommonName: "intermediate.example.com",
+		TTL:        "4380h",
+	})
+	require.NoError(t, err)
+	require.True(t, intermediateCA.Certificate.IsCA)
+	require.NoError(t, intermediateCA.Certificate.CheckSignatureFrom(rootCA.Certificate))
+
+	require.NoError(t, intermediate.SetSignedIntermediate(intermediateCA.Chain))
+
+	// the intermediate CA can now issue certificates
+	require.NoError(t, intermediate.CreateRole(PKIRoleOptions{
+		Name:           "leaf",
+		AllowedDomains: []string{"example.com"},
+		AllowAnyName:   true,
+	}))
+
+	leaf, err := intermediate.Issue("leaf", PKICertificateOptions{CommonName: "leaf.example.com"})
+	require.NoError(t, err)
+	require.NoError(t, leaf.Certificate.CheckSignatureFrom(intermediateCA.Certificate))
+}
+
+func Test_PKI_Revoke(t *testing.T) {
+	client := getClient(t, rootTokener)
+	pki := client.PKIMount("pki")
+
+	cert, err := pki.Issue("example-dot-com", PKICertificateOptions{CommonName: "revoked.example.com"})
+	require.NoError(t, err)
+
+	serials, err := pki.ListCertificates()
+	require.NoError(t, err)
+	require.Contains(t, serials, strings.Replace(cert.SerialNumber, ":", "-", -1))
+
+	stored, err := pki.ReadCertificate(cert.SerialNumber)
+	require.NoError(t, err)
+	require.Equal(t, cert.Certificate.Raw, stored.Certificate.Raw)
+	require.True(t, stored.RevocationTime.IsZero())
+
+	revoked, err := pki.Revoke(cert.SerialNumber)
+	require.NoError(t, err)
+	require.False(t, revoked.IsZero())
+
+	stored, err = pki.ReadCertificate(cert.SerialNumber)
+	require.NoError(t, err)
+	require.Equal(t, revoked, stored.RevocationTime)
+
+	// the revoked certificate is in the CRL
+	der, err := pki.CRLDER()
+	require.NoError(t, err)
+	crl, err := x509.ParseCRL(der)
+	require.NoError(t, err)
+
+	found := false
+	for _, entry := range crl.TBSCertList.RevokedCertificates {
+		found = found || entry.SerialNumber.Cmp(cert.Certificate.SerialNumber) == 0
+	}
+	require.True(t, found)
+
+	pemCRL, err := pki.CRLPEM()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(pemCRL), "-----BEGIN X509 CRL-----"))
+}
+
+func Test_PKI_CA_read(t *testing.T) {
+	client := getClient(t, rootTokener)
+	pki := client.PKIMount("pki")
+
+	der, err := pki.CADER()
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	require.Equal(t, "example.com", ca.Subject.CommonName)
+
+	pemCA, err := pki.CAPEM()
+	require.NoError(t, err)
+	parsed, err := parseCertificatePEM(pemCA)
+	require.NoError(t, err)
+	require.Equal(t, der, parsed.Raw)
+
+	// the chain of a root CA is empty
+	chain, err := pki.CAChainPEM()
+	require.NoError(t, err)
+	require.Empty(t, chain)
+}
+
+func Test_PKI_Tidy(t *testing.T) {
+	client := getClient(t, rootTokener)
+	pki := client.PKIMount("pki")
+
+	err := pki.Tidy(PKITidyOptions{
+		TidyCertStore:    true,
+		TidyRevokedCerts: true,
+		SafetyBuffer:     "1h",
+	})
+	require.NoError(t, err)
+}
+
+func Test_pki_CreateRole(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/pki/roles/web": "",
+	})
+	pki := vault.PKIMount("pki")
+
+	require.NoError(t, pki.CreateRole(PKIRoleOptions{
+		Name:           "web",
+		AllowedDomains: []string{"example.com"},
+	}))
+
+	require.NoError(t, pki.CreateRole(PKIRoleOptions{
+		Name:        "web",
+		AllowIPSANs: Bool(false),
+		ServerFlag:  Bool(true),
+		ClientFlag:  Bool(false),
+	}))
+
+	require.Equal(t, `{"allowed_domains":"example.com"}`, vault.requests[0].Body)
+	require.Equal(t, `{"allow_ip_sans":false,"server_flag":true,"client_flag":false}`, vault.requests[1].Body)
+}
@@ -0,0 +1,159 @@
+// Author hoenig
+
+// Package pkiclient keeps a tls.Config supplied with certificates
+// issued by the pki secrets engine of vault, e.g.
+//
+//	manager, err := pkiclient.NewCertManager(client.PKIMount("pki"), "web", pkiclient.Options{
+//		Certificate: vaultapi.PKICertificateOptions{CommonName: "www.example.com"},
+//	})
+//	...
+//	defer manager.Stop()
+//	server := &http.Server{
+//		TLSConfig: &tls.Config{GetCertificate: manager.GetCertificate},
+//	}
+package pkiclient
+
+import (
+	"crypto/tls"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shoenig/vaultapi"
+	"github.com/shoenig/vaultapi/internal/watch"
+)
+
+// Options are used to configure a CertManager.
+type Options struct {
+	// Certificate are the parameters of each certificate issued.
+	Certificate vaultapi.PKICertificateOptions
+
+	// Jitter is the fraction of time by which renewals are randomly
+	// moved earlier, so that many servers started at once do not all
+	// renew at once. By default, this value is 0.1.
+	Jitter float64
+
+	// RetryDelay configures how long to wait after failing to issue
+	// a certificate before trying again. By default, this value is
+	// 5 seconds.
+	RetryDelay time.Duration
+
+	// ErrorHandler, if set, is called with the error each time
+	// issuing a renewed certificate fails.
+	ErrorHandler func(error)
+}
+
+// A CertManager issues a certificate from a pki role, and issues
+// a new certificate in the background before the current one expires.
+// The current certificate is provided to a tls.Config through the
+// GetCertificate and GetClientCertificate methods.
+type CertManager struct {
+	pki  vaultapi.PKI
+	role string
+	opts Options
+
+	lock sync.RWMutex
+	cert *tls.Certificate
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewCertManager creates a CertManager which issues certificates with
+// the given pki role. The first certificate is issued before returning,
+// after which renewals happen in the background until Stop is called.
+func NewCertManager(pki vaultapi.PKI, role string, opts Options) (*CertManager, error) {
+	if opts.Jitter <= 0 || opts.Jitter >= 1 {
+		opts.Jitter = watch.DefaultJitter
+	}
+
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = watch.DefaultRetryDelay
+	}
+
+	m := &CertManager{
+		pki:  pki,
+		role: role,
+		opts: opts,
+		stop: make(chan struct{}),
+	}
+
+	if err := m.issue(); err != nil {
+		return nil, err
+	}
+
+	go m.run()
+	return m, nil
+}
+
+// GetCertificate returns the current certificate, for use
+// as the GetCertificate function of a tls.Config.
+func (m *CertManager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return m.Certificate(), nil
+}
+
+// GetClientCertificate returns the current certificate, for use
+// as the GetClientCertificate function of a tls.Config.
+func (m *CertManager) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return m.Certificate(), nil
+}
+
+// Certificate returns the current certificate. The Leaf of the
+// certificate is always set.
+func (m *CertManager) Certificate() *tls.Certificate {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.cert
+}
+
+// Stop stops renewing the certificate. The current certificate
+// remains available. It is safe to call Stop more than once.
+func (m *CertManager) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+func (m *CertManager) issue() error {
+	issued, err := m.pki.Issue(m.role, m.opts.Certificate)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.X509KeyPair(issued.Chain, issued.PrivateKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to load issued certificate")
+	}
+	cert.Leaf = issued.Certificate
+
+	m.lock.Lock()
+	m.cert = &cert
+	m.lock.Unlock()
+	return nil
+}
+
+func (m *CertManager) run() {
+	for {
+		lifetime := time.Until(m.Certificate().Leaf.NotAfter)
+		if !watch.Sleep(watch.RenewDelay(lifetime, m.opts.Jitter), m.stop) {
+			return
+		}
+
+		// keep trying, even once the current certificate has
+		// expired, as there is nothing better to serve
+		for {
+			err := m.issue()
+			if err == nil {
+				break
+			}
+
+			if m.opts.ErrorHandler != nil {
+				m.opts.ErrorHandler(err)
+			}
+
+			if !watch.Sleep(m.opts.RetryDelay, m.stop) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Author hoenig
+
+package pkiclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shoenig/vaultapi"
+	"github.com/stretchr/testify/require"
+)
+
+// issuer is a PKI which only implements Issue, creating self
+// signed certificates with a short lifetime
+type issuer struct {
+	vaultapi.PKI
+	lifetime time.Duration
+
+	lock   sync.Mutex
+	issued int
+	fail   bool
+}
+
+func (i *issuer) Issue(role string, opts vaultapi.PKICertificateOptions) (vaultapi.PKICertificate, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.fail {
+		return vaultapi.PKICertificate{}, errors.New("permission denied")
+	}
+	i.issued++
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return vaultapi.PKICertificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(int64(i.issued)),
+		Subject:      pkix.Name{CommonName: opts.CommonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(i.lifetime),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return vaultapi.PKICertificate{}, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return vaultapi.PKICertificate{}, err
+	}
+	// the encoding only has a granularity of seconds
+	cert.NotAfter = template.NotAfter
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return vaultapi.PKICertificate{}, err
+	}
+
+	return vaultapi.PKICertificate{
+		Certificate: cert,
+		Chain:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		PrivateKey:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+func (i *issuer) setFail(fail bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.fail = fail
+}
+
+func Test_CertManager_renew(t *testing.T) {
+	pki := &issuer{lifetime: 300 * time.Millisecond}
+
+	manager, err := NewCertManager(pki, "web", Options{
+		Certificate: vaultapi.PKICertificateOptions{CommonName: "www.example.com"},
+	})
+	require.NoError(t, err)
+	defer manager.Stop()
+
+	first, err := manager.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, "www.example.com", first.Leaf.Subject.CommonName)
+	require.Equal(t, int64(1), first.Leaf.SerialNumber.Int64())
+
+	// renewed after roughly 2/3 of the lifetime
+	time.Sleep(400 * time.Millisecond)
+
+	second, err := manager.GetClientCertificate(nil)
+	require.NoError(t, err)
+	require.True(t, second.Leaf.SerialNumber.Int64() > 1)
+}
+
+func Test_CertManager_retry(t *testing.T) {
+	pki := &issuer{lifetime: 300 * time.Millisecond}
+
+	errs := make(chan error, 100)
+	manager, err := NewCertManager(pki, "web", Options{
+		RetryDelay:   50 * time.Millisecond,
+		ErrorHandler: func(err error) { errs <- err },
+	})
+	require.NoError(t, err)
+	defer manager.Stop()
+
+	pki.setFail(true)
+	require.Error(t, <-errs)
+
+	// the current certificate is kept until a new one is issued
+	require.Equal(t, int64(1), manager.Certificate().Leaf.SerialNumber.Int64())
+
+	pki.setFail(false)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int64(2), manager.Certificate().Leaf.SerialNumber.Int64())
+}
+
+func Test_CertManager_fail(t *testing.T) {
+	pki := &issuer{fail: true}
+
+	_, err := NewCertManager(pki, "web", Options{})
+	require.Error(t, err)
+}
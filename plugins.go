@@ -0,0 +1,126 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// The types of plugins which can be registered in the plugin catalog.
+const (
+	PluginTypeAuth     = "auth"
+	PluginTypeDatabase = "database"
+	PluginTypeSecret   = "secret"
+)
+
+// PluginOptions are used to register a plugin in the plugin catalog. The
+// Command is the name of the executable of the plugin, relative to the
+// plugin directory of vault, and SHA256 is the hex encoded checksum of
+// that executable. The Args and Env (as "KEY=value") are passed to the
+// command when the plugin is started.
+//
+// More information about plugins can be found here:
+// https://www.vaultproject.io/docs/internals/plugins.html
+type PluginOptions struct {
+	Command string   `json:"command"`
+	SHA256  string   `json:"sha256"`
+	Args    []string `json:"args,omitempty"`
+	Env     []string `json:"env,omitempty"`
+	Version string   `json:"version,omitempty"`
+}
+
+// A Plugin is a plugin of the plugin catalog. Builtin plugins are
+// compiled into vault, and have no Command or SHA256.
+type Plugin struct {
+	Name    string   `json:"name"`
+	Command string   `json:"command"`
+	SHA256  string   `json:"sha256"`
+	Args    []string `json:"args"`
+	Builtin bool     `json:"builtin"`
+	Version string   `json:"version"`
+}
+
+// RegisterPlugin registers the plugin of the given type and name in the
+// plugin catalog, replacing any plugin already registered with them.
+func (c *client) RegisterPlugin(pluginType, name string, opts PluginOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling plugin data to JSON request body")
+	}
+
+	// do not log the request, which may contain secrets in the env
+	requestPath := fmt.Sprintf("/v1/sys/plugins/catalog/%s/%s", pluginType, name)
+	if err := c.put(requestPath, string(bs)); err != nil {
+		return errors.Wrapf(err, "registering plugin at %q", requestPath)
+	}
+	return nil
+}
+
+func (c *client) LookupPlugin(pluginType, name string) (Plugin, error) {
+	var wrapper struct {
+		Data Plugin `json:"data"`
+	}
+	requestPath := fmt.Sprintf("/v1/sys/plugins/catalog/%s/%s", pluginType, name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return Plugin{}, errors.Wrapf(err, "failed to look up plugin %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+// ListPlugins returns the names of the plugins of the given
+// type in the plugin catalog, including the builtin plugins.
+func (c *client) ListPlugins(pluginType string) ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := fmt.Sprintf("/v1/sys/plugins/catalog/%s", pluginType)
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list plugins at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+// DeregisterPlugin removes the plugin of the given type and name from
+// the plugin catalog. Mounts which use the plugin are left in place,
+// but fail to start until the plugin is registered again.
+func (c *client) DeregisterPlugin(pluginType, name string) error {
+	requestPath := fmt.Sprintf("/v1/sys/plugins/catalog/%s/%s", pluginType, name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to deregister plugin %q", name)
+	}
+	return nil
+}
+
+// PluginReloadOptions are used to reload either all the mounts of the
+// Plugin, or only the given Mounts, but not both. If Scope is "global",
+// the mounts are reloaded on every node of the cluster, rather than
+// only on the node serving the request.
+type PluginReloadOptions struct {
+	Plugin string   `json:"plugin,omitempty"`
+	Mounts []string `json:"mounts,omitempty"`
+	Scope  string   `json:"scope,omitempty"`
+}
+
+// ReloadPlugin restarts the backends of the mounts of a plugin, e.g.
+// after its executable has been upgraded. The reload ID is returned
+// only for reloads with global scope.
+func (c *client) ReloadPlugin(opts PluginReloadOptions) (string, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling plugin reload data to JSON request body")
+	}
+	c.logRequest("plugin-reload", "/v1/sys/plugins/reload/backend", bs)
+
+	var wrapper struct {
+		Data struct {
+			ReloadID string `json:"reload_id"`
+		} `json:"data"`
+	}
+	if err := c.post("/v1/sys/plugins/reload/backend", string(bs), &wrapper); err != nil {
+		return "", errors.Wrap(err, "failed to reload plugin")
+	}
+	return wrapper.Data.ReloadID, nil
+}
@@ -0,0 +1,15 @@
+// Author hoenig
+
+package vaultapi
+
+// Bool returns a pointer to b, for options which are left
+// unchanged unless set, such as KV2MetadataOptions.CASRequired.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Int returns a pointer to i, for options which are left
+// unchanged unless set, such as KV2MetadataOptions.MaxVersions.
+func Int(i int) *int {
+	return &i
+}
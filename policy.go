@@ -0,0 +1,187 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// The capabilities a policy may grant on a path.
+//
+// More information about policies can be found here:
+// https://www.vaultproject.io/docs/concepts/policies.html
+const (
+	CapabilityCreate = "create"
+	CapabilityRead   = "read"
+	CapabilityUpdate = "update"
+	CapabilityPatch  = "patch"
+	CapabilityDelete = "delete"
+	CapabilityList   = "list"
+	CapabilitySudo   = "sudo"
+	CapabilityDeny   = "deny"
+)
+
+var validCapabilities = map[string]bool{
+	CapabilityCreate: true,
+	CapabilityRead:   true,
+	CapabilityUpdate: true,
+	CapabilityPatch:  true,
+	CapabilityDelete: true,
+	CapabilityList:   true,
+	CapabilitySudo:   true,
+	CapabilityDeny:   true,
+}
+
+// A Policy is a set of rules granting capabilities on paths, which can
+// be rendered into the HCL understood by vault with HCL, e.g.
+//
+//	var policy Policy
+//	policy.Path("secret/app/*", CapabilityRead, CapabilityList)
+//	policy.Path("transit/encrypt/app", CapabilityUpdate).Allow("plaintext")
+//	hcl, err := policy.HCL()
+//	...
+//	err = client.SetPolicy("app", hcl)
+type Policy struct {
+	Rules []*PolicyRule
+}
+
+// A PolicyRule grants Capabilities on a Path, which may end in a * to
+// match any suffix, or contain + to match any single path segment.
+//
+// The parameters of requests to the path may be constrained. If any
+// AllowedParameters are set, only those parameters are allowed, with
+// any of the given values, or any value if none are given. The key
+// "*" allows all parameters. DeniedParameters are never allowed, with
+// any of the given values, or any value if none are given. All of the
+// RequiredParameters must be set.
+type PolicyRule struct {
+	Path               string
+	Capabilities       []string
+	AllowedParameters  map[string][]string
+	DeniedParameters   map[string][]string
+	RequiredParameters []string
+}
+
+// Path adds a rule granting capabilities on path to the policy. The
+// returned rule may be used to constrain the parameters of requests.
+func (p *Policy) Path(path string, capabilities ...string) *PolicyRule {
+	rule := &PolicyRule{
+		Path:         path,
+		Capabilities: capabilities,
+	}
+	p.Rules = append(p.Rules, rule)
+	return rule
+}
+
+// Allow allows the parameter in requests, with any of the given values,
+// or any value if none are given.
+func (r *PolicyRule) Allow(parameter string, values ...string) *PolicyRule {
+	if r.AllowedParameters == nil {
+		r.AllowedParameters = make(map[string][]string)
+	}
+	r.AllowedParameters[parameter] = append(r.AllowedParameters[parameter], values...)
+	return r
+}
+
+// Deny denies the parameter in requests, with any of the given values,
+// or any value if none are given.
+func (r *PolicyRule) Deny(parameter string, values ...string) *PolicyRule {
+	if r.DeniedParameters == nil {
+		r.DeniedParameters = make(map[string][]string)
+	}
+	r.DeniedParameters[parameter] = append(r.DeniedParameters[parameter], values...)
+	return r
+}
+
+// Require requires the parameters to be set in requests.
+func (r *PolicyRule) Require(parameters ...string) *PolicyRule {
+	r.RequiredParameters = append(r.RequiredParameters, parameters...)
+	return r
+}
+
+// Validate checks that each rule of the policy has a path, and grants
+// only valid capabilities. Vault accepts policies with unknown
+// capabilities, which then silently grant nothing.
+func (p Policy) Validate() error {
+	paths := make(map[string]bool, len(p.Rules))
+	for _, rule := range p.Rules {
+		if rule.Path == "" {
+			return errors.New("policy rule has no path")
+		}
+
+		if paths[rule.Path] {
+			return errors.Errorf("policy has multiple rules for path %q", rule.Path)
+		}
+		paths[rule.Path] = true
+
+		if len(rule.Capabilities) == 0 {
+			return errors.Errorf("policy rule for path %q grants no capabilities", rule.Path)
+		}
+
+		for _, capability := range rule.Capabilities {
+			if !validCapabilities[capability] {
+				return errors.Errorf("policy rule for path %q has invalid capability %q", rule.Path, capability)
+			}
+		}
+	}
+	return nil
+}
+
+// HCL validates the policy, and renders it into HCL.
+func (p Policy) HCL() (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	for i, rule := range p.Rules {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "path %s {\n", strconv.Quote(rule.Path))
+		fmt.Fprintf(&buf, "  capabilities = %s\n", hclList(rule.Capabilities))
+		writeHCLParameters(&buf, "allowed_parameters", rule.AllowedParameters)
+		writeHCLParameters(&buf, "denied_parameters", rule.DeniedParameters)
+		if len(rule.RequiredParameters) > 0 {
+			fmt.Fprintf(&buf, "  required_parameters = %s\n", hclList(rule.RequiredParameters))
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.String(), nil
+}
+
+func writeHCLParameters(buf *bytes.Buffer, name string, parameters map[string][]string) {
+	if len(parameters) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(parameters))
+	for key := range parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(buf, "  %s = {\n", name)
+	for _, key := range keys {
+		fmt.Fprintf(buf, "    %s = %s\n", strconv.Quote(key), hclList(parameters[key]))
+	}
+	buf.WriteString("  }\n")
+}
+
+func hclList(values []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i, value := range values {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.Quote(value))
+	}
+	buf.WriteString("]")
+	return buf.String()
+}
@@ -0,0 +1,86 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Policy_HCL(t *testing.T) {
+	var policy Policy
+	policy.Path("secret/app/*", CapabilityRead, CapabilityList)
+	policy.Path("transit/encrypt/app", CapabilityUpdate).
+		Allow("plaintext").
+		Allow("key_version", "1", "2").
+		Deny("context").
+		Require("plaintext")
+
+	hcl, err := policy.HCL()
+	require.NoError(t, err)
+	require.Equal(t, `path "secret/app/*" {
+  capabilities = ["read", "list"]
+}
+
+path "transit/encrypt/app" {
+  capabilities = ["update"]
+  allowed_parameters = {
+    "key_version" = ["1", "2"]
+    "plaintext" = []
+  }
+  denied_parameters = {
+    "context" = []
+  }
+  required_parameters = ["plaintext"]
+}
+`, hcl)
+}
+
+func Test_Policy_Validate(t *testing.T) {
+	try := func(policy Policy, exp string) {
+		err := policy.Validate()
+		if exp == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, exp)
+		}
+	}
+
+	try(Policy{}, "")
+	try(Policy{Rules: []*PolicyRule{
+		{Path: "secret/*", Capabilities: []string{"read", "deny"}},
+	}}, "")
+	try(Policy{Rules: []*PolicyRule{
+		{Capabilities: []string{"read"}},
+	}}, `policy rule has no path`)
+	try(Policy{Rules: []*PolicyRule{
+		{Path: "secret/*"},
+	}}, `policy rule for path "secret/*" grants no capabilities`)
+	try(Policy{Rules: []*PolicyRule{
+		{Path: "secret/*", Capabilities: []string{"read", "write"}},
+	}}, `policy rule for path "secret/*" has invalid capability "write"`)
+	try(Policy{Rules: []*PolicyRule{
+		{Path: "secret/*", Capabilities: []string{"read"}},
+		{Path: "secret/*", Capabilities: []string{"list"}},
+	}}, `policy has multiple rules for path "secret/*"`)
+}
+
+func Test_Client_SetPolicy_HCL(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	var policy Policy
+	policy.Path("secret/built/*", CapabilityRead).Allow("*")
+	hcl, err := policy.HCL()
+	require.NoError(t, err)
+
+	err = client.SetPolicy("built", hcl)
+	require.NoError(t, err)
+
+	rules, err := client.GetPolicy("built")
+	require.NoError(t, err)
+	require.Equal(t, hcl, rules)
+
+	err = client.DeletePolicy("built")
+	require.NoError(t, err)
+}
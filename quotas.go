@@ -0,0 +1,182 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// QuotaConfig is the configuration of the resource quotas of vault.
+// Requests to the RateLimitExemptPaths are never rate limited.
+//
+// More information about resource quotas can be found here:
+// https://www.vaultproject.io/docs/concepts/resource-quotas
+type QuotaConfig struct {
+	EnableRateLimitAuditLogging    bool     `json:"enable_rate_limit_audit_logging"`
+	EnableRateLimitResponseHeaders bool     `json:"enable_rate_limit_response_headers"`
+	RateLimitExemptPaths           []string `json:"rate_limit_exempt_paths"`
+}
+
+func (c *client) ConfigureQuotas(config QuotaConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling quota config data to JSON request body")
+	}
+	c.logRequest("quota-config", "/v1/sys/quotas/config", bs)
+
+	if err := c.post("/v1/sys/quotas/config", string(bs), nil); err != nil {
+		return errors.Wrap(err, "failed to configure quotas")
+	}
+	return nil
+}
+
+func (c *client) LookupQuotaConfig() (QuotaConfig, error) {
+	var wrapper struct {
+		Data QuotaConfig `json:"data"`
+	}
+	if err := c.get("/v1/sys/quotas/config", &wrapper); err != nil {
+		return QuotaConfig{}, errors.Wrap(err, "failed to read quota config")
+	}
+	return wrapper.Data, nil
+}
+
+// RateLimitQuotaOptions are used to create a rate limit quota, which
+// allows Rate requests per Interval to the Path, which is a mount or
+// a namespace, or all of vault if empty. A client which exceeds the
+// rate is blocked for the BlockInterval, if set. If Role is set, the
+// Path must be an auth mount, and only logins to the role are limited.
+type RateLimitQuotaOptions struct {
+	Path          string  `json:"path,omitempty"`
+	Rate          float64 `json:"rate"`
+	Interval      string  `json:"interval,omitempty"`
+	BlockInterval string  `json:"block_interval,omitempty"`
+	Role          string  `json:"role,omitempty"`
+}
+
+// A RateLimitQuota is a rate limit quota, whose Interval and
+// BlockInterval are in seconds.
+type RateLimitQuota struct {
+	Name          string  `json:"name"`
+	Type          string  `json:"type"`
+	Path          string  `json:"path"`
+	Rate          float64 `json:"rate"`
+	Interval      int     `json:"interval"`
+	BlockInterval int     `json:"block_interval"`
+	Role          string  `json:"role"`
+}
+
+// CreateRateLimitQuota creates or updates the rate limit quota
+// of the given name.
+func (c *client) CreateRateLimitQuota(name string, opts RateLimitQuotaOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling rate limit quota data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/sys/quotas/rate-limit/%s", name)
+	c.logRequest("rate-limit-quota-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating rate limit quota at %q", requestPath)
+	}
+	return nil
+}
+
+func (c *client) LookupRateLimitQuota(name string) (RateLimitQuota, error) {
+	var wrapper struct {
+		Data RateLimitQuota `json:"data"`
+	}
+	requestPath := fmt.Sprintf("/v1/sys/quotas/rate-limit/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return RateLimitQuota{}, errors.Wrapf(err, "failed to look up rate limit quota %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListRateLimitQuotas() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/sys/quotas/rate-limit"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list rate limit quotas at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteRateLimitQuota(name string) error {
+	requestPath := fmt.Sprintf("/v1/sys/quotas/rate-limit/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete rate limit quota %q", name)
+	}
+	return nil
+}
+
+// LeaseCountQuotaOptions are used to create a lease count quota, which
+// allows at most MaxLeases leases to be created under the Path, which
+// is a mount or a namespace. If Role is set, the Path must be an auth
+// mount, and only the leases of logins to the role are counted. Lease
+// count quotas require vault enterprise.
+type LeaseCountQuotaOptions struct {
+	Path      string `json:"path,omitempty"`
+	MaxLeases int    `json:"max_leases"`
+	Role      string `json:"role,omitempty"`
+}
+
+// A LeaseCountQuota is a lease count quota, whose Counter
+// is the number of leases currently counted against it.
+type LeaseCountQuota struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Path      string `json:"path"`
+	MaxLeases int    `json:"max_leases"`
+	Counter   int    `json:"counter"`
+	Role      string `json:"role"`
+}
+
+// CreateLeaseCountQuota creates or updates the lease count
+// quota of the given name.
+func (c *client) CreateLeaseCountQuota(name string, opts LeaseCountQuotaOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling lease count quota data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/sys/quotas/lease-count/%s", name)
+	c.logRequest("lease-count-quota-create", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating lease count quota at %q", requestPath)
+	}
+	return nil
+}
+
+func (c *client) LookupLeaseCountQuota(name string) (LeaseCountQuota, error) {
+	var wrapper struct {
+		Data LeaseCountQuota `json:"data"`
+	}
+	requestPath := fmt.Sprintf("/v1/sys/quotas/lease-count/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LeaseCountQuota{}, errors.Wrapf(err, "failed to look up lease count quota %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListLeaseCountQuotas() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/sys/quotas/lease-count"
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list lease count quotas at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteLeaseCountQuota(name string) error {
+	requestPath := fmt.Sprintf("/v1/sys/quotas/lease-count/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete lease count quota %q", name)
+	}
+	return nil
+}
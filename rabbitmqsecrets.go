@@ -0,0 +1,190 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A RabbitMQSecrets represents a rabbitmq secrets engine, which
+// generates RabbitMQ users.
+//
+// More information about the rabbitmq secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/rabbitmq/index.html
+type RabbitMQSecrets interface {
+	// Configuration
+	ConfigureConnection(config RabbitMQConnectionConfig) error
+
+	// Roles
+	CreateRole(opts RabbitMQRoleOptions) error
+	LookupRole(name string) (LookedUpRabbitMQRole, error)
+	DeleteRole(name string) error
+
+	// Credentials
+	Credentials(role string) (RabbitMQCredentials, error)
+}
+
+func (c *client) RabbitMQSecretsMount(mount string) RabbitMQSecrets {
+	return &rabbitMQSecrets{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type rabbitMQSecrets struct {
+	client *client
+	mount  string
+}
+
+// RabbitMQConnectionConfig is the configuration of how vault connects
+// to the management API of RabbitMQ, as an administrator. Unless
+// VerifyConnection is false, vault checks the connection works
+// before saving the configuration.
+type RabbitMQConnectionConfig struct {
+	ConnectionURI    string `json:"connection_uri"`
+	Username         string `json:"username"`
+	Password         string `json:"password"`
+	VerifyConnection bool   `json:"verify_connection"`
+}
+
+func (r *rabbitMQSecrets) ConfigureConnection(config RabbitMQConnectionConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// do not log the request, which contains the password
+	requestPath := r.mount + "/config/connection"
+	if err := r.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "configuring rabbitmq connection at %q", requestPath)
+	}
+
+	return nil
+}
+
+// A RabbitMQVhost is the permissions of a user in a vhost, as
+// regular expressions matching the names of resources.
+type RabbitMQVhost struct {
+	Configure string `json:"configure"`
+	Write     string `json:"write"`
+	Read      string `json:"read"`
+}
+
+// RabbitMQRoleOptions are used to define properties of a rabbitmq
+// role being created or updated. The Tags of the generated users are
+// e.g. "management" or "administrator", and the Vhosts map the name
+// of each vhost to the permissions of the users in it.
+type RabbitMQRoleOptions struct {
+	Name   string                   `json:"-"`
+	Tags   []string                 `json:"-"`
+	Vhosts map[string]RabbitMQVhost `json:"-"`
+}
+
+type rabbitMQRoleRequest struct {
+	Tags   string `json:"tags,omitempty"`
+	Vhosts string `json:"vhosts,omitempty"`
+}
+
+func (r *rabbitMQSecrets) CreateRole(opts RabbitMQRoleOptions) error {
+	request := rabbitMQRoleRequest{Tags: strings.Join(opts.Tags, ",")}
+
+	// vault expects the vhosts to be a JSON encoded string
+	if len(opts.Vhosts) > 0 {
+		vhosts, err := json.Marshal(opts.Vhosts)
+		if err != nil {
+			return errors.Wrap(err, "marshalling rabbitmq vhosts")
+		}
+		request.Vhosts = string(vhosts)
+	}
+
+	bs, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "marshalling rabbitmq role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/roles/%s", r.mount, opts.Name)
+	r.client.logRequest("rabbitmq-role-create", requestPath, bs)
+	if err := r.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating rabbitmq role at %q", requestPath)
+	}
+
+	return nil
+}
+
+// A LookedUpRabbitMQRole represents information returned from vault
+// after making a request for information about a particular rabbitmq
+// role.
+type LookedUpRabbitMQRole struct {
+	Tags   []string
+	Vhosts map[string]RabbitMQVhost
+}
+
+type lookedUpRabbitMQRoleWrapper struct {
+	Data struct {
+		Tags   string                   `json:"tags"`
+		Vhosts map[string]RabbitMQVhost `json:"vhosts"`
+	} `json:"data"`
+}
+
+func (r *rabbitMQSecrets) LookupRole(name string) (LookedUpRabbitMQRole, error) {
+	var wrapper lookedUpRabbitMQRoleWrapper
+	requestPath := fmt.Sprintf("%s/roles/%s", r.mount, name)
+	if err := r.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpRabbitMQRole{}, errors.Wrapf(err, "failed to look up rabbitmq role %q", name)
+	}
+
+	role := LookedUpRabbitMQRole{Vhosts: wrapper.Data.Vhosts}
+	if wrapper.Data.Tags != "" {
+		role.Tags = strings.Split(wrapper.Data.Tags, ",")
+	}
+
+	return role, nil
+}
+
+func (r *rabbitMQSecrets) DeleteRole(name string) error {
+	requestPath := fmt.Sprintf("%s/roles/%s", r.mount, name)
+	if err := r.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete rabbitmq role %q", name)
+	}
+	return nil
+}
+
+// RabbitMQCredentials are the credentials of a RabbitMQ user generated
+// by vault, along with their lease.
+type RabbitMQCredentials struct {
+	Username      string
+	Password      string
+	LeaseID       string
+	LeaseDuration int
+	Renewable     bool
+}
+
+type rabbitMQCredentialsWrapper struct {
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+	Data          struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"data"`
+}
+
+func (r *rabbitMQSecrets) Credentials(role string) (RabbitMQCredentials, error) {
+	var wrapper rabbitMQCredentialsWrapper
+	requestPath := fmt.Sprintf("%s/creds/%s", r.mount, role)
+	if err := r.client.get(requestPath, &wrapper); err != nil {
+		return RabbitMQCredentials{}, errors.Wrapf(err, "failed to generate rabbitmq credentials for role %q", role)
+	}
+
+	return RabbitMQCredentials{
+		Username:      wrapper.Data.Username,
+		Password:      wrapper.Data.Password,
+		LeaseID:       wrapper.LeaseID,
+		LeaseDuration: wrapper.LeaseDuration,
+		Renewable:     wrapper.Renewable,
+	}, nil
+}
@@ -0,0 +1,79 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rabbitMQSecrets_ConfigureConnection(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/rabbitmq/config/connection": "",
+	})
+
+	err := vault.RabbitMQSecretsMount("rabbitmq").ConfigureConnection(RabbitMQConnectionConfig{
+		ConnectionURI:    "http://rabbitmq:15672",
+		Username:         "admin",
+		Password:         "secret",
+		VerifyConnection: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"connection_uri":"http://rabbitmq:15672","username":"admin","password":"secret","verify_connection":true}`,
+		vault.requests[0].Body,
+	)
+}
+
+func Test_rabbitMQSecrets_Roles(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/rabbitmq/roles/app":   "",
+		"GET /v1/rabbitmq/roles/app":    `{"data":{"tags":"management,monitoring","vhosts":{"/":{"configure":".*","write":".*","read":".*"}}}}`,
+		"GET /v1/rabbitmq/roles/plain":  `{"data":{"tags":"","vhosts":{}}}`,
+		"DELETE /v1/rabbitmq/roles/app": "",
+	})
+	rabbitmq := vault.RabbitMQSecretsMount("rabbitmq")
+
+	// the tags are sent as a comma separated list, and the
+	// vhosts as a JSON encoded string
+	err := rabbitmq.CreateRole(RabbitMQRoleOptions{
+		Name:   "app",
+		Tags:   []string{"management", "monitoring"},
+		Vhosts: map[string]RabbitMQVhost{"/": {Configure: ".*", Write: ".*", Read: ".*"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"tags":"management,monitoring","vhosts":"{\"/\":{\"configure\":\".*\",\"write\":\".*\",\"read\":\".*\"}}"}`,
+		vault.requests[0].Body,
+	)
+
+	role, err := rabbitmq.LookupRole("app")
+	require.NoError(t, err)
+	require.Equal(t, LookedUpRabbitMQRole{
+		Tags:   []string{"management", "monitoring"},
+		Vhosts: map[string]RabbitMQVhost{"/": {Configure: ".*", Write: ".*", Read: ".*"}},
+	}, role)
+
+	role, err = rabbitmq.LookupRole("plain")
+	require.NoError(t, err)
+	require.Empty(t, role.Tags)
+
+	require.NoError(t, rabbitmq.DeleteRole("app"))
+}
+
+func Test_rabbitMQSecrets_Credentials(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"GET /v1/rabbitmq/creds/app": `{"lease_id":"rabbitmq/creds/app/abc","lease_duration":3600,"renewable":true,"data":{"username":"app-abc","password":"secret"}}`,
+	})
+
+	creds, err := vault.RabbitMQSecretsMount("rabbitmq").Credentials("app")
+	require.NoError(t, err)
+	require.Equal(t, RabbitMQCredentials{
+		Username:      "app-abc",
+		Password:      "secret",
+		LeaseID:       "rabbitmq/creds/app/abc",
+		LeaseDuration: 3600,
+		Renewable:     true,
+	}, creds)
+}
@@ -0,0 +1,159 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// RaftJoinOptions are used to configure how a node joins an existing
+// raft cluster through the leader at LeaderAPIAddr. The leader TLS
+// options are PEM encoded, and are needed only if the certificate of
+// the leader is not trusted by the node. If Retry is set, the node keeps
+// trying to join until it succeeds. A NonVoter node receives the data
+// of the cluster, but does not take part in elections.
+//
+// More information about integrated storage can be found here:
+// https://www.vaultproject.io/docs/concepts/integrated-storage
+type RaftJoinOptions struct {
+	LeaderAPIAddr    string `json:"leader_api_addr"`
+	LeaderCACert     string `json:"leader_ca_cert,omitempty"`
+	LeaderClientCert string `json:"leader_client_cert,omitempty"`
+	LeaderClientKey  string `json:"leader_client_key,omitempty"`
+	Retry            bool   `json:"retry,omitempty"`
+	NonVoter         bool   `json:"non_voter,omitempty"`
+}
+
+// RaftJoin makes the vault server of the Client join an existing raft
+// cluster. The server must not be initialized yet, and so the Client
+// may use any token, such as NewStaticToken(""). It returns whether the
+// server has joined the cluster, after which it must still be unsealed.
+func (c *client) RaftJoin(opts RaftJoinOptions) (bool, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// do not log the request, which contains the client key
+	var joined struct {
+		Joined bool `json:"joined"`
+	}
+	if err := c.post("/v1/sys/storage/raft/join", string(bs), &joined); err != nil {
+		return false, errors.Wrapf(err, "failed to join raft cluster at %q", opts.LeaderAPIAddr)
+	}
+	return joined.Joined, nil
+}
+
+// A RaftConfiguration is the configuration of a raft cluster, which is
+// made up of the Servers, as of the raft log entry at Index.
+type RaftConfiguration struct {
+	Servers []RaftServer `json:"servers"`
+	Index   int          `json:"index"`
+}
+
+// A RaftServer is a member of a raft cluster. The NodeID is the ID by
+// which the server is removed from the cluster, and the Address is its
+// cluster address.
+type RaftServer struct {
+	NodeID          string `json:"node_id"`
+	Address         string `json:"address"`
+	Leader          bool   `json:"leader"`
+	ProtocolVersion string `json:"protocol_version"`
+	Voter           bool   `json:"voter"`
+}
+
+func (c *client) RaftConfiguration() (RaftConfiguration, error) {
+	var wrapper struct {
+		Data struct {
+			Config RaftConfiguration `json:"config"`
+		} `json:"data"`
+	}
+	if err := c.get("/v1/sys/storage/raft/configuration", &wrapper); err != nil {
+		return RaftConfiguration{}, errors.Wrap(err, "failed to read raft configuration")
+	}
+	return wrapper.Data.Config, nil
+}
+
+// RaftRemovePeer removes the server with the given node ID from the
+// raft cluster, e.g. after the node has been destroyed.
+func (c *client) RaftRemovePeer(nodeID string) error {
+	bs, err := json.Marshal(struct {
+		ServerID string `json:"server_id"`
+	}{ServerID: nodeID})
+	if err != nil {
+		return err
+	}
+
+	if err := c.post("/v1/sys/storage/raft/remove-peer", string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to remove raft peer %q", nodeID)
+	}
+	return nil
+}
+
+// A RaftBootstrapChallenge is issued by the leader of a raft cluster to
+// a node joining the cluster. The Challenge is encrypted with the keyring
+// of the cluster, and so the node must be unsealed with the keys of the
+// cluster to answer it. The SealConfig is the seal configuration of the
+// cluster, in the format used by vault.
+type RaftBootstrapChallenge struct {
+	Challenge  string          `json:"challenge"`
+	SealConfig json.RawMessage `json:"seal_config"`
+}
+
+// RaftBootstrapChallenge requests a challenge from the leader of a raft
+// cluster for the node with the given node ID, which is the first step
+// of the node joining the cluster. Joining is normally done by RaftJoin,
+// which handles the challenge on behalf of the node.
+func (c *client) RaftBootstrapChallenge(nodeID string) (RaftBootstrapChallenge, error) {
+	bs, err := json.Marshal(struct {
+		ServerID string `json:"server_id"`
+	}{ServerID: nodeID})
+	if err != nil {
+		return RaftBootstrapChallenge{}, err
+	}
+
+	var challenge RaftBootstrapChallenge
+	if err := c.post("/v1/sys/storage/raft/bootstrap/challenge", string(bs), &challenge); err != nil {
+		return RaftBootstrapChallenge{}, errors.Wrapf(err, "failed to request raft bootstrap challenge for %q", nodeID)
+	}
+	return challenge, nil
+}
+
+// RaftBootstrapAnswerOptions are the answer of the node with ServerID to
+// a RaftBootstrapChallenge. The Answer is the decrypted challenge, encoded
+// in base64, and the ClusterAddr is the cluster address of the node.
+type RaftBootstrapAnswerOptions struct {
+	ServerID    string `json:"server_id"`
+	Answer      string `json:"answer"`
+	ClusterAddr string `json:"cluster_addr"`
+	NonVoter    bool   `json:"non_voter,omitempty"`
+}
+
+// A RaftBootstrapAnswer is returned by the leader of a raft cluster once
+// a node has answered its challenge. The Peers are the members of the
+// cluster, and the TLSKeyring is used by the node to communicate with
+// them, in the format used by vault.
+type RaftBootstrapAnswer struct {
+	Peers []struct {
+		ID      string `json:"id"`
+		Address string `json:"address"`
+	} `json:"peers"`
+	TLSKeyring       json.RawMessage `json:"tls_keyring"`
+	AutoloadedUnseal bool            `json:"autoloaded_unseal"`
+}
+
+func (c *client) RaftBootstrapAnswer(opts RaftBootstrapAnswerOptions) (RaftBootstrapAnswer, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return RaftBootstrapAnswer{}, err
+	}
+	// do not log the request, which contains the answer to the challenge
+
+	var answer RaftBootstrapAnswer
+	if err := c.post("/v1/sys/storage/raft/bootstrap/answer", string(bs), &answer); err != nil {
+		return RaftBootstrapAnswer{}, errors.Wrapf(err, "failed to answer raft bootstrap challenge for %q", opts.ServerID)
+	}
+	return answer, nil
+}
@@ -0,0 +1,103 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RawRequestOptions are the optional parts of a request made with
+// RawRequest.
+type RawRequestOptions struct {
+	// Params are the query parameters of the request.
+	Params url.Values
+
+	// Header are headers which are sent with the request, in addition
+	// to the token and the headers configured for every request.
+	Header http.Header
+
+	// Body is the body of the request. A Body which is a *bytes.Buffer,
+	// *bytes.Reader, or *strings.Reader can be sent again when the
+	// request is retried or made to the next server, while any other
+	// Body is sent only once.
+	Body io.Reader
+}
+
+// RawRequest makes a request with the given method to the path of
+// vault, including the /v1/ prefix, and returns the response as is,
+// whatever its status code. The request is made to the next server
+// only if it fails without a response. The caller must close the body
+// of the response. As the HTTPTimeout includes reading the body, use
+// WithTimeout(0) and WithContext for requests which stream responses.
+func (c *client) RawRequest(method, path string, opts RawRequestOptions) (*http.Response, error) {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if len(opts.Params) > 0 {
+		path += "?" + opts.Params.Encode()
+	}
+
+	servers := c.servers()
+	request, err := http.NewRequestWithContext(c.context(), method, servers[0]+path, opts.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build %s request to %q", method, path)
+	}
+
+	token, err := c.token()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get token for request")
+	}
+
+	for key, values := range opts.Header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+	request.Header.Set(headerVaultToken, token)
+	c.setHeaders(request)
+
+	var last error
+	for i, address := range servers {
+		if i > 0 {
+			// the body was consumed by the previous attempt
+			if request.Body != nil && request.GetBody == nil {
+				break
+			}
+			if err := c.retarget(request, address+path); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := c.do(request)
+		if err == nil {
+			return response, nil
+		} else if c.final(err) {
+			return nil, errors.Wrapf(err, "failed to execute %s request to %q", method, request.URL)
+		}
+		c.opts.Logger.Warnf("%s request failed: %v", method, err)
+		last = err
+	}
+	return nil, allFailed(method, servers, last)
+}
+
+// retarget points the request to rawURL, resetting its body.
+func (c *client) retarget(request *http.Request, rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse url %q", rawURL)
+	}
+	request.URL = target
+	request.Host = target.Host
+
+	if request.GetBody != nil {
+		if request.Body, err = request.GetBody(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
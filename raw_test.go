@@ -0,0 +1,54 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_client_RawRequest(t *testing.T) {
+	var bodies []string
+	opts := devOpts()
+	opts.Servers = []string{"http://vault-1:8200", "http://vault-2:8200"}
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(request.Body)
+		bodies = append(bodies, string(body))
+		if request.URL.Host == "vault-1:8200" {
+			return nil, errors.New("connection refused")
+		}
+
+		require.Equal(t, "/v1/plugin/raw", request.URL.Path)
+		require.Equal(t, "fast", request.URL.Query().Get("mode"))
+		require.Equal(t, "text/csv", request.Header.Get("Content-Type"))
+		require.Equal(t, "token", request.Header.Get("X-Vault-Token"))
+		return &http.Response{
+			StatusCode: http.StatusTeapot,
+			Body:       ioutil.NopCloser(strings.NewReader("a,b,c")),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	response, err := client.RawRequest(http.MethodPut, "v1/plugin/raw", RawRequestOptions{
+		Params: url.Values{"mode": []string{"fast"}},
+		Header: http.Header{"Content-Type": []string{"text/csv"}},
+		Body:   strings.NewReader("1,2,3"),
+	})
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	// the body is sent again to the next server
+	require.Equal(t, []string{"1,2,3", "1,2,3"}, bodies)
+	require.Equal(t, http.StatusTeapot, response.StatusCode)
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, "a,b,c", string(body))
+}
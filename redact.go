@@ -0,0 +1,154 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveFields are the fields of request bodies whose values are
+// never logged, in addition to fields ending in one of sensitiveSuffixes.
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"passphrase":    true,
+	"secret":        true,
+	"secret_id":     true,
+	"token":         true,
+	"client_token":  true,
+	"private_key":   true,
+	"client_key":    true,
+	"access_key":    true,
+	"secret_key":    true,
+	"credentials":   true,
+	"bindpass":      true,
+	"otp":           true,
+	"key":           true,
+	"answer":        true,
+	"plaintext":     true,
+	"session_token": true,
+}
+
+var sensitiveSuffixes = []string{
+	"_password",
+	"_secret",
+	"_token",
+	"_key",
+	"_pass",
+	"_pem",
+}
+
+// sensitivePaths are patterns of the paths of requests whose bodies
+// are never logged, where each segment of a pattern matches the same
+// segment of a path, "*" matches any one segment, and a trailing "**"
+// matches any remaining segments.
+var sensitivePaths = []string{
+	"auth/*/login/**",
+	"auth/token/create/**",
+	"auth/token/create-orphan/**",
+	"secret/**",
+	"cubbyhole/**",
+	"*/data/**",
+	"*/encrypt/**",
+	"*/decrypt/**",
+	"*/rewrap/**",
+	"*/datakey/**",
+	"*/hmac/**",
+	"*/creds/**",
+	"*/static-creds/**",
+	"sys/wrapping/**",
+}
+
+// logRequest logs the JSON body of a request to path at the debug level,
+// with the values of sensitive fields redacted. The body of a request to
+// a sensitive path is not logged at all. Requests whose body consists
+// mostly of secrets should not be logged by the caller in the first place.
+func (c *client) logRequest(kind, path string, body []byte) {
+	if sensitivePath(path) {
+		c.opts.Logger.Debugf("%s request: %s", kind, redacted)
+		return
+	}
+	c.opts.Logger.Debugf("%s request: %s", kind, c.redact(body))
+}
+
+// sensitivePath returns whether path, with or without its /v1/ prefix
+// and query, matches one of the sensitivePaths.
+func sensitivePath(path string) bool {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	path = strings.TrimPrefix(strings.Trim(path, "/"), "v1/")
+	segments := strings.Split(path, "/")
+
+	for _, pattern := range sensitivePaths {
+		if matchSegments(strings.Split(pattern, "/"), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for i, p := range pattern {
+		if p == "**" {
+			return true
+		}
+		if i >= len(segments) || (p != "*" && p != segments[i]) {
+			return false
+		}
+	}
+	return len(pattern) == len(segments)
+}
+
+// redact returns body with the values of sensitive fields, and fields
+// for which the Redact option of the client returns true, replaced.
+func (c *client) redact(body []byte) string {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		// there is no telling which parts are sensitive
+		return redacted
+	}
+
+	bs, err := json.Marshal(c.redactValue(data))
+	if err != nil {
+		return redacted
+	}
+	return string(bs)
+}
+
+func (c *client) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, nested := range v {
+			if c.sensitive(field) {
+				v[field] = redacted
+			} else {
+				v[field] = c.redactValue(nested)
+			}
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = c.redactValue(nested)
+		}
+	}
+	return value
+}
+
+func (c *client) sensitive(field string) bool {
+	if c.opts.Redact != nil && c.opts.Redact(field) {
+		return true
+	}
+
+	field = strings.ToLower(field)
+	if sensitiveFields[field] {
+		return true
+	}
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(field, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_client_redact(t *testing.T) {
+	c := &client{opts: ClientOptions{
+		Redact: func(field string) bool {
+			return field == "ssn"
+		},
+	}}
+
+	body := `{"name":"alice","password":"hunter2","db_password":"abc","meta":{"ssn":"123","team":"a"},"users":[{"Token":"t1"}]}`
+	require.Equal(t,
+		`{"db_password":"REDACTED","meta":{"ssn":"REDACTED","team":"a"},"name":"alice","password":"REDACTED","users":[{"Token":"REDACTED"}]}`,
+		c.redact([]byte(body)),
+	)
+
+	// bodies which are not JSON are redacted entirely
+	require.Equal(t, "REDACTED", c.redact([]byte("password=hunter2")))
+
+	body = `{"api_key":"a","db_pass":"b","private_key_pem":"c","key_type":"rsa"}`
+	require.Equal(t,
+		`{"api_key":"REDACTED","db_pass":"REDACTED","key_type":"rsa","private_key_pem":"REDACTED"}`,
+		c.redact([]byte(body)),
+	)
+}
+
+func Test_sensitivePath(t *testing.T) {
+	try := func(path string, exp bool) {
+		require.Equal(t, exp, sensitivePath(path), path)
+	}
+
+	try("/v1/auth/userpass/login/alice", true)
+	try("/v1/auth/approle/login", true)
+	try("/v1/auth/token/create", true)
+	try("/v1/secret/foo", true)
+	try("/v1/kv/data/team/db?version=2", true)
+	try("/v1/transit/encrypt/orders", true)
+	try("/v1/transit/decrypt/orders", true)
+	try("/v1/database/creds/readonly", true)
+	try("/v1/sys/wrapping/wrap", true)
+	try("cubbyhole/foo", true)
+
+	try("/v1/auth/approle/role/web", false)
+	try("/v1/pki/issue/web", false)
+	try("/v1/sys/mounts/kv", false)
+	try("/v1/identity/entity", false)
+}
+
+func Test_client_logRequest(t *testing.T) {
+	var buf bytes.Buffer
+	c := &client{opts: ClientOptions{
+		Logger: NewStdLogger(log.New(&buf, "", 0)),
+	}}
+
+	c.logRequest("kv-put", "/v1/kv/data/foo", []byte(`{"data":{"api_key":"abc123"}}`))
+	c.logRequest("role-create", "/v1/auth/approle/role/web", []byte(`{"policies":["web"],"secret_id":"abc123"}`))
+
+	require.Equal(t, "[DEBUG] kv-put request: REDACTED\n"+
+		`[DEBUG] role-create request: {"policies":["web"],"secret_id":"REDACTED"}`+"\n", buf.String())
+}
@@ -0,0 +1,274 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	rekeyPrefix            = "/v1/sys/rekey"
+	rekeyRecoveryKeyPrefix = "/v1/sys/rekey-recovery-key"
+)
+
+// A RekeyStatus describes the progress of a rekey. The Nonce identifies
+// the rekey, and must be provided with each key share. Progress of the
+// Required key shares of the current keys have been provided so far.
+// Threshold and Shares describe the new keys.
+//
+// More information about rekeying can be found here:
+// https://www.vaultproject.io/docs/concepts/rekeying.html
+type RekeyStatus struct {
+	Nonce                string   `json:"nonce"`
+	Started              bool     `json:"started"`
+	Threshold            int      `json:"t"`
+	Shares               int      `json:"n"`
+	Progress             int      `json:"progress"`
+	Required             int      `json:"required"`
+	PGPFingerprints      []string `json:"pgp_fingerprints"`
+	Backup               bool     `json:"backup"`
+	VerificationRequired bool     `json:"verification_required"`
+}
+
+// RekeyOptions are used to configure the new keys of a rekey. If
+// PGPKeys are set, each new key share is encrypted with the
+// corresponding PGP public key, and if Backup is set, the encrypted
+// key shares are also stored in vault until deleted. If
+// RequireVerification is set, the new keys take effect only once a
+// threshold of them have been provided to verify the rekey.
+type RekeyOptions struct {
+	SecretShares        int      `json:"secret_shares"`
+	SecretThreshold     int      `json:"secret_threshold"`
+	PGPKeys             []string `json:"pgp_keys,omitempty"`
+	Backup              bool     `json:"backup,omitempty"`
+	RequireVerification bool     `json:"require_verification,omitempty"`
+}
+
+// A RekeyUpdate is the result of providing a key share to a rekey. Once
+// enough key shares have been provided the rekey is Complete, and the
+// new Keys are returned. If verification is required, the new keys must
+// then be provided with the VerificationNonce to RekeyVerify.
+type RekeyUpdate struct {
+	Nonce                string   `json:"nonce"`
+	Complete             bool     `json:"complete"`
+	Progress             int      `json:"progress"`
+	Required             int      `json:"required"`
+	Keys                 []string `json:"keys"`
+	KeysBase64           []string `json:"keys_base64"`
+	PGPFingerprints      []string `json:"pgp_fingerprints"`
+	Backup               bool     `json:"backup"`
+	VerificationRequired bool     `json:"verification_required"`
+	VerificationNonce    string   `json:"verification_nonce"`
+}
+
+// A RekeyBackup is the backup of the PGP encrypted key shares of a
+// rekey, keyed by the fingerprint of the PGP key of each share.
+type RekeyBackup struct {
+	Nonce string              `json:"nonce"`
+	Keys  map[string][]string `json:"keys"`
+}
+
+// A RekeyVerificationStatus describes the progress of the verification
+// of a rekey. Progress of the Threshold new key shares have been
+// provided so far.
+type RekeyVerificationStatus struct {
+	Nonce     string `json:"nonce"`
+	Started   bool   `json:"started"`
+	Threshold int    `json:"t"`
+	Shares    int    `json:"n"`
+	Progress  int    `json:"progress"`
+}
+
+// A RekeyVerification is the result of providing a new key share to
+// the verification of a rekey, which is Complete once enough new key
+// shares have been provided.
+type RekeyVerification struct {
+	Nonce    string `json:"nonce"`
+	Complete bool   `json:"complete"`
+}
+
+func (c *client) RekeyStatus() (RekeyStatus, error) {
+	return c.rekeyStatus(rekeyPrefix)
+}
+
+// RekeyInit starts a rekey, which generates new key shares
+// once enough of the current key shares are provided.
+func (c *client) RekeyInit(opts RekeyOptions) (RekeyStatus, error) {
+	return c.rekeyInit(rekeyPrefix, opts)
+}
+
+func (c *client) RekeyCancel() error {
+	return c.rekeyCancel(rekeyPrefix)
+}
+
+// RekeyUpdate provides one of the current key shares to the
+// rekey identified by nonce.
+func (c *client) RekeyUpdate(key, nonce string) (RekeyUpdate, error) {
+	return c.rekeyUpdate(rekeyPrefix, key, nonce)
+}
+
+func (c *client) RekeyBackup() (RekeyBackup, error) {
+	return c.rekeyBackup(rekeyPrefix)
+}
+
+func (c *client) RekeyDeleteBackup() error {
+	return c.rekeyDeleteBackup(rekeyPrefix)
+}
+
+func (c *client) RekeyVerificationStatus() (RekeyVerificationStatus, error) {
+	return c.rekeyVerificationStatus(rekeyPrefix)
+}
+
+// RekeyVerify provides one of the new key shares to the
+// verification of the rekey identified by nonce.
+func (c *client) RekeyVerify(key, nonce string) (RekeyVerification, error) {
+	return c.rekeyVerify(rekeyPrefix, key, nonce)
+}
+
+// RekeyCancelVerification restarts the verification of a rekey,
+// discarding the new key shares provided so far.
+func (c *client) RekeyCancelVerification() error {
+	return c.rekeyCancelVerification(rekeyPrefix)
+}
+
+// RekeyRecoveryKeyStatus is like RekeyStatus, but for a rekey of the
+// recovery keys of a vault server using auto unseal.
+func (c *client) RekeyRecoveryKeyStatus() (RekeyStatus, error) {
+	return c.rekeyStatus(rekeyRecoveryKeyPrefix)
+}
+
+// RekeyRecoveryKeyInit starts a rekey of the recovery keys, which
+// generates new recovery key shares once enough of the current recovery
+// key shares are provided. Recovery keys require verification, so
+// opts.RequireVerification must be set.
+func (c *client) RekeyRecoveryKeyInit(opts RekeyOptions) (RekeyStatus, error) {
+	return c.rekeyInit(rekeyRecoveryKeyPrefix, opts)
+}
+
+func (c *client) RekeyRecoveryKeyCancel() error {
+	return c.rekeyCancel(rekeyRecoveryKeyPrefix)
+}
+
+// RekeyRecoveryKeyUpdate provides one of the current recovery key
+// shares to the rekey identified by nonce.
+func (c *client) RekeyRecoveryKeyUpdate(key, nonce string) (RekeyUpdate, error) {
+	return c.rekeyUpdate(rekeyRecoveryKeyPrefix, key, nonce)
+}
+
+func (c *client) RekeyRecoveryKeyBackup() (RekeyBackup, error) {
+	return c.rekeyBackup(rekeyRecoveryKeyPrefix)
+}
+
+func (c *client) RekeyRecoveryKeyDeleteBackup() error {
+	return c.rekeyDeleteBackup(rekeyRecoveryKeyPrefix)
+}
+
+func (c *client) RekeyRecoveryKeyVerificationStatus() (RekeyVerificationStatus, error) {
+	return c.rekeyVerificationStatus(rekeyRecoveryKeyPrefix)
+}
+
+// RekeyRecoveryKeyVerify provides one of the new recovery key shares
+// to the verification of the rekey identified by nonce.
+func (c *client) RekeyRecoveryKeyVerify(key, nonce string) (RekeyVerification, error) {
+	return c.rekeyVerify(rekeyRecoveryKeyPrefix, key, nonce)
+}
+
+func (c *client) RekeyRecoveryKeyCancelVerification() error {
+	return c.rekeyCancelVerification(rekeyRecoveryKeyPrefix)
+}
+
+func (c *client) rekeyStatus(prefix string) (RekeyStatus, error) {
+	var status RekeyStatus
+	if err := c.get(prefix+"/init", &status); err != nil {
+		return RekeyStatus{}, errors.Wrap(err, "failed to read rekey status")
+	}
+	return status, nil
+}
+
+func (c *client) rekeyInit(prefix string, opts RekeyOptions) (RekeyStatus, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return RekeyStatus{}, err
+	}
+	c.logRequest("rekey-init", prefix+"/init", bs)
+
+	var status RekeyStatus
+	if err := c.post(prefix+"/init", string(bs), &status); err != nil {
+		return RekeyStatus{}, errors.Wrap(err, "failed to start rekey")
+	}
+	return status, nil
+}
+
+func (c *client) rekeyCancel(prefix string) error {
+	if err := c.delete(prefix + "/init"); err != nil {
+		return errors.Wrap(err, "failed to cancel rekey")
+	}
+	return nil
+}
+
+type keyShare struct {
+	Key   string `json:"key"`
+	Nonce string `json:"nonce"`
+}
+
+func (c *client) rekeyUpdate(prefix, key, nonce string) (RekeyUpdate, error) {
+	bs, err := json.Marshal(keyShare{Key: key, Nonce: nonce})
+	if err != nil {
+		return RekeyUpdate{}, err
+	}
+
+	// do not log the request, which contains the key share
+	var update RekeyUpdate
+	if err := c.post(prefix+"/update", string(bs), &update); err != nil {
+		return RekeyUpdate{}, errors.Wrapf(err, "failed to update rekey %q", nonce)
+	}
+	return update, nil
+}
+
+func (c *client) rekeyBackup(prefix string) (RekeyBackup, error) {
+	var wrapper struct {
+		Data RekeyBackup `json:"data"`
+	}
+	if err := c.get(prefix+"/backup", &wrapper); err != nil {
+		return RekeyBackup{}, errors.Wrap(err, "failed to read rekey backup")
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) rekeyDeleteBackup(prefix string) error {
+	if err := c.delete(prefix + "/backup"); err != nil {
+		return errors.Wrap(err, "failed to delete rekey backup")
+	}
+	return nil
+}
+
+func (c *client) rekeyVerificationStatus(prefix string) (RekeyVerificationStatus, error) {
+	var status RekeyVerificationStatus
+	if err := c.get(prefix+"/verify", &status); err != nil {
+		return RekeyVerificationStatus{}, errors.Wrap(err, "failed to read rekey verification status")
+	}
+	return status, nil
+}
+
+func (c *client) rekeyVerify(prefix, key, nonce string) (RekeyVerification, error) {
+	bs, err := json.Marshal(keyShare{Key: key, Nonce: nonce})
+	if err != nil {
+		return RekeyVerification{}, err
+	}
+
+	// do not log the request, which contains the key share
+	var verification RekeyVerification
+	if err := c.post(prefix+"/verify", string(bs), &verification); err != nil {
+		return RekeyVerification{}, errors.Wrapf(err, "failed to verify rekey %q", nonce)
+	}
+	return verification, nil
+}
+
+func (c *client) rekeyCancelVerification(prefix string) error {
+	if err := c.delete(prefix + "/verify"); err != nil {
+		return errors.Wrap(err, "failed to cancel rekey verification")
+	}
+	return nil
+}
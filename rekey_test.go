@@ -0,0 +1,36 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_Rekey(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	status, err := client.RekeyStatus()
+	require.NoError(t, err)
+	require.False(t, status.Started)
+
+	status, err = client.RekeyInit(RekeyOptions{
+		SecretShares:    3,
+		SecretThreshold: 2,
+	})
+	require.NoError(t, err)
+	require.True(t, status.Started)
+	require.NotEmpty(t, status.Nonce)
+	require.Equal(t, 2, status.Threshold)
+	require.Equal(t, 3, status.Shares)
+	require.Equal(t, 0, status.Progress)
+
+	// the dev server has a single unseal key, which is unknown here
+	err = client.RekeyCancel()
+	require.NoError(t, err)
+
+	status, err = client.RekeyStatus()
+	require.NoError(t, err)
+	require.False(t, status.Started)
+}
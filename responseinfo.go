@@ -0,0 +1,125 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	headerRetryAfter         = "Retry-After"
+	headerRateLimitLimit     = "X-Ratelimit-Limit"
+	headerRateLimitRemaining = "X-Ratelimit-Remaining"
+	headerRateLimitReset     = "X-Ratelimit-Reset"
+)
+
+// ResponseInfo describes the response to a request made by a Client,
+// beyond the result returned by the method of the Client.
+type ResponseInfo struct {
+	// RateLimit is the rate limit quota which applies to the
+	// request, if vault is configured to report it.
+	RateLimit *RateLimit
+
+	// RequestID is the ID which vault assigned to the request, which
+	// identifies the request in the audit log of vault.
+	RequestID string
+
+	// Warnings are the warnings of vault about the request, such as
+	// the use of deprecated parameters.
+	Warnings []string
+
+	// LeaseID, LeaseDuration, and Renewable describe the lease of the
+	// secret of the response, if any. The LeaseDuration is in seconds.
+	LeaseID       string
+	LeaseDuration int
+	Renewable     bool
+}
+
+// responseEnvelope holds the fields common to the JSON bodies of the
+// responses of vault, beyond their data.
+type responseEnvelope struct {
+	RequestID     string    `json:"request_id"`
+	LeaseID       string    `json:"lease_id"`
+	LeaseDuration int       `json:"lease_duration"`
+	Renewable     bool      `json:"renewable"`
+	Warnings      []string  `json:"warnings"`
+	WrapInfo      *WrapInfo `json:"wrap_info"`
+}
+
+// A RateLimit describes the rate limit quota of vault which applies to
+// a request, as reported by vault if its quota config enables rate limit
+// response headers. Remaining of the Limit requests may be made until
+// the quota resets after Reset. Once the quota is exhausted, vault asks
+// clients to wait RetryAfter before making another request.
+type RateLimit struct {
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// record stores the info of the response into the ResponseInfo
+// of the client, if set by WithResponseInfo.
+func (c *client) record(response *http.Response) {
+	if c.responseInfo == nil {
+		return
+	}
+	*c.responseInfo = ResponseInfo{
+		RateLimit: rateLimit(response.Header),
+	}
+}
+
+// recordEnvelope stores the fields of the body of the response into
+// the ResponseInfo of the client, if set by WithResponseInfo.
+func (c *client) recordEnvelope(envelope responseEnvelope) {
+	if c.responseInfo == nil {
+		return
+	}
+	c.responseInfo.RequestID = envelope.RequestID
+	c.responseInfo.Warnings = envelope.Warnings
+	c.responseInfo.LeaseID = envelope.LeaseID
+	c.responseInfo.LeaseDuration = envelope.LeaseDuration
+	c.responseInfo.Renewable = envelope.Renewable
+}
+
+func rateLimit(header http.Header) *RateLimit {
+	limit, err := strconv.Atoi(header.Get(headerRateLimitLimit))
+	if err != nil {
+		return nil
+	}
+
+	remaining, _ := strconv.Atoi(header.Get(headerRateLimitRemaining))
+	reset, _ := strconv.Atoi(header.Get(headerRateLimitReset))
+	after, _ := retryAfter(header)
+
+	return &RateLimit{
+		Limit:      limit,
+		Remaining:  remaining,
+		Reset:      time.Duration(reset) * time.Second,
+		RetryAfter: after,
+	}
+}
+
+// retryAfter parses the Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get(headerRetryAfter)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if until := time.Until(date); until > 0 {
+			return until, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,84 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rateLimit(t *testing.T) {
+	header := make(http.Header)
+	require.Nil(t, rateLimit(header))
+
+	header.Set("X-Ratelimit-Limit", "20")
+	header.Set("X-Ratelimit-Remaining", "0")
+	header.Set("X-Ratelimit-Reset", "7")
+	header.Set("Retry-After", "7")
+	require.Equal(t, &RateLimit{
+		Limit:      20,
+		Remaining:  0,
+		Reset:      7 * time.Second,
+		RetryAfter: 7 * time.Second,
+	}, rateLimit(header))
+}
+
+func Test_retryAfter(t *testing.T) {
+	try := func(value string, expOK bool, min, max time.Duration) {
+		header := make(http.Header)
+		header.Set("Retry-After", value)
+		after, ok := retryAfter(header)
+		require.Equal(t, expOK, ok, "value %q", value)
+		require.True(t, after >= min && after <= max, "value %q, after %v", value, after)
+	}
+
+	try("", false, 0, 0)
+	try("garbage", false, 0, 0)
+	try("3", true, 3*time.Second, 3*time.Second)
+	try(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), true, 58*time.Second, time.Minute)
+	try("Wed, 21 Oct 2015 07:28:00 GMT", true, 0, 0)
+}
+
+func Test_client_WithResponseInfo(t *testing.T) {
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		header := make(http.Header)
+		header.Set("X-Ratelimit-Limit", "20")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body: ioutil.NopCloser(strings.NewReader(`{
+				"request_id": "d09a6f9b-8e5c-4d3b-8a6f-0c2f1a6c5e3d",
+				"lease_id": "secret/foo/abc",
+				"lease_duration": 3600,
+				"renewable": true,
+				"warnings": ["deprecated parameter"],
+				"data": {"value": "abc123"}
+			}`)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	var info ResponseInfo
+	_, err = client.WithResponseInfo(&info).Get("/foo")
+	require.NoError(t, err)
+	require.Equal(t, "d09a6f9b-8e5c-4d3b-8a6f-0c2f1a6c5e3d", info.RequestID)
+	require.Equal(t, "secret/foo/abc", info.LeaseID)
+	require.Equal(t, 3600, info.LeaseDuration)
+	require.True(t, info.Renewable)
+	require.Equal(t, []string{"deprecated parameter"}, info.Warnings)
+	require.Equal(t, 20, info.RateLimit.Limit)
+
+	// the response of a put is read only for its info
+	info = ResponseInfo{}
+	err = client.WithResponseInfo(&info).Put("/foo", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, []string{"deprecated parameter"}, info.Warnings)
+}
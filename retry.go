@@ -0,0 +1,200 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shoenig/toolkit"
+	"github.com/shoenig/vaultapi/internal/watch"
+)
+
+const (
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 10 * time.Second
+	defaultRetryJitter     = 0.1
+)
+
+// RetryOptions are used to configure how a Client retries requests
+// which fail in a way which is likely to be temporary, such as a 502
+// from a load balancer in front of vault. Each server is retried before
+// the request moves on to the next server.
+//
+// Reads (GET and LIST requests) are retried upon any of the StatusCodes,
+// and upon any failure to execute the request. Writes are retried only
+// if vault cannot have acted upon them, which is upon a 429 (if one of
+// the StatusCodes), or upon a failure to connect to vault. If vault
+// responds with a Retry-After header, as it does when a rate limit quota
+// is exhausted, the retry waits for that long instead of the backoff.
+type RetryOptions struct {
+	// MaxRetries configures how many times a failed request is
+	// retried. By default, this value is 0, and requests are not
+	// retried.
+	MaxRetries int
+
+	// MinBackoff configures how long to wait before the first retry,
+	// which doubles with each retry up to MaxBackoff. By default, these
+	// values are 500 milliseconds and 10 seconds.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of time by which each backoff is randomly
+	// shortened, so that many clients failing at once do not all retry
+	// at once. By default, this value is 0.1.
+	Jitter float64
+
+	// NoJitter disables the Jitter, so that each backoff is exactly
+	// as configured.
+	NoJitter bool
+
+	// StatusCodes are the response status codes upon which a request
+	// is retried. By default, these are 429, 500, 502, 503, and 504.
+	StatusCodes []int
+}
+
+func (r RetryOptions) withDefaults() RetryOptions {
+	if r.MinBackoff <= 0 {
+		r.MinBackoff = defaultRetryMinBackoff
+	}
+
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = defaultRetryMaxBackoff
+	}
+
+	if r.MaxBackoff < r.MinBackoff {
+		r.MaxBackoff = r.MinBackoff
+	}
+
+	switch {
+	case r.NoJitter:
+		r.Jitter = 0
+	case r.Jitter <= 0 || r.Jitter >= 1:
+		r.Jitter = defaultRetryJitter
+	}
+
+	if len(r.StatusCodes) == 0 {
+		r.StatusCodes = []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		}
+	}
+
+	return r
+}
+
+// backoff computes how long to wait before the given retry, where
+// the first retry is 0, shortened by up to the jitter fraction
+func (r RetryOptions) backoff(retry int) time.Duration {
+	delay := r.MinBackoff
+	for i := 0; i < retry && delay < r.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.MaxBackoff {
+		delay = r.MaxBackoff
+	}
+	return time.Duration(float64(delay) - float64(delay)*r.Jitter*rand.Float64())
+}
+
+// do executes the request, retrying it as configured by the
+// RetryOptions of the client.
+func (c *client) do(request *http.Request) (*http.Response, error) {
+	if c.indexes != nil {
+		c.indexes.apply(request)
+	}
+
+	start := time.Now()
+	response, retries, err := c.attempt(request)
+	if err == nil && c.relogin(request, response) {
+		response, retries, err = c.attempt(request)
+	}
+	c.observe(request, response, retries, time.Since(start), err)
+	return response, err
+}
+
+// attempt executes the request until it succeeds or may not be retried,
+// returning the final response and the number of retries made.
+func (c *client) attempt(request *http.Request) (*http.Response, int, error) {
+	for retry := 0; ; retry++ {
+		c.opts.Logger.Debugf("%s request to %q", request.Method, request.URL)
+		start := time.Now()
+		response, err := c.doer.Do(request)
+		if retry >= c.opts.Retry.MaxRetries || !c.retryable(request, response, err) {
+			if err == nil {
+				c.opts.Logger.Debugf("%s response from %q: %d in %v", request.Method, request.URL, response.StatusCode, time.Since(start))
+				c.record(response)
+				if c.indexes != nil {
+					c.indexes.capture(response)
+				}
+			}
+			return response, retry, err
+		}
+
+		delay := c.opts.Retry.backoff(retry)
+
+		if err == nil {
+			// vault asks rate limited clients to wait until the
+			// rate limit quota resets
+			if after, ok := retryAfter(response.Header); ok {
+				delay = after
+			}
+			toolkit.Drain(response.Body)
+			err = errors.Errorf("bad status code: %d", response.StatusCode)
+		}
+
+		c.opts.Logger.Warnf("retrying %s request to %q in %v: %v", request.Method, request.URL, delay, err)
+
+		if !c.sleep(delay) {
+			return nil, retry, c.context().Err()
+		}
+
+		if request.GetBody != nil {
+			if request.Body, err = request.GetBody(); err != nil {
+				return nil, retry, err
+			}
+		}
+	}
+}
+
+// retryable returns whether the request may be retried after it
+// failed with the response or err.
+func (c *client) retryable(request *http.Request, response *http.Response, err error) bool {
+	read := request.Method == http.MethodGet || request.Method == methodLIST
+
+	if err != nil {
+		if c.context().Err() != nil {
+			return false
+		}
+		return read || dialFailed(err)
+	}
+
+	for _, code := range c.opts.Retry.StatusCodes {
+		if response.StatusCode == code {
+			return read || code == http.StatusTooManyRequests
+		}
+	}
+	return false
+}
+
+// dialFailed returns whether err is a failure to connect to the
+// server, in which case the request was never sent.
+func dialFailed(err error) bool {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+	opErr, ok := err.(*net.OpError)
+	return ok && opErr.Op == "dial"
+}
+
+// sleep waits for duration, unless the context of the client is
+// done first, in which case it returns false.
+func (c *client) sleep(duration time.Duration) bool {
+	return watch.Sleep(duration, c.context().Done())
+}
@@ -0,0 +1,76 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetryOptions_backoff(t *testing.T) {
+	opts := RetryOptions{
+		MinBackoff: time.Second,
+		MaxBackoff: 5 * time.Second,
+	}.withDefaults()
+
+	try := func(retry int, max time.Duration) {
+		delay := opts.backoff(retry)
+		require.True(t, delay <= max, "delay %v of retry %d", delay, retry)
+		require.True(t, delay >= time.Duration(float64(max)*(1-opts.Jitter)), "delay %v of retry %d", delay, retry)
+	}
+
+	try(0, time.Second)
+	try(1, 2*time.Second)
+	try(2, 4*time.Second)
+	try(3, 5*time.Second)
+	try(100, 5*time.Second)
+}
+
+func Test_RetryOptions_noJitter(t *testing.T) {
+	opts := RetryOptions{
+		MinBackoff: time.Second,
+		MaxBackoff: 5 * time.Second,
+		Jitter:     0.5,
+		NoJitter:   true,
+	}.withDefaults()
+
+	require.Zero(t, opts.Jitter)
+	require.Equal(t, time.Second, opts.backoff(0))
+	require.Equal(t, 2*time.Second, opts.backoff(1))
+	require.Equal(t, 5*time.Second, opts.backoff(3))
+
+	// without NoJitter, a zero Jitter is the default
+	require.Equal(t, defaultRetryJitter, RetryOptions{}.withDefaults().Jitter)
+}
+
+func Test_client_retryable(t *testing.T) {
+	c := &client{opts: ClientOptions{Retry: RetryOptions{MaxRetries: 3}.withDefaults()}}
+
+	get, _ := http.NewRequest(http.MethodGet, "http://localhost:8200/v1/secret/foo", nil)
+	post, _ := http.NewRequest(http.MethodPost, "http://localhost:8200/v1/secret/foo", nil)
+
+	status := func(code int) *http.Response {
+		return &http.Response{StatusCode: code}
+	}
+
+	// reads are retried upon any of the status codes
+	require.True(t, c.retryable(get, status(http.StatusBadGateway), nil))
+	require.True(t, c.retryable(get, status(http.StatusTooManyRequests), nil))
+	require.False(t, c.retryable(get, status(http.StatusForbidden), nil))
+
+	// writes are retried only if vault cannot have acted upon them
+	require.False(t, c.retryable(post, status(http.StatusBadGateway), nil))
+	require.True(t, c.retryable(post, status(http.StatusTooManyRequests), nil))
+
+	dial := &url.Error{Op: "Post", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+	reset := &url.Error{Op: "Post", Err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}}
+	require.True(t, c.retryable(get, nil, reset))
+	require.True(t, c.retryable(post, nil, dial))
+	require.False(t, c.retryable(post, nil, reset))
+}
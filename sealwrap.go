@@ -0,0 +1,40 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"github.com/pkg/errors"
+)
+
+// A SealWrapRewrapStatus describes the progress of rewrapping the seal
+// wrapped entries of vault enterprise with the current seal key, which
+// IsRunning until every entry has been processed.
+type SealWrapRewrapStatus struct {
+	IsRunning bool `json:"is_running"`
+	Entries   struct {
+		Processed int `json:"processed"`
+		Succeeded int `json:"succeeded"`
+		Failed    int `json:"failed"`
+	} `json:"entries"`
+}
+
+func (c *client) SealWrapRewrapStatus() (SealWrapRewrapStatus, error) {
+	var wrapper struct {
+		Data SealWrapRewrapStatus `json:"data"`
+	}
+	if err := c.get("/v1/sys/sealwrap/rewrap", &wrapper); err != nil {
+		return SealWrapRewrapStatus{}, errors.Wrap(err, "failed to read seal wrap rewrap status")
+	}
+	return wrapper.Data, nil
+}
+
+// SealWrapRewrap starts rewrapping the seal wrapped entries with the
+// current seal key, e.g. after the key has been rotated in the HSM. The
+// rewrap runs in the background, and its progress is reported by
+// SealWrapRewrapStatus.
+func (c *client) SealWrapRewrap() error {
+	if err := c.post("/v1/sys/sealwrap/rewrap", "", nil); err != nil {
+		return errors.Wrap(err, "failed to start seal wrap rewrap")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// the get-vanilla case from the AWS signature version 4 test suite
+func Test_signV4(t *testing.T) {
+	request, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+
+	creds := AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	signV4(request, nil, creds, "us-east-1", "service", now)
+
+	require.Equal(t, "20150830T123600Z", request.Header.Get("X-Amz-Date"))
+	require.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+			"SignedHeaders=host;x-amz-date, "+
+			"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		request.Header.Get("Authorization"),
+	)
+}
+
+func Test_canonicalQuery(t *testing.T) {
+	request, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/?b=2&a=x%20y&a=1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "a=1&a=x%20y&b=2", canonicalQuery(request.URL.Query()))
+}
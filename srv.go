@@ -0,0 +1,125 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultSRVRefresh = time.Minute
+
+// SRVOptions are used to discover the vault servers from DNS SRV
+// records, such as those of a service registered in consul, e.g. the
+// records of _vault._tcp.service.consul. The servers are looked up
+// again once Refresh has passed since the last lookup.
+type SRVOptions struct {
+	// Service, Proto, and Name are the parts of the SRV record to
+	// look up, as in _service._proto.name. If Service and Proto are
+	// empty, Name is looked up directly.
+	Service string
+	Proto   string
+	Name    string
+
+	// Scheme is the scheme of the discovered servers. By default,
+	// this value is "https".
+	Scheme string
+
+	// Refresh configures how often the servers are looked up. By
+	// default, this value is 1 minute.
+	Refresh time.Duration
+}
+
+type lookupSRVFunc func(service, proto, name string) (string, []*net.SRV, error)
+
+// A discovery keeps the servers discovered from SRV records, and is
+// shared by a client and the clients derived from it.
+type discovery struct {
+	opts   SRVOptions
+	static []string
+	logger Logger
+	lookup lookupSRVFunc
+
+	lock     sync.Mutex // protects servers and resolved
+	servers  []string
+	resolved time.Time
+}
+
+func newDiscovery(opts ClientOptions, lookup lookupSRVFunc) (*discovery, error) {
+	srv := opts.SRV
+	if srv.Scheme == "" {
+		srv.Scheme = "https"
+	}
+
+	if srv.Refresh <= 0 {
+		srv.Refresh = defaultSRVRefresh
+	}
+
+	d := &discovery{
+		opts:   srv,
+		static: opts.Servers,
+		logger: opts.Logger,
+		lookup: lookup,
+	}
+
+	// fail early if the servers cannot be discovered at all
+	servers, err := d.resolve()
+	if err != nil {
+		return nil, err
+	}
+	d.servers = servers
+	d.resolved = time.Now()
+
+	return d, nil
+}
+
+// Servers returns the discovered servers, followed by the static
+// servers, looking up the servers again if they are out of date.
+func (d *discovery) Servers() []string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if time.Since(d.resolved) >= d.opts.Refresh {
+		// keep using the previous servers if the lookup fails
+		if servers, err := d.resolve(); err != nil {
+			d.logger.Warnf("failed to discover servers: %v", err)
+		} else {
+			d.servers = servers
+		}
+		d.resolved = time.Now()
+	}
+
+	return d.servers
+}
+
+func (d *discovery) resolve() ([]string, error) {
+	_, records, err := d.lookup(d.opts.Service, d.opts.Proto, d.opts.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to look up SRV records of %q", d.opts.Name)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.Errorf("no SRV records of %q", d.opts.Name)
+	}
+
+	// records are ordered by priority, and randomized by weight
+	var servers []string
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		servers = append(servers, fmt.Sprintf("%s://%s", d.opts.Scheme, net.JoinHostPort(host, fmt.Sprint(record.Port))))
+	}
+	return append(servers, d.static...), nil
+}
+
+// servers returns the servers with which c communicates
+func (c *client) servers() []string {
+	if c.discovery != nil {
+		return c.discovery.Servers()
+	}
+	return c.opts.Servers
+}
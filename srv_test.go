@@ -0,0 +1,71 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_discovery_Servers(t *testing.T) {
+	var records []*net.SRV
+	var failure error
+	var lookups int
+
+	lookup := func(service, proto, name string) (string, []*net.SRV, error) {
+		require.Equal(t, "vault", service)
+		require.Equal(t, "tcp", proto)
+		require.Equal(t, "service.consul", name)
+		lookups++
+		return "", records, failure
+	}
+
+	opts := ClientOptions{
+		Servers: []string{"https://fallback:8200"},
+		SRV: SRVOptions{
+			Service: "vault",
+			Proto:   "tcp",
+			Name:    "service.consul",
+		},
+		Logger: nopLogger{},
+	}
+
+	// no records at all is an error
+	_, err := newDiscovery(opts, lookup)
+	require.Error(t, err)
+
+	records = []*net.SRV{
+		{Target: "vault-1.node.dc1.consul.", Port: 8200},
+		{Target: "vault-2.node.dc1.consul.", Port: 8300},
+	}
+	d, err := newDiscovery(opts, lookup)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"https://vault-1.node.dc1.consul:8200",
+		"https://vault-2.node.dc1.consul:8300",
+		"https://fallback:8200",
+	}, d.Servers())
+	require.Equal(t, 2, lookups)
+
+	// the servers are looked up again once out of date
+	records = records[1:]
+	d.resolved = time.Now().Add(-2 * time.Minute)
+	require.Equal(t, []string{
+		"https://vault-2.node.dc1.consul:8300",
+		"https://fallback:8200",
+	}, d.Servers())
+	require.Equal(t, 3, lookups)
+
+	// a failed lookup keeps the previous servers
+	failure = errors.New("no such host")
+	d.resolved = time.Now().Add(-2 * time.Minute)
+	require.Equal(t, []string{
+		"https://vault-2.node.dc1.consul:8300",
+		"https://fallback:8200",
+	}, d.Servers())
+	require.Equal(t, 4, lookups)
+}
@@ -0,0 +1,129 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// An SSH represents an ssh secrets engine, which provides access to
+// hosts through one time passwords, or by signing ssh keys with a CA.
+//
+// More information about the ssh secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/ssh/index.html
+type SSH interface {
+	// GenerateOTP will create a one time password for username to
+	// log in to the host at ip, with the parameters of an otp role.
+	GenerateOTP(role, ip, username string) (SSHOTP, error)
+	// SignKey will sign the publicKey, which is in the authorized_keys
+	// format, with the CA and the parameters of a ca role.
+	SignKey(role string, publicKey []byte, opts SSHSignOptions) (SSHSignedKey, error)
+	// CAPublicKey will return the public key of the CA, in the
+	// authorized_keys format, to be trusted by hosts.
+	CAPublicKey() ([]byte, error)
+}
+
+func (c *client) SSHMount(mount string) SSH {
+	return &ssh{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type ssh struct {
+	client *client
+	mount  string
+}
+
+// An SSHOTP is a one time password generated by vault for logging
+// in to a host, which runs the vault-ssh-helper to verify it.
+type SSHOTP struct {
+	Key      string `json:"key"`
+	KeyType  string `json:"key_type"`
+	Username string `json:"username"`
+	IP       string `json:"ip"`
+	Port     int    `json:"port"`
+}
+
+type sshOTPWrapper struct {
+	Data SSHOTP `json:"data"`
+}
+
+func (s *ssh) GenerateOTP(role, ip, username string) (SSHOTP, error) {
+	bs, err := json.Marshal(struct {
+		IP       string `json:"ip"`
+		Username string `json:"username,omitempty"`
+	}{IP: ip, Username: username})
+	if err != nil {
+		return SSHOTP{}, err
+	}
+
+	var wrapper sshOTPWrapper
+	requestPath := fmt.Sprintf("%s/creds/%s", s.mount, role)
+	if err := s.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return SSHOTP{}, errors.Wrapf(err, "failed to generate ssh otp for role %q", role)
+	}
+
+	return wrapper.Data, nil
+}
+
+// SSHSignOptions are the parameters of an ssh certificate being
+// signed. The role determines which values are allowed. CertType is
+// either "user" (the default) or "host". TTL is expressed as a string
+// that vault understands, such as "30m".
+type SSHSignOptions struct {
+	ValidPrincipals []string          `json:"-"`
+	CertType        string            `json:"cert_type,omitempty"`
+	KeyID           string            `json:"key_id,omitempty"`
+	TTL             string            `json:"ttl,omitempty"`
+	CriticalOptions map[string]string `json:"critical_options,omitempty"`
+	Extensions      map[string]string `json:"extensions,omitempty"`
+}
+
+// An SSHSignedKey is an ssh certificate signed by the CA of vault.
+// The SignedKey is in the authorized_keys format, and is generally
+// written next to the private key as e.g. id_rsa-cert.pub.
+type SSHSignedKey struct {
+	SerialNumber string `json:"serial_number"`
+	SignedKey    string `json:"signed_key"`
+}
+
+type sshSignedKeyWrapper struct {
+	Data SSHSignedKey `json:"data"`
+}
+
+func (s *ssh) SignKey(role string, publicKey []byte, opts SSHSignOptions) (SSHSignedKey, error) {
+	bs, err := json.Marshal(struct {
+		SSHSignOptions
+		PublicKey       string `json:"public_key"`
+		ValidPrincipals string `json:"valid_principals,omitempty"`
+	}{
+		SSHSignOptions:  opts,
+		PublicKey:       string(publicKey),
+		ValidPrincipals: strings.Join(opts.ValidPrincipals, ","),
+	})
+	if err != nil {
+		return SSHSignedKey{}, err
+	}
+
+	var wrapper sshSignedKeyWrapper
+	requestPath := fmt.Sprintf("%s/sign/%s", s.mount, role)
+	s.client.logRequest("ssh-sign", requestPath, bs)
+	if err := s.client.post(requestPath, string(bs), &wrapper); err != nil {
+		return SSHSignedKey{}, errors.Wrapf(err, "failed to sign ssh key for role %q", role)
+	}
+
+	return wrapper.Data, nil
+}
+
+func (s *ssh) CAPublicKey() ([]byte, error) {
+	var bs []byte
+	if err := s.client.get(s.mount+"/public_key", &bs); err != nil {
+		return nil, errors.Wrap(err, "failed to read ssh CA public key")
+	}
+	return bs, nil
+}
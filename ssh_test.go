@@ -0,0 +1,63 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// authorizedKey encodes an ed25519 public key in the
+// authorized_keys format
+func authorizedKey(key ed25519.PublicKey) []byte {
+	var wire []byte
+	for _, field := range [][]byte{[]byte("ssh-ed25519"), key} {
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(field)))
+		wire = append(wire, length...)
+		wire = append(wire, field...)
+	}
+	return []byte("ssh-ed25519 " + base64.StdEncoding.EncodeToString(wire))
+}
+
+func Test_SSH_SignKey(t *testing.T) {
+	client := getClient(t, rootTokener)
+	ssh := client.SSHMount("ssh")
+
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signed, err := ssh.SignKey("signer", authorizedKey(public), SSHSignOptions{
+		ValidPrincipals: []string{"ubuntu"},
+		TTL:             "10m",
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(signed.SignedKey, "ssh-ed25519-cert-v01@openssh.com "))
+	require.NotEmpty(t, signed.SerialNumber)
+
+	ca, err := ssh.CAPublicKey()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(ca), "ssh-rsa "))
+}
+
+func Test_SSH_GenerateOTP(t *testing.T) {
+	client := getClient(t, rootTokener)
+	ssh := client.SSHMount("ssh")
+
+	otp, err := ssh.GenerateOTP("otp", "127.0.0.1", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, otp.Key)
+	require.Equal(t, "otp", otp.KeyType)
+	require.Equal(t, "ubuntu", otp.Username)
+	require.Equal(t, "127.0.0.1", otp.IP)
+
+	// the ip must be allowed by the role
+	_, err = ssh.GenerateOTP("otp", "10.0.0.1", "")
+	require.Error(t, err)
+}
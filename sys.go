@@ -3,8 +3,12 @@
 package vaultapi
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -14,13 +18,61 @@ import (
 // For more information about the system backend, visit:
 // https://www.vaultproject.io/api/system/index.html.
 type Sys interface {
+	// Activity
+	Activity(start, end time.Time) (Activity, error)
+	MonthlyActivity() (Activity, error)
+	ExportActivity(start, end time.Time, format string) ([]byte, error)
+
+	// Audit Devices
+	ListAuditDevices() (map[string]AuditDevice, error)
+	EnableAuditDevice(path string, opts AuditDeviceOptions) error
+	DisableAuditDevice(path string) error
+	AuditHash(path, input string) (string, error)
+
 	// Capabilities
 	AccessorCapabilities(path, accessor string) ([]string, error)
 	TokenCapabilities(path, token string) ([]string, error)
 	SelfCapabilities(path string) ([]string, error)
+	Capabilities(token string, paths []string) (map[string][]string, error)
+	CapabilitiesSelf(paths []string) (map[string][]string, error)
+	ResultantACL() (ResultantACL, error)
+
+	// Control Groups
+	AuthorizeControlGroup(accessor string) (bool, error)
+	LookupControlGroupRequest(accessor string) (ControlGroupRequest, error)
 
 	// Leases
 	LookupLease(id string) (Lease, error)
+	ListLeases(prefix string) ([]string, error)
+	RenewLease(id string, increment time.Duration) (RenewedLease, error)
+	RevokeLease(id string) error
+	RevokePrefix(prefix string) error
+	RevokeForce(prefix string) error
+	TidyLeases() error
+
+	// Loggers
+	Loggers() (map[string]string, error)
+	LookupLogger(name string) (string, error)
+	SetLogLevel(level string) error
+	SetLoggerLevel(name, level string) error
+	ResetLogLevels() error
+	ResetLoggerLevel(name string) error
+
+	// MFA
+	ValidateMFA(requestID string, payload map[string][]string) (CreatedToken, error)
+
+	// Namespaces
+	CreateNamespace(path string, customMetadata map[string]string) (Namespace, error)
+	LookupNamespace(path string) (Namespace, error)
+	ListNamespaces() ([]string, error)
+	DeleteNamespace(path string) error
+
+	// Plugins
+	RegisterPlugin(pluginType, name string, opts PluginOptions) error
+	LookupPlugin(pluginType, name string) (Plugin, error)
+	ListPlugins(pluginType string) ([]string, error)
+	DeregisterPlugin(pluginType, name string) error
+	ReloadPlugin(opts PluginReloadOptions) (string, error)
 
 	// Policies
 	ListPolicies() ([]string, error)
@@ -28,12 +80,293 @@ type Sys interface {
 	SetPolicy(name, content string) error
 	DeletePolicy(name string) error
 
+	// Initialization
+	InitStatus() (bool, error)
+	Init(opts InitOptions) (InitKeys, error)
+
 	// Vault Status
 	Health() (Health, error)
 	Leader() (Leader, error)
+	HAStatus() ([]HANode, error)
 	StepDown() error
 	SealStatus() (SealStatus, error)
 	ListMounts() (Mounts, error)
+	Metrics(format string) (Metrics, error)
+	HostInfo() (HostInfo, error)
+	InFlightRequests() (map[string]InFlightRequest, error)
+	LicenseStatus() (LicenseStatus, error)
+	SanitizedConfig() (map[string]interface{}, error)
+	OpenAPI(genericMountPaths bool) ([]byte, error)
+	Monitor(ctx context.Context, opts MonitorOptions) (<-chan LogEntry, error)
+
+	// Managed Keys
+	CreateManagedKey(keyType, name string, params map[string]interface{}) error
+	LookupManagedKey(keyType, name string) (map[string]interface{}, error)
+	ListManagedKeys(keyType string) ([]string, error)
+	DeleteManagedKey(keyType, name string) error
+	TestManagedKeySign(keyType, name, hashAlgorithm string) error
+
+	// Mounts
+	EnableMount(path string, opts MountOptions) error
+	DisableMount(path string) error
+	LookupMountConfig(path string) (MountConfig, error)
+	TuneMount(path string, opts MountConfigOptions) error
+	Remount(from, to string) (string, error)
+	RemountStatus(migrationID string) (RemountStatus, error)
+
+	// UI Mounts
+	ListUIMounts() (UIMounts, error)
+	LookupUIMount(path string) (UIMount, error)
+
+	// Auth Mounts
+	ListAuthMounts() (Mounts, error)
+	EnableAuthMount(path string, opts MountOptions) error
+	DisableAuthMount(path string) error
+	LookupAuthMountConfig(path string) (MountConfig, error)
+	TuneAuthMount(path string, opts MountConfigOptions) error
+
+	// Raft Storage
+	RaftJoin(opts RaftJoinOptions) (bool, error)
+	RaftConfiguration() (RaftConfiguration, error)
+	RaftRemovePeer(nodeID string) error
+	RaftBootstrapChallenge(nodeID string) (RaftBootstrapChallenge, error)
+	RaftBootstrapAnswer(opts RaftBootstrapAnswerOptions) (RaftBootstrapAnswer, error)
+
+	// Quotas
+	ConfigureQuotas(config QuotaConfig) error
+	LookupQuotaConfig() (QuotaConfig, error)
+	CreateRateLimitQuota(name string, opts RateLimitQuotaOptions) error
+	LookupRateLimitQuota(name string) (RateLimitQuota, error)
+	ListRateLimitQuotas() ([]string, error)
+	DeleteRateLimitQuota(name string) error
+	CreateLeaseCountQuota(name string, opts LeaseCountQuotaOptions) error
+	LookupLeaseCountQuota(name string) (LeaseCountQuota, error)
+	ListLeaseCountQuotas() ([]string, error)
+	DeleteLeaseCountQuota(name string) error
+
+	// Rekey
+	RekeyStatus() (RekeyStatus, error)
+	RekeyInit(opts RekeyOptions) (RekeyStatus, error)
+	RekeyCancel() error
+	RekeyUpdate(key, nonce string) (RekeyUpdate, error)
+	RekeyBackup() (RekeyBackup, error)
+	RekeyDeleteBackup() error
+	RekeyVerificationStatus() (RekeyVerificationStatus, error)
+	RekeyVerify(key, nonce string) (RekeyVerification, error)
+	RekeyCancelVerification() error
+	RekeyRecoveryKeyStatus() (RekeyStatus, error)
+	RekeyRecoveryKeyInit(opts RekeyOptions) (RekeyStatus, error)
+	RekeyRecoveryKeyCancel() error
+	RekeyRecoveryKeyUpdate(key, nonce string) (RekeyUpdate, error)
+	RekeyRecoveryKeyBackup() (RekeyBackup, error)
+	RekeyRecoveryKeyDeleteBackup() error
+	RekeyRecoveryKeyVerificationStatus() (RekeyVerificationStatus, error)
+	RekeyRecoveryKeyVerify(key, nonce string) (RekeyVerification, error)
+	RekeyRecoveryKeyCancelVerification() error
+
+	// Generate Root
+	GenerateRootStatus() (GenerateRootStatus, error)
+	GenerateRootInit(otp, pgpKey string) (GenerateRootStatus, error)
+	GenerateRootCancel() error
+	GenerateRootUpdate(key, nonce string) (GenerateRootStatus, error)
+	GenerateRecoveryTokenStatus() (GenerateRootStatus, error)
+	GenerateRecoveryTokenInit(otp, pgpKey string) (GenerateRootStatus, error)
+	GenerateRecoveryTokenCancel() error
+	GenerateRecoveryTokenUpdate(key, nonce string) (GenerateRootStatus, error)
+
+	// Seal Wrap
+	SealWrapRewrapStatus() (SealWrapRewrapStatus, error)
+	SealWrapRewrap() error
+
+	// Response Wrapping
+	Wrap(data map[string]interface{}, ttl time.Duration) (WrapInfo, error)
+	Unwrap(token string) (Unwrapped, error)
+	LookupWrapping(token string) (WrappingLookup, error)
+	RewrapWrapping(token string) (WrapInfo, error)
+
+	// Tools
+	ToolsRandomBytes(n int) ([]byte, error)
+	ToolsHash(input []byte, algorithm string) ([]byte, error)
+}
+
+// Capabilities returns the capabilities of the token on each of the
+// paths, keyed by path.
+func (c *client) Capabilities(token string, paths []string) (map[string][]string, error) {
+	bs, err := json.Marshal(struct {
+		Paths []string `json:"paths"`
+		Token string   `json:"token"`
+	}{Paths: paths, Token: token})
+	if err != nil {
+		return nil, err
+	}
+	return c.capabilities("/v1/sys/capabilities", string(bs), paths)
+}
+
+// CapabilitiesSelf returns the capabilities of the token of the
+// Client on each of the paths, keyed by path.
+func (c *client) CapabilitiesSelf(paths []string) (map[string][]string, error) {
+	bs, err := json.Marshal(struct {
+		Paths []string `json:"paths"`
+	}{Paths: paths})
+	if err != nil {
+		return nil, err
+	}
+	return c.capabilities("/v1/sys/capabilities-self", string(bs), paths)
+}
+
+func (c *client) capabilities(requestPath, body string, paths []string) (map[string][]string, error) {
+	// the data also contains the capabilities of the
+	// first path as "capabilities", which is ignored
+	var wrapper struct {
+		Data map[string][]string `json:"data"`
+	}
+	if err := c.post(requestPath, body, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to read capabilities at %v", paths)
+	}
+
+	caps := make(map[string][]string, len(paths))
+	for _, path := range paths {
+		pathCaps := wrapper.Data[path]
+		sort.Strings(pathCaps)
+		caps[path] = pathCaps
+	}
+	return caps, nil
+}
+
+// An MFARequirement describes the MFA required to complete a login. Each
+// of the Constraints, keyed by the name of a login enforcement, must be
+// satisfied by passing any one of its methods.
+type MFARequirement struct {
+	RequestID   string                   `json:"mfa_request_id"`
+	Constraints map[string]MFAConstraint `json:"mfa_constraints"`
+}
+
+// An MFAConstraint is satisfied by passing any one of its methods.
+type MFAConstraint struct {
+	Any []MFAConstraintMethod `json:"any"`
+}
+
+// An MFAConstraintMethod is an MFA method which satisfies an MFAConstraint.
+// If UsesPasscode is set, a passcode must be provided for the method.
+type MFAConstraintMethod struct {
+	Type         string `json:"type"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	UsesPasscode bool   `json:"uses_passcode"`
+}
+
+// ValidateMFA completes a login which returned an MFARequirement, and
+// returns the token of the login. The requestID is that of the
+// requirement, and the payload maps the ID of each MFA method being
+// passed to its passcodes, which are empty for methods that do not
+// use a passcode.
+func (c *client) ValidateMFA(requestID string, payload map[string][]string) (CreatedToken, error) {
+	request := struct {
+		RequestID string              `json:"mfa_request_id"`
+		Payload   map[string][]string `json:"mfa_payload"`
+	}{RequestID: requestID, Payload: make(map[string][]string, len(payload))}
+
+	// vault rejects a null list of passcodes
+	for method, passcodes := range payload {
+		if passcodes == nil {
+			passcodes = []string{}
+		}
+		request.Payload[method] = passcodes
+	}
+
+	bs, err := json.Marshal(request)
+	if err != nil {
+		return CreatedToken{}, err
+	}
+
+	// do not log the request, which contains the passcodes
+	var ct createdToken
+	if err := c.post("/v1/sys/mfa/validate", string(bs), &ct); err != nil {
+		return CreatedToken{}, errors.Wrapf(err, "failed to validate mfa request %q", requestID)
+	}
+
+	return ct.Data, nil
+}
+
+// An AuditDevice logs every request to and response from vault, with
+// sensitive values replaced by their HMAC. The Type of the device is
+// "file", "syslog", or "socket", and the Options are specific to the
+// Type, e.g. the file_path of a file device.
+//
+// More information about audit devices can be found here:
+// https://www.vaultproject.io/docs/audit/index.html
+type AuditDevice struct {
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Path        string            `json:"path"`
+	Local       bool              `json:"local"`
+	Options     map[string]string `json:"options"`
+}
+
+// AuditDeviceOptions are used to configure an audit device being
+// enabled. Local devices are not replicated.
+type AuditDeviceOptions struct {
+	Type        string            `json:"type"`
+	Description string            `json:"description,omitempty"`
+	Local       bool              `json:"local,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+}
+
+// ListAuditDevices lists the audit devices currently enabled,
+// keyed by the path of each device.
+func (c *client) ListAuditDevices() (map[string]AuditDevice, error) {
+	var wrapper struct {
+		Data map[string]AuditDevice `json:"data"`
+	}
+	if err := c.get("/v1/sys/audit", &wrapper); err != nil {
+		return nil, errors.Wrap(err, "failed to read audit devices")
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) EnableAuditDevice(path string, opts AuditDeviceOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling audit device data to JSON request body")
+	}
+
+	requestPath := "/v1/sys/audit/" + strings.Trim(path, "/")
+	c.logRequest("audit-enable", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to enable audit device at %q", path)
+	}
+
+	return nil
+}
+
+func (c *client) DisableAuditDevice(path string) error {
+	requestPath := "/v1/sys/audit/" + strings.Trim(path, "/")
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to disable audit device at %q", path)
+	}
+	return nil
+}
+
+// AuditHash computes the HMAC of input with the salt of the audit
+// device at path, as it would appear in the logs of the device.
+func (c *client) AuditHash(path, input string) (string, error) {
+	bs, err := json.Marshal(struct {
+		Input string `json:"input"`
+	}{Input: input})
+	if err != nil {
+		return "", err
+	}
+
+	// do not log the request, which contains the input
+	var response struct {
+		Hash string `json:"hash"`
+	}
+	requestPath := "/v1/sys/audit-hash/" + strings.Trim(path, "/")
+	if err := c.post(requestPath, string(bs), &response); err != nil {
+		return "", errors.Wrapf(err, "failed to compute audit hash with audit device at %q", path)
+	}
+
+	return response.Hash, nil
 }
 
 type capabilities struct {
@@ -101,6 +434,10 @@ type Lease struct {
 	TTL             int    `json:"ttl"`
 }
 
+type leaseWrapper struct {
+	Data Lease `json:"data"`
+}
+
 func (c *client) LookupLease(id string) (Lease, error) {
 	bs, err := json.Marshal(struct {
 		ID string `json:"lease_id"`
@@ -108,38 +445,213 @@ func (c *client) LookupLease(id string) (Lease, error) {
 	if err != nil {
 		return Lease{}, err
 	}
-	var lease Lease
-	if err := c.put("/v1/sys/leases/lookup", string(bs)); err != nil {
+	var wrapper leaseWrapper
+	if err := c.post("/v1/sys/leases/lookup", string(bs), &wrapper); err != nil {
 		return Lease{}, errors.Wrapf(err, "failed to lookup lease for %q", id)
 	}
+	return wrapper.Data, nil
+}
+
+// ListLeases lists the leases under prefix, e.g. "database/creds/app/".
+// Keys which end with a / are themselves prefixes of more leases.
+func (c *client) ListLeases(prefix string) ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := "/v1/sys/leases/lookup/" + strings.TrimPrefix(prefix, "/")
+	if err := c.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list leases under %q", prefix)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+// A RenewedLease represents information returned from vault
+// after making a request to renew a lease.
+type RenewedLease struct {
+	ID            string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// RenewLease requests the lease with the given id be extended by
+// increment, starting from now. Vault may grant less, e.g. when the
+// lease nears its max TTL. A zero increment requests the default TTL
+// of the lease.
+func (c *client) RenewLease(id string, increment time.Duration) (RenewedLease, error) {
+	bs, err := json.Marshal(struct {
+		ID        string `json:"lease_id"`
+		Increment int    `json:"increment,omitempty"`
+	}{ID: id, Increment: int(increment.Seconds())})
+	if err != nil {
+		return RenewedLease{}, err
+	}
+	var lease RenewedLease
+	if err := c.post("/v1/sys/leases/renew", string(bs), &lease); err != nil {
+		return RenewedLease{}, errors.Wrapf(err, "failed to renew lease %q", id)
+	}
 	return lease, nil
 }
 
+// RevokeLease revokes the lease with the given id, which immediately
+// invalidates the secret of the lease.
+func (c *client) RevokeLease(id string) error {
+	bs, err := json.Marshal(struct {
+		ID string `json:"lease_id"`
+	}{ID: id})
+	if err != nil {
+		return err
+	}
+	if err := c.put("/v1/sys/leases/revoke", string(bs)); err != nil {
+		return errors.Wrapf(err, "failed to revoke lease %q", id)
+	}
+	return nil
+}
+
+// RevokePrefix revokes all of the leases under prefix, e.g.
+// "database/creds/" revokes every database credential. It requires
+// sudo capability on the path.
+func (c *client) RevokePrefix(prefix string) error {
+	requestPath := "/v1/sys/leases/revoke-prefix/" + strings.TrimPrefix(prefix, "/")
+	if err := c.put(requestPath, ""); err != nil {
+		return errors.Wrapf(err, "failed to revoke leases under %q", prefix)
+	}
+	return nil
+}
+
+// RevokeForce is like RevokePrefix, but removes the leases from vault
+// even if revoking their secrets fails, e.g. because the database of
+// the credentials no longer exists. The secrets are left in place
+// and must be cleaned up by hand. It requires sudo capability on
+// the path.
+func (c *client) RevokeForce(prefix string) error {
+	requestPath := "/v1/sys/leases/revoke-force/" + strings.TrimPrefix(prefix, "/")
+	if err := c.put(requestPath, ""); err != nil {
+		return errors.Wrapf(err, "failed to force revoke leases under %q", prefix)
+	}
+	return nil
+}
+
+// TidyLeases cleans up the dangling storage entries of leases, which
+// can be left behind by failures. The tidy runs in the background.
+func (c *client) TidyLeases() error {
+	if err := c.put("/v1/sys/leases/tidy", ""); err != nil {
+		return errors.Wrap(err, "failed to tidy leases")
+	}
+	return nil
+}
+
+func (c *client) InitStatus() (bool, error) {
+	var status struct {
+		Initialized bool `json:"initialized"`
+	}
+	if err := c.get("/v1/sys/init", &status); err != nil {
+		return false, errors.Wrap(err, "failed to read init status")
+	}
+	return status.Initialized, nil
+}
+
+// InitOptions are used to configure the initialization of vault. The
+// master key is split into SecretShares key shares, of which
+// SecretThreshold are needed to unseal vault. If PGPKeys are set, each
+// key share is encrypted with the corresponding base64 encoded PGP
+// public key (or keybase user, as "keybase:name"), and likewise the
+// root token with the RootTokenPGPKey.
+//
+// Vault servers using auto unseal are instead initialized with
+// recovery key shares, configured by the recovery options.
+type InitOptions struct {
+	SecretShares      int      `json:"secret_shares"`
+	SecretThreshold   int      `json:"secret_threshold"`
+	StoredShares      int      `json:"stored_shares,omitempty"`
+	PGPKeys           []string `json:"pgp_keys,omitempty"`
+	RootTokenPGPKey   string   `json:"root_token_pgp_key,omitempty"`
+	RecoveryShares    int      `json:"recovery_shares,omitempty"`
+	RecoveryThreshold int      `json:"recovery_threshold,omitempty"`
+	RecoveryPGPKeys   []string `json:"recovery_pgp_keys,omitempty"`
+}
+
+// InitKeys are the key shares and the initial root token generated
+// when initializing vault. They are returned only once, and should
+// be distributed to their holders immediately.
+type InitKeys struct {
+	Keys               []string `json:"keys"`
+	KeysBase64         []string `json:"keys_base64"`
+	RecoveryKeys       []string `json:"recovery_keys"`
+	RecoveryKeysBase64 []string `json:"recovery_keys_base64"`
+	RootToken          string   `json:"root_token"`
+}
+
+// Init initializes a new vault server. Since vault has no tokens
+// until it is initialized, the Client may use any token, such as
+// NewStaticToken("").
+func (c *client) Init(opts InitOptions) (InitKeys, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return InitKeys{}, err
+	}
+	c.logRequest("init", "/v1/sys/init", bs)
+
+	var keys InitKeys
+	if err := c.post("/v1/sys/init", string(bs), &keys); err != nil {
+		return InitKeys{}, errors.Wrap(err, "failed to initialize vault")
+	}
+	return keys, nil
+}
+
 // A Health is returned upon requesting health status from vault
-// and contains some metadata about the vault configuartion.
+// and contains some metadata about the vault configuartion. The
+// replication modes are "disabled" unless vault is an enterprise
+// server with replication configured.
 type Health struct {
-	Initialized   bool   `json:"initialized"`
-	Sealed        bool   `json:"sealed"`
-	Standby       bool   `json:"standby"`
-	ServerTimeUTC int    `json:"server_time_utc"`
-	Version       string `json:"version"`
-	ClusterName   string `json:"cluster_name"`
-	ClusterID     string `json:"cluster_id"`
+	Initialized                bool   `json:"initialized"`
+	Sealed                     bool   `json:"sealed"`
+	Standby                    bool   `json:"standby"`
+	PerformanceStandby         bool   `json:"performance_standby"`
+	ReplicationPerformanceMode string `json:"replication_performance_mode"`
+	ReplicationDRMode          string `json:"replication_dr_mode"`
+	ServerTimeUTC              int    `json:"server_time_utc"`
+	Version                    string `json:"version"`
+	ClusterName                string `json:"cluster_name"`
+	ClusterID                  string `json:"cluster_id"`
 }
 
+// healthCode is the status code vault is asked to respond with when it
+// is not active, instead of the error status codes it responds with by
+// default, e.g. 503 if sealed. This way the health is always decoded,
+// and describes why vault is not active.
+const healthCode = "299"
+
+// Health returns the health of vault, which is reported whether or not
+// vault is initialized, unsealed, and active.
 func (c *client) Health() (Health, error) {
 	var health Health
-	if err := c.get("/v1/sys/health", &health); err != nil {
+	requestPath := fixup("/v1/sys", "/health",
+		[2]string{"uninitcode", healthCode},
+		[2]string{"sealedcode", healthCode},
+		[2]string{"standbycode", healthCode},
+		[2]string{"performancestandbycode", healthCode},
+		[2]string{"drsecondarycode", healthCode},
+	)
+	if err := c.get(requestPath, &health); err != nil {
 		return Health{}, errors.Wrap(err, "failed to read health")
 	}
 	return health, nil
 }
 
-// A Leader is returned upon requesting the leader from vault.
+// A Leader is returned upon requesting the leader from vault. The
+// LeaderAddress is the api address of the active node, and the
+// LeaderClusterAddress is its address for request forwarding. The
+// ActiveTime is when the active node became active, and the raft
+// indexes are set only if vault uses integrated storage.
 type Leader struct {
-	HAEnabled     bool   `json:"ha_enabled"`
-	IsSelf        bool   `json:"is_self"`
-	LeaderAddress string `json:"leader_address"`
+	HAEnabled                 bool   `json:"ha_enabled"`
+	IsSelf                    bool   `json:"is_self"`
+	ActiveTime                string `json:"active_time"`
+	LeaderAddress             string `json:"leader_address"`
+	LeaderClusterAddress      string `json:"leader_cluster_address"`
+	PerformanceStandby        bool   `json:"performance_standby"`
+	PerformanceStandbyLastWAL int    `json:"performance_standby_last_remote_wal"`
+	RaftCommittedIndex        int    `json:"raft_committed_index"`
+	RaftAppliedIndex          int    `json:"raft_applied_index"`
 }
 
 func (c *client) Leader() (Leader, error) {
@@ -150,6 +662,46 @@ func (c *client) Leader() (Leader, error) {
 	return leader, nil
 }
 
+// An HANode is a node of a vault cluster, which is the ActiveNode or a
+// standby. The LastEcho is when a standby last checked in with the
+// active node, and is not set for the active node.
+type HANode struct {
+	Hostname       string `json:"hostname"`
+	APIAddress     string `json:"api_address"`
+	ClusterAddress string `json:"cluster_address"`
+	ActiveNode     bool   `json:"active_node"`
+	LastEcho       string `json:"last_echo"`
+	Version        string `json:"version"`
+	UpgradeVersion string `json:"upgrade_version"`
+	RedundancyZone string `json:"redundancy_zone"`
+}
+
+type haStatusWrapper struct {
+	Nodes []HANode `json:"nodes"`
+
+	// newer versions of vault wrap the nodes in data
+	Data struct {
+		Nodes []HANode `json:"nodes"`
+	} `json:"data"`
+}
+
+// HAStatus returns the nodes of the vault cluster, as known to the
+// active node.
+func (c *client) HAStatus() ([]HANode, error) {
+	var wrapper haStatusWrapper
+	if err := c.get("/v1/sys/ha-status", &wrapper); err != nil {
+		return nil, errors.Wrap(err, "failed to read ha status")
+	}
+	if wrapper.Data.Nodes != nil {
+		return wrapper.Data.Nodes, nil
+	}
+	return wrapper.Nodes, nil
+}
+
+// StepDown forces the active node to give up leadership, so that
+// a standby node takes over. The active node becomes a standby, and
+// may be elected again. It requires a root token, or sudo capability
+// on sys/step-down.
 func (c *client) StepDown() error {
 	if err := c.put("/v1/sys/step-down", ""); err != nil {
 		return errors.Wrap(err, "failed to step down")
@@ -157,25 +709,56 @@ func (c *client) StepDown() error {
 	return nil
 }
 
+// SanitizedConfig returns the effective configuration of the vault
+// server, with secrets such as seal and storage credentials removed. It
+// requires a root token, or sudo capability on sys/config/state.
+func (c *client) SanitizedConfig() (map[string]interface{}, error) {
+	var wrapper struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := c.get("/v1/sys/config/state/sanitized", &wrapper); err != nil {
+		return nil, errors.Wrap(err, "failed to read sanitized config")
+	}
+	return wrapper.Data, nil
+}
+
 type mountsWrapper struct {
 	Data Mounts `json:"data"`
 }
 
 // Mounts contains information about the mounts currently configured
-// with vault. Generally, users of the vaultapi client library are
-// likely only concerned with the default backends that come with vault,
-// and will not need to be concerned with this API.
+// with vault, keyed by the path of each mount.
 //
 // More information can be found here:
 // https://www.vaultproject.io/docs/internals/architecture.html
-type Mounts map[string]struct {
-	Type        string `json:"type"`
-	Description string `json:"description"`
-	Config      struct {
-		DefaultLeaseTTL int  `json:"default_lease_ttl"`
-		MaxLeaseTTL     int  `json:"max_lease_ttl"`
-		ForceNoCache    bool `json:"force_no_cache"`
-	} `json:"config"`
+type Mounts map[string]Mount
+
+// A Mount is a secrets engine (or auth method) mounted at a path. The
+// Options are specific to the Type, e.g. the version of a kv mount.
+// Local mounts are not replicated, and SealWrap mounts have their data
+// wrapped by the seal of vault.
+type Mount struct {
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Accessor    string            `json:"accessor"`
+	Local       bool              `json:"local"`
+	SealWrap    bool              `json:"seal_wrap"`
+	Options     map[string]string `json:"options"`
+	Config      MountConfig       `json:"config"`
+}
+
+// A MountConfig is the configuration of the leases and auditing of a
+// mount. The lease TTLs are in seconds. The AuditNonHMAC keys are the
+// request and response keys which are logged in plaintext by audit
+// devices, rather than as HMACs.
+type MountConfig struct {
+	DefaultLeaseTTL           int      `json:"default_lease_ttl"`
+	MaxLeaseTTL               int      `json:"max_lease_ttl"`
+	ForceNoCache              bool     `json:"force_no_cache"`
+	AuditNonHMACRequestKeys   []string `json:"audit_non_hmac_request_keys"`
+	AuditNonHMACResponseKeys  []string `json:"audit_non_hmac_response_keys"`
+	ListingVisibility         string   `json:"listing_visibility"`
+	PassthroughRequestHeaders []string `json:"passthrough_request_headers"`
 }
 
 func (c *client) ListMounts() (Mounts, error) {
@@ -188,6 +771,217 @@ func (c *client) ListMounts() (Mounts, error) {
 	return wrapper.Data, nil
 }
 
+// MountOptions are used to configure a mount being enabled. The Type
+// is the type of secrets engine (or auth method), e.g. "kv" or "pki".
+type MountOptions struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Config      MountConfigOptions `json:"config"`
+	Options     map[string]string  `json:"options,omitempty"`
+	Local       bool               `json:"local,omitempty"`
+	SealWrap    bool               `json:"seal_wrap,omitempty"`
+}
+
+// MountConfigOptions are used to configure a mount being enabled or
+// tuned. Options which are not set are left unchanged when tuning.
+// Durations are expressed as strings that vault understands, such
+// as "1h". ListingVisibility is either "hidden" or "unauth", which
+// lists the mount in the UI before logging in.
+type MountConfigOptions struct {
+	DefaultLeaseTTL           string   `json:"default_lease_ttl,omitempty"`
+	MaxLeaseTTL               string   `json:"max_lease_ttl,omitempty"`
+	ForceNoCache              bool     `json:"force_no_cache,omitempty"`
+	AuditNonHMACRequestKeys   []string `json:"audit_non_hmac_request_keys,omitempty"`
+	AuditNonHMACResponseKeys  []string `json:"audit_non_hmac_response_keys,omitempty"`
+	ListingVisibility         string   `json:"listing_visibility,omitempty"`
+	PassthroughRequestHeaders []string `json:"passthrough_request_headers,omitempty"`
+}
+
+// EnableMount mounts a new secrets engine at path.
+func (c *client) EnableMount(path string, opts MountOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling mount data to JSON request body")
+	}
+
+	requestPath := "/v1/sys/mounts/" + strings.Trim(path, "/")
+	c.logRequest("mount-enable", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to enable mount at %q", path)
+	}
+
+	return nil
+}
+
+// DisableMount unmounts the secrets engine at path, revoking all of
+// its leases and deleting all of its data.
+func (c *client) DisableMount(path string) error {
+	requestPath := "/v1/sys/mounts/" + strings.Trim(path, "/")
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to disable mount at %q", path)
+	}
+	return nil
+}
+
+type mountConfigWrapper struct {
+	MountConfig
+	Data *MountConfig `json:"data"`
+}
+
+// config returns the data of the response if set, since older
+// versions of vault respond with the config at the top level.
+func (w mountConfigWrapper) config() MountConfig {
+	if w.Data != nil {
+		return *w.Data
+	}
+	return w.MountConfig
+}
+
+func (c *client) LookupMountConfig(path string) (MountConfig, error) {
+	var wrapper mountConfigWrapper
+	requestPath := fmt.Sprintf("/v1/sys/mounts/%s/tune", strings.Trim(path, "/"))
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return MountConfig{}, errors.Wrapf(err, "failed to read config of mount at %q", path)
+	}
+	return wrapper.config(), nil
+}
+
+func (c *client) TuneMount(path string, opts MountConfigOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling mount config data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/sys/mounts/%s/tune", strings.Trim(path, "/"))
+	c.logRequest("mount-tune", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to tune mount at %q", path)
+	}
+
+	return nil
+}
+
+// Remount moves the mount at from to the path to, along with its data
+// and leases. Newer versions of vault move the mount asynchronously, and
+// return the ID of the migration, whose progress is checked by polling
+// RemountStatus. Older versions of vault move the mount before returning,
+// and return no ID.
+func (c *client) Remount(from, to string) (string, error) {
+	bs, err := json.Marshal(struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}{From: from, To: to})
+	if err != nil {
+		return "", err
+	}
+
+	var wrapper struct {
+		Data struct {
+			MigrationID string `json:"migration_id"`
+		} `json:"data"`
+	}
+	if err := c.post("/v1/sys/remount", string(bs), &wrapper); err != nil {
+		return "", errors.Wrapf(err, "failed to remount %q to %q", from, to)
+	}
+
+	return wrapper.Data.MigrationID, nil
+}
+
+// The states of the migration of a Remount.
+const (
+	RemountInProgress = "in-progress"
+	RemountSuccess    = "success"
+	RemountFailure    = "failure"
+)
+
+// A RemountStatus describes the progress of the migration of a Remount,
+// where Status is one of RemountInProgress, RemountSuccess, or
+// RemountFailure.
+type RemountStatus struct {
+	MigrationID string `json:"migration_id"`
+	SourceMount string `json:"source_mount"`
+	TargetMount string `json:"target_mount"`
+	Status      string `json:"status"`
+}
+
+func (c *client) RemountStatus(migrationID string) (RemountStatus, error) {
+	var wrapper struct {
+		Data struct {
+			MigrationID   string        `json:"migration_id"`
+			MigrationInfo RemountStatus `json:"migration_info"`
+		} `json:"data"`
+	}
+	requestPath := "/v1/sys/remount/status/" + migrationID
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return RemountStatus{}, errors.Wrapf(err, "failed to read status of remount %q", migrationID)
+	}
+
+	status := wrapper.Data.MigrationInfo
+	status.MigrationID = wrapper.Data.MigrationID
+	return status, nil
+}
+
+// ListAuthMounts lists the auth methods currently enabled,
+// keyed by the path of each auth method under auth/.
+func (c *client) ListAuthMounts() (Mounts, error) {
+	var wrapper mountsWrapper
+	if err := c.get("/v1/sys/auth", &wrapper); err != nil {
+		return nil, errors.Wrap(err, "failed to read auth mounts")
+	}
+	return wrapper.Data, nil
+}
+
+// EnableAuthMount enables a new auth method at path, e.g. an
+// auth method enabled at "approle" is used at auth/approle.
+func (c *client) EnableAuthMount(path string, opts MountOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling auth mount data to JSON request body")
+	}
+
+	requestPath := "/v1/sys/auth/" + strings.Trim(path, "/")
+	c.logRequest("auth-enable", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to enable auth mount at %q", path)
+	}
+
+	return nil
+}
+
+// DisableAuthMount disables the auth method at path, revoking
+// all of the tokens created by logging in with it.
+func (c *client) DisableAuthMount(path string) error {
+	requestPath := "/v1/sys/auth/" + strings.Trim(path, "/")
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to disable auth mount at %q", path)
+	}
+	return nil
+}
+
+func (c *client) LookupAuthMountConfig(path string) (MountConfig, error) {
+	var wrapper mountConfigWrapper
+	requestPath := fmt.Sprintf("/v1/sys/auth/%s/tune", strings.Trim(path, "/"))
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return MountConfig{}, errors.Wrapf(err, "failed to read config of auth mount at %q", path)
+	}
+	return wrapper.config(), nil
+}
+
+func (c *client) TuneAuthMount(path string, opts MountConfigOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling auth mount config data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/sys/auth/%s/tune", strings.Trim(path, "/"))
+	c.logRequest("auth-tune", requestPath, bs)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to tune auth mount at %q", path)
+	}
+
+	return nil
+}
+
 type listPolicies struct {
 	Policies []string `json:"policies"`
 }
@@ -258,3 +1052,15 @@ func (c *client) SealStatus() (SealStatus, error) {
 	}
 	return ss, nil
 }
+
+// ToolsRandomBytes is the same as RandomBytes, but does not
+// require the transit secrets engine to be mounted.
+func (c *client) ToolsRandomBytes(n int) ([]byte, error) {
+	return c.randomBytes("/v1/sys/tools", n)
+}
+
+// ToolsHash is the same as Hash, but does not require the
+// transit secrets engine to be mounted.
+func (c *client) ToolsHash(input []byte, algorithm string) ([]byte, error) {
+	return c.hash("/v1/sys/tools", input, algorithm)
+}
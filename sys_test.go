@@ -3,8 +3,13 @@
 package vaultapi
 
 import (
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,11 +39,20 @@ func Test_Client_SelfCapabilities(t *testing.T) {
 	require.Equal(t, "root", caps[0])
 }
 
+func Test_Client_InitStatus(t *testing.T) {
+	client := getClient(t, rootTokener)
+	initialized, err := client.InitStatus()
+	require.NoError(t, err)
+	require.True(t, initialized)
+}
+
 func Test_Client_Health(t *testing.T) {
 	client := getClient(t, rootTokener)
 	health, err := client.Health()
 	require.NoError(t, err)
+	require.True(t, health.Initialized)
 	require.False(t, health.Sealed)
+	require.False(t, health.Standby)
 	t.Log("health:", health)
 }
 
@@ -58,6 +72,117 @@ func Test_Client_ListMounts(t *testing.T) {
 	require.Equal(t, "generic secret storage", mounts["secret/"].Description)
 }
 
+func Test_Client_Mounts(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	err := client.EnableMount("scratch", MountOptions{
+		Type:        "generic",
+		Description: "scratch space",
+		Config: MountConfigOptions{
+			MaxLeaseTTL: "2h",
+		},
+	})
+	require.NoError(t, err)
+
+	mounts, err := client.ListMounts()
+	require.NoError(t, err)
+	require.Equal(t, "generic", mounts["scratch/"].Type)
+	require.Equal(t, "scratch space", mounts["scratch/"].Description)
+
+	err = client.TuneMount("scratch", MountConfigOptions{
+		DefaultLeaseTTL: "30m",
+	})
+	require.NoError(t, err)
+
+	config, err := client.LookupMountConfig("scratch")
+	require.NoError(t, err)
+	require.Equal(t, 1800, config.DefaultLeaseTTL)
+	require.Equal(t, 7200, config.MaxLeaseTTL)
+
+	err = client.DisableMount("scratch")
+	require.NoError(t, err)
+
+	mounts, err = client.ListMounts()
+	require.NoError(t, err)
+	_, exists := mounts["scratch/"]
+	require.False(t, exists)
+}
+
+func Test_Client_Remount(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	err := client.EnableMount("remount-from", MountOptions{Type: "generic"})
+	require.NoError(t, err)
+
+	// the remount completes before returning in older versions of vault,
+	// which do not have a migration to check the status of
+	_, err = client.Remount("remount-from", "remount-to")
+	require.NoError(t, err)
+
+	mounts, err := client.ListMounts()
+	require.NoError(t, err)
+	_, exists := mounts["remount-from/"]
+	require.False(t, exists)
+	require.Equal(t, "generic", mounts["remount-to/"].Type)
+
+	err = client.DisableMount("remount-to")
+	require.NoError(t, err)
+}
+
+func Test_Client_AuthMounts(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	err := client.EnableAuthMount("userpass-scratch", MountOptions{
+		Type:        "userpass",
+		Description: "scratch logins",
+	})
+	require.NoError(t, err)
+
+	mounts, err := client.ListAuthMounts()
+	require.NoError(t, err)
+	require.Equal(t, "userpass", mounts["userpass-scratch/"].Type)
+	require.Equal(t, "scratch logins", mounts["userpass-scratch/"].Description)
+	require.Equal(t, "approle", mounts["approle/"].Type)
+
+	err = client.DisableAuthMount("userpass-scratch")
+	require.NoError(t, err)
+
+	mounts, err = client.ListAuthMounts()
+	require.NoError(t, err)
+	_, exists := mounts["userpass-scratch/"]
+	require.False(t, exists)
+}
+
+func Test_Client_AuditDevices(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	err := client.EnableAuditDevice("scratch-audit", AuditDeviceOptions{
+		Type:        "file",
+		Description: "scratch audit log",
+		Options: map[string]string{
+			"file_path": "/tmp/vault-audit.log",
+		},
+	})
+	require.NoError(t, err)
+
+	devices, err := client.ListAuditDevices()
+	require.NoError(t, err)
+	require.Equal(t, "file", devices["scratch-audit/"].Type)
+	require.Equal(t, "scratch audit log", devices["scratch-audit/"].Description)
+
+	hash, err := client.AuditHash("scratch-audit", "secret value")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "hmac-sha256:"))
+
+	err = client.DisableAuditDevice("scratch-audit")
+	require.NoError(t, err)
+
+	devices, err = client.ListAuditDevices()
+	require.NoError(t, err)
+	_, exists := devices["scratch-audit/"]
+	require.False(t, exists)
+}
+
 const pol1 = `
 # Allow a token to manage secret/foo/bar/* (no deletes)
 path "secret/foo/bar/*" {
@@ -103,3 +228,144 @@ func Test_Client_StepDown(t *testing.T) {
 	err := client.StepDown()
 	t.Log("step down error:", err)
 }
+
+// issueLeases issues count certificates with a pki role which
+// generates a lease for each of them, returning the prefix of the leases
+func issueLeases(t *testing.T, client Client, count int) string {
+	pki := client.PKIMount("pki")
+	require.NoError(t, pki.CreateRole(PKIRoleOptions{
+		Name:            "leased",
+		AllowedDomains:  []string{"example.com"},
+		AllowSubdomains: true,
+		GenerateLease:   true,
+	}))
+
+	for i := 0; i < count; i++ {
+		_, err := pki.Issue("leased", PKICertificateOptions{
+			CommonName: "leased.example.com",
+			TTL:        "1h",
+		})
+		require.NoError(t, err)
+	}
+
+	return "pki/issue/leased/"
+}
+
+func Test_Client_Leases(t *testing.T) {
+	client := getClient(t, rootTokener)
+	prefix := issueLeases(t, client, 2)
+	defer func() {
+		require.NoError(t, client.RevokePrefix(prefix))
+		require.NoError(t, client.PKIMount("pki").DeleteRole("leased"))
+	}()
+
+	ids, err := client.ListLeases(prefix)
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	lease, err := client.LookupLease(prefix + ids[0])
+	require.NoError(t, err)
+	require.Equal(t, prefix+ids[0], lease.ID)
+	require.NotEmpty(t, lease.ExpireTime)
+
+	// pki leases only exist to revoke certificates, and cannot be renewed
+	_, err = client.RenewLease(prefix+ids[0], time.Hour)
+	require.Error(t, err)
+
+	require.NoError(t, client.RevokeLease(prefix+ids[0]))
+
+	_, err = client.LookupLease(prefix + ids[0])
+	require.Error(t, err)
+
+	remaining, err := client.ListLeases(prefix)
+	require.NoError(t, err)
+	require.Equal(t, ids[1:], remaining)
+}
+
+func Test_Client_RevokePrefix(t *testing.T) {
+	client := getClient(t, rootTokener)
+	prefix := issueLeases(t, client, 2)
+	defer func() {
+		require.NoError(t, client.PKIMount("pki").DeleteRole("leased"))
+	}()
+
+	require.NoError(t, client.RevokePrefix(prefix))
+
+	// once empty, the prefix no longer exists
+	_, err := client.ListLeases(prefix)
+	require.True(t, errors.Is(err, ErrPathNotFound))
+}
+
+func Test_Client_RevokeForce(t *testing.T) {
+	client := getClient(t, rootTokener)
+	prefix := issueLeases(t, client, 1)
+	defer func() {
+		require.NoError(t, client.PKIMount("pki").DeleteRole("leased"))
+	}()
+
+	require.NoError(t, client.RevokeForce(prefix))
+
+	_, err := client.ListLeases(prefix)
+	require.True(t, errors.Is(err, ErrPathNotFound))
+
+	// my_policy1 denies the sys paths used to revoke by prefix
+	clientWithoutPerm := getClient(t, nonRenewableTokener)
+	require.Error(t, clientWithoutPerm.RevokeForce(prefix))
+	require.Error(t, clientWithoutPerm.RevokePrefix(prefix))
+}
+
+func Test_Client_TidyLeases(t *testing.T) {
+	client := getClient(t, rootTokener)
+	require.NoError(t, client.TidyLeases())
+
+	clientWithoutPerm := getClient(t, nonRenewableTokener)
+	require.Error(t, clientWithoutPerm.TidyLeases())
+}
+
+func Test_client_RenewLease(t *testing.T) {
+	var body string
+
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		require.Equal(t, "/v1/sys/leases/renew", request.URL.Path)
+		bs, _ := ioutil.ReadAll(request.Body)
+		body = string(bs)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"lease_id":"database/creds/app/abc","lease_duration":3600,"renewable":true}`,
+			)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	lease, err := client.RenewLease("database/creds/app/abc", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, `{"lease_id":"database/creds/app/abc","increment":3600}`, body)
+	require.Equal(t, RenewedLease{
+		ID:            "database/creds/app/abc",
+		LeaseDuration: 3600,
+		Renewable:     true,
+	}, lease)
+}
+
+func Test_client_ValidateMFA(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/sys/mfa/validate": `{"auth":{"client_token":"s.validated","policies":["default"]}}`,
+	})
+
+	token, err := vault.ValidateMFA("r1", map[string][]string{
+		"m1": {"123456"},
+		"m2": nil,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "s.validated", token.ID)
+
+	// methods without a passcode are sent an empty list, not null
+	require.Equal(t,
+		`{"mfa_request_id":"r1","mfa_payload":{"m1":["123456"],"m2":[]}}`,
+		vault.requests[0].Body,
+	)
+}
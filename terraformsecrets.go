@@ -0,0 +1,215 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A TerraformSecrets represents a terraform secrets engine, which
+// generates Terraform Cloud API tokens.
+//
+// More information about the terraform secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/terraform/index.html
+type TerraformSecrets interface {
+	// Configuration
+	Configure(config TerraformConfig) error
+	LookupConfig() (TerraformConfig, error)
+	DeleteConfig() error
+
+	// Roles
+	CreateRole(opts TerraformRoleOptions) error
+	LookupRole(name string) (LookedUpTerraformRole, error)
+	ListRoles() ([]string, error)
+	DeleteRole(name string) error
+
+	// Credentials
+	Credentials(role string) (TerraformCredentials, error)
+	RotateRole(name string) error
+}
+
+func (c *client) TerraformSecretsMount(mount string) TerraformSecrets {
+	return &terraformSecrets{
+		client: c,
+		mount:  "/v1/" + strings.Trim(mount, "/"),
+	}
+}
+
+type terraformSecrets struct {
+	client *client
+	mount  string
+}
+
+// TerraformConfig is the configuration of how vault connects to
+// Terraform Cloud (or Enterprise, at Address), using an API Token to
+// manage other tokens. The Token is never returned when looking up the
+// configuration.
+type TerraformConfig struct {
+	Token    string `json:"token,omitempty"`
+	Address  string `json:"address,omitempty"`
+	BasePath string `json:"base_path,omitempty"`
+}
+
+type terraformConfigWrapper struct {
+	Data TerraformConfig `json:"data"`
+}
+
+func (t *terraformSecrets) Configure(config TerraformConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// do not log the request, which contains the token
+	requestPath := t.mount + "/config"
+	if err := t.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "configuring terraform secrets at %q", requestPath)
+	}
+
+	return nil
+}
+
+func (t *terraformSecrets) LookupConfig() (TerraformConfig, error) {
+	var wrapper terraformConfigWrapper
+	if err := t.client.get(t.mount+"/config", &wrapper); err != nil {
+		return TerraformConfig{}, errors.Wrap(err, "failed to read terraform secrets config")
+	}
+	return wrapper.Data, nil
+}
+
+func (t *terraformSecrets) DeleteConfig() error {
+	if err := t.client.delete(t.mount + "/config"); err != nil {
+		return errors.Wrap(err, "failed to delete terraform secrets config")
+	}
+	return nil
+}
+
+// TerraformRoleOptions are used to define properties of a terraform
+// role being created or updated. A role generates tokens for exactly
+// one of an Organization, a team (TeamID), or a user (UserID).
+// Organization and team tokens are rotated with RotateRole rather
+// than generated per request. Durations are expressed as strings that
+// vault understands, such as "1h".
+type TerraformRoleOptions struct {
+	Name         string `json:"-"`
+	Organization string `json:"organization,omitempty"`
+	TeamID       string `json:"team_id,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+	TTL          string `json:"ttl,omitempty"`
+	MaxTTL       string `json:"max_ttl,omitempty"`
+}
+
+func (t *terraformSecrets) CreateRole(opts TerraformRoleOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling terraform role data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("%s/role/%s", t.mount, opts.Name)
+	t.client.logRequest("terraform-role-create", requestPath, bs)
+	if err := t.client.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating terraform role at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpTerraformRoleWrapper struct {
+	Data LookedUpTerraformRole `json:"data"`
+}
+
+// A LookedUpTerraformRole represents information returned from vault
+// after making a request for information about a particular terraform
+// role.
+type LookedUpTerraformRole struct {
+	Organization string `json:"organization"`
+	TeamID       string `json:"team_id"`
+	UserID       string `json:"user_id"`
+	TTL          int    `json:"ttl"`
+	MaxTTL       int    `json:"max_ttl"`
+}
+
+func (t *terraformSecrets) LookupRole(name string) (LookedUpTerraformRole, error) {
+	var wrapper lookedUpTerraformRoleWrapper
+	requestPath := fmt.Sprintf("%s/role/%s", t.mount, name)
+	if err := t.client.get(requestPath, &wrapper); err != nil {
+		return LookedUpTerraformRole{}, errors.Wrapf(err, "failed to look up terraform role %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (t *terraformSecrets) ListRoles() ([]string, error) {
+	var rolesWrapper rolesWrapper
+	requestPath := t.mount + "/role"
+	if err := t.client.list(requestPath, &rolesWrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list terraform roles at %q", requestPath)
+	}
+	sort.Strings(rolesWrapper.Data.Keys)
+	return rolesWrapper.Data.Keys, nil
+}
+
+func (t *terraformSecrets) DeleteRole(name string) error {
+	requestPath := fmt.Sprintf("%s/role/%s", t.mount, name)
+	if err := t.client.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete terraform role %q", name)
+	}
+	return nil
+}
+
+// TerraformCredentials are a Terraform Cloud API token generated by
+// vault. User tokens have a lease, while organization and team tokens
+// are shared until the role is rotated.
+type TerraformCredentials struct {
+	Token         string
+	TokenID       string
+	Organization  string
+	TeamID        string
+	LeaseID       string
+	LeaseDuration int
+	Renewable     bool
+}
+
+type terraformCredentialsWrapper struct {
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+	Data          struct {
+		Token        string `json:"token"`
+		TokenID      string `json:"token_id"`
+		Organization string `json:"organization"`
+		TeamID       string `json:"team_id"`
+	} `json:"data"`
+}
+
+func (t *terraformSecrets) Credentials(role string) (TerraformCredentials, error) {
+	var wrapper terraformCredentialsWrapper
+	requestPath := fmt.Sprintf("%s/creds/%s", t.mount, role)
+	if err := t.client.get(requestPath, &wrapper); err != nil {
+		return TerraformCredentials{}, errors.Wrapf(err, "failed to generate terraform credentials for role %q", role)
+	}
+
+	return TerraformCredentials{
+		Token:         wrapper.Data.Token,
+		TokenID:       wrapper.Data.TokenID,
+		Organization:  wrapper.Data.Organization,
+		TeamID:        wrapper.Data.TeamID,
+		LeaseID:       wrapper.LeaseID,
+		LeaseDuration: wrapper.LeaseDuration,
+		Renewable:     wrapper.Renewable,
+	}, nil
+}
+
+// RotateRole replaces the organization or team token of the
+// named role with a new token.
+func (t *terraformSecrets) RotateRole(name string) error {
+	requestPath := fmt.Sprintf("%s/rotate-role/%s", t.mount, name)
+	if err := t.client.post(requestPath, "", nil); err != nil {
+		return errors.Wrapf(err, "failed to rotate terraform role %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_terraformSecrets_Config(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/terraform/config":   "",
+		"GET /v1/terraform/config":    `{"data":{"address":"https://app.terraform.io","base_path":"/api/v2/"}}`,
+		"DELETE /v1/terraform/config": "",
+	})
+	terraform := vault.TerraformSecretsMount("terraform")
+
+	err := terraform.Configure(TerraformConfig{Token: "management"})
+	require.NoError(t, err)
+	require.Equal(t, `{"token":"management"}`, vault.requests[0].Body)
+
+	config, err := terraform.LookupConfig()
+	require.NoError(t, err)
+	require.Equal(t, TerraformConfig{
+		Address:  "https://app.terraform.io",
+		BasePath: "/api/v2/",
+	}, config)
+
+	require.NoError(t, terraform.DeleteConfig())
+}
+
+func Test_terraformSecrets_Roles(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"POST /v1/terraform/role/ops":        "",
+		"GET /v1/terraform/role/ops":         `{"data":{"organization":"example","ttl":0,"max_ttl":0}}`,
+		"LIST /v1/terraform/role":            `{"data":{"keys":["ops","dev"]}}`,
+		"DELETE /v1/terraform/role/ops":      "",
+		"POST /v1/terraform/rotate-role/ops": "",
+	})
+	terraform := vault.TerraformSecretsMount("terraform")
+
+	err := terraform.CreateRole(TerraformRoleOptions{
+		Name:         "ops",
+		Organization: "example",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"organization":"example"}`, vault.requests[0].Body)
+
+	role, err := terraform.LookupRole("ops")
+	require.NoError(t, err)
+	require.Equal(t, LookedUpTerraformRole{Organization: "example"}, role)
+
+	roles, err := terraform.ListRoles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"dev", "ops"}, roles)
+
+	require.NoError(t, terraform.RotateRole("ops"))
+	require.NoError(t, terraform.DeleteRole("ops"))
+}
+
+func Test_terraformSecrets_Credentials(t *testing.T) {
+	vault := newFakeVault(t, map[string]string{
+		"GET /v1/terraform/creds/dev": `{"lease_id":"terraform/creds/dev/abc","lease_duration":3600,"renewable":true,"data":{"token":"tfc","token_id":"at-1"}}`,
+	})
+
+	creds, err := vault.TerraformSecretsMount("terraform").Credentials("dev")
+	require.NoError(t, err)
+	require.Equal(t, TerraformCredentials{
+		Token:         "tfc",
+		TokenID:       "at-1",
+		LeaseID:       "terraform/creds/dev/abc",
+		LeaseDuration: 3600,
+		Renewable:     true,
+	}, creds)
+}
@@ -0,0 +1,112 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// TLSOptions are used to configure how a Client verifies the TLS
+// certificates of vault, and the certificate it presents to vault.
+type TLSOptions struct {
+	// CACert is the path of a PEM encoded CA certificate file used to
+	// verify the certificates of vault, instead of the system roots.
+	CACert string
+
+	// CAPath is the path of a directory of PEM encoded CA certificate
+	// files used to verify the certificates of vault, in addition to
+	// any CACert.
+	CAPath string
+
+	// ClientCert and ClientKey are the paths of the PEM encoded
+	// certificate and key presented to vault, for use with the cert
+	// auth method, or with load balancers which require mutual TLS.
+	ClientCert string
+	ClientKey  string
+
+	// ServerName is the name used to verify the certificates of vault,
+	// and is sent with SNI. By default, the host of each server is used.
+	ServerName string
+
+	// MinVersion is the minimum version of TLS used to communicate
+	// with vault, e.g. tls.VersionTLS13. By default, this value
+	// is tls.VersionTLS12.
+	MinVersion uint16
+
+	// Config may be optionally configured with the tls.Config used to
+	// communicate with vault. If set, the other TLSOptions, as well as
+	// the SkipTLSVerification of the ClientOptions, are ignored.
+	Config *tls.Config
+}
+
+func (o TLSOptions) config(skipVerification bool) (*tls.Config, error) {
+	if o.Config != nil {
+		return o.Config, nil
+	}
+
+	config := &tls.Config{
+		InsecureSkipVerify: skipVerification,
+		ServerName:         o.ServerName,
+		MinVersion:         o.MinVersion,
+	}
+
+	if config.MinVersion == 0 {
+		config.MinVersion = tls.VersionTLS12
+	}
+
+	if o.CACert != "" || o.CAPath != "" {
+		pool, err := o.caPool()
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	if o.ClientCert != "" || o.ClientKey != "" {
+		certificate, err := tls.LoadX509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client certificate")
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+func (o TLSOptions) caPool() (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+
+	var files []string
+	if o.CACert != "" {
+		files = append(files, o.CACert)
+	}
+
+	if o.CAPath != "" {
+		infos, err := ioutil.ReadDir(o.CAPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CA path %q", o.CAPath)
+		}
+		for _, info := range infos {
+			if !info.IsDir() {
+				files = append(files, filepath.Join(o.CAPath, info.Name()))
+			}
+		}
+	}
+
+	for _, file := range files {
+		bs, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CA certificate %q", file)
+		}
+		if !pool.AppendCertsFromPEM(bs) {
+			return nil, errors.Errorf("no PEM encoded CA certificates in %q", file)
+		}
+	}
+
+	return pool, nil
+}
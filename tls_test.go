@@ -0,0 +1,93 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self signed certificate and its
+// key into dir, returning the paths of both files
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "vault.example.com"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	err = ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	require.NoError(t, err)
+
+	return certFile, keyFile
+}
+
+func Test_TLSOptions_config(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vaultapi-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeCertificate(t, dir)
+
+	config, err := TLSOptions{}.config(true)
+	require.NoError(t, err)
+	require.True(t, config.InsecureSkipVerify)
+	require.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	require.Nil(t, config.RootCAs)
+
+	config, err = TLSOptions{
+		CACert:     certFile,
+		ClientCert: certFile,
+		ClientKey:  keyFile,
+		ServerName: "vault.example.com",
+		MinVersion: tls.VersionTLS13,
+	}.config(false)
+	require.NoError(t, err)
+	require.False(t, config.InsecureSkipVerify)
+	require.NotNil(t, config.RootCAs)
+	require.Len(t, config.Certificates, 1)
+	require.Equal(t, "vault.example.com", config.ServerName)
+	require.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+
+	// the key file is not a CA certificate
+	_, err = TLSOptions{CAPath: dir}.config(false)
+	require.Error(t, err)
+
+	_, err = TLSOptions{ClientCert: certFile}.config(false)
+	require.Error(t, err)
+
+	// an explicit config is used as is
+	explicit := &tls.Config{ServerName: "other"}
+	config, err = TLSOptions{CACert: "/does/not/exist", Config: explicit}.config(true)
+	require.NoError(t, err)
+	require.Equal(t, explicit, config)
+}
@@ -0,0 +1,127 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"time"
+
+	"github.com/shoenig/vaultapi/internal/watch"
+)
+
+// TokenWatcherOptions are used to configure a TokenWatcher.
+type TokenWatcherOptions struct {
+	// Increment is the lease extension requested upon each renewal.
+	// Vault may grant less, e.g. when the token nears its max TTL.
+	// By default, the token is renewed by its own period or TTL.
+	Increment time.Duration
+
+	// Jitter is the fraction of time by which renewals are randomly
+	// moved earlier, so that many clients created at once do not all
+	// renew at once. By default, this value is 0.1.
+	Jitter float64
+
+	// RetryDelay configures how long to wait after a failed renewal
+	// before trying again. By default, this value is 5 seconds.
+	RetryDelay time.Duration
+}
+
+// A TokenEvent is emitted by a TokenWatcher after each attempt to
+// renew the token. Exactly one of Renewed, Err, or Expired is set.
+type TokenEvent struct {
+	// Renewed is the result of a successful renewal.
+	Renewed *RenewedToken
+
+	// Err is the reason a renewal failed. The renewal will be
+	// retried until the token expires.
+	Err error
+
+	// Expired is set once the token can no longer be renewed and
+	// its lease has run out. No more events follow an expiration.
+	Expired bool
+}
+
+// A TokenWatcher renews the token of a Client in the background
+// before the lease of the token expires, emitting a TokenEvent on
+// the Events channel after each attempt.
+type TokenWatcher struct {
+	client  Client
+	opts    TokenWatcherOptions
+	watcher *watch.Watcher[TokenEvent]
+}
+
+// NewTokenWatcher creates a TokenWatcher that will renew the token
+// used by client, which must be renewable. Call Start to begin
+// renewing, and Stop to stop.
+func NewTokenWatcher(client Client, opts TokenWatcherOptions) *TokenWatcher {
+	if opts.Jitter <= 0 || opts.Jitter >= 1 {
+		opts.Jitter = watch.DefaultJitter
+	}
+
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = watch.DefaultRetryDelay
+	}
+
+	return &TokenWatcher{
+		client:  client,
+		opts:    opts,
+		watcher: watch.New(opts.RetryDelay, TokenEvent{Expired: true}),
+	}
+}
+
+// Events returns the channel on which a TokenEvent is emitted after
+// each renewal attempt. The channel must be read from, as renewals
+// wait on events being received. The channel is closed once the
+// TokenWatcher is done, because it was stopped, the token expired,
+// or the token does not expire.
+func (w *TokenWatcher) Events() <-chan TokenEvent {
+	return w.watcher.Events()
+}
+
+// Start begins renewing the token in the background.
+func (w *TokenWatcher) Start() {
+	w.watcher.Start(w.run)
+}
+
+// Stop stops renewing the token. It is safe to call Stop
+// more than once.
+func (w *TokenWatcher) Stop() {
+	w.watcher.Stop()
+}
+
+func (w *TokenWatcher) run() {
+	// until the first renewal we do not know when the token
+	// expires, so keep trying until the watcher is stopped
+	var expires time.Time
+
+	for {
+		renewed, err := w.client.RenewSelfToken(w.opts.Increment)
+		if err != nil {
+			if !w.watcher.Retry(TokenEvent{Err: err}, expires) {
+				return
+			}
+			continue
+		}
+
+		if !w.watcher.Emit(TokenEvent{Renewed: &renewed}) {
+			return
+		}
+
+		lease := time.Duration(renewed.LeaseDuration) * time.Second
+		if lease <= 0 {
+			// the token does not expire, nothing to do
+			return
+		}
+		expires = time.Now().Add(lease)
+
+		// vault grants less than requested once the token reaches its
+		// max TTL, at which point renewing is no longer useful
+		if !renewed.Renewable || (w.opts.Increment > 0 && lease < w.opts.Increment/2) {
+			w.watcher.Expire(expires)
+			return
+		}
+
+		if !w.watcher.Sleep(watch.RenewDelay(lease, w.opts.Jitter)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// renewer is a Client which only implements RenewSelfToken
+type renewer struct {
+	Client
+	results []RenewedToken
+	errs    []error
+}
+
+func (r *renewer) RenewSelfToken(time.Duration) (RenewedToken, error) {
+	result, err := r.results[0], r.errs[0]
+	if len(r.results) > 1 {
+		r.results, r.errs = r.results[1:], r.errs[1:]
+	}
+	return result, err
+}
+
+func Test_TokenWatcher_renew(t *testing.T) {
+	client := &renewer{
+		results: []RenewedToken{{LeaseDuration: 1, Renewable: true}},
+		errs:    []error{nil},
+	}
+
+	watcher := NewTokenWatcher(client, TokenWatcherOptions{})
+	watcher.Start()
+	defer watcher.Stop()
+
+	for i := 0; i < 2; i++ {
+		event := <-watcher.Events()
+		require.NoError(t, event.Err)
+		require.False(t, event.Expired)
+		require.Equal(t, 1, event.Renewed.LeaseDuration)
+	}
+}
+
+func Test_TokenWatcher_expire(t *testing.T) {
+	client := &renewer{
+		results: []RenewedToken{{LeaseDuration: 1, Renewable: true}, {}},
+		errs:    []error{nil, errors.New("permission denied")},
+	}
+
+	watcher := NewTokenWatcher(client, TokenWatcherOptions{
+		RetryDelay: 100 * time.Millisecond,
+	})
+	watcher.Start()
+	defer watcher.Stop()
+
+	event := <-watcher.Events()
+	require.NotNil(t, event.Renewed)
+
+	// renewals fail until the lease runs out
+	for event = range watcher.Events() {
+		if event.Expired {
+			break
+		}
+		require.Error(t, event.Err)
+	}
+	require.True(t, event.Expired)
+
+	// no more events follow an expiration
+	_, open := <-watcher.Events()
+	require.False(t, open)
+}
+
+func Test_TokenWatcher_stop(t *testing.T) {
+	client := &renewer{
+		results: []RenewedToken{{LeaseDuration: 60, Renewable: true}},
+		errs:    []error{nil},
+	}
+
+	watcher := NewTokenWatcher(client, TokenWatcherOptions{})
+	watcher.Start()
+
+	event := <-watcher.Events()
+	require.NotNil(t, event.Renewed)
+
+	// the events are closed once the watcher is stopped
+	watcher.Stop()
+	_, open := <-watcher.Events()
+	require.False(t, open)
+}
@@ -0,0 +1,453 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Transit provides encryption as a service through the transit
+// secrets engine. Data is encrypted and decrypted by vault using
+// named keys which never leave vault.
+//
+// The transit engine must be mounted at the default path of
+// transit/ for this API to work.
+//
+// More information about the transit secrets engine can be found here:
+// https://www.vaultproject.io/docs/secrets/transit/index.html
+type Transit interface {
+	Encrypt(key string, plaintext []byte, opts TransitOptions) (string, error)
+	Decrypt(key, ciphertext string, opts TransitOptions) ([]byte, error)
+	Rewrap(key, ciphertext string, opts TransitOptions) (string, error)
+	HMAC(key string, input []byte, algorithm string) (string, error)
+	VerifyHMAC(key string, input []byte, hmac, algorithm string) (bool, error)
+	GenerateDataKey(key, dataKeyType string, opts DataKeyOptions) (DataKey, error)
+	RandomBytes(n int) ([]byte, error)
+	Hash(input []byte, algorithm string) ([]byte, error)
+
+	EncryptBatch(key string, items []TransitBatchItem) ([]TransitBatchResult, error)
+	DecryptBatch(key string, items []TransitBatchItem) ([]TransitBatchResult, error)
+	RewrapBatch(key string, items []TransitBatchItem, keyVersion int) ([]TransitBatchResult, error)
+	SignBatch(key string, items []TransitBatchItem, algorithm string) ([]TransitBatchResult, error)
+	VerifyBatch(key string, items []TransitBatchItem, algorithm string) ([]TransitBatchResult, error)
+
+	CreateTransitKey(opts TransitKeyOptions) error
+	LookupTransitKey(name string) (TransitKey, error)
+	ListTransitKeys() ([]string, error)
+	DeleteTransitKey(name string) error
+	RotateTransitKey(name string) error
+	ConfigureTransitKey(name string, config TransitKeyConfig) error
+	TrimTransitKey(name string, minAvailableVersion int) error
+}
+
+// TransitOptions are optional parameters of transit operations.
+//
+// Context is required for keys which were created with key
+// derivation enabled, and the same Context must be provided to
+// decrypt. Nonce is only used with convergent encryption. KeyVersion
+// selects the version of the key used to encrypt, which defaults to
+// the latest version.
+type TransitOptions struct {
+	Context    []byte `json:"context,omitempty"`
+	Nonce      []byte `json:"nonce,omitempty"`
+	KeyVersion int    `json:"key_version,omitempty"`
+}
+
+type transitEncrypt struct {
+	// []byte is marshalled as base64, which is what vault expects
+	Plaintext []byte `json:"plaintext"`
+	TransitOptions
+}
+
+type transitCiphertextWrapper struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+	} `json:"data"`
+}
+
+func (c *client) Encrypt(key string, plaintext []byte, opts TransitOptions) (string, error) {
+	bs, err := json.Marshal(transitEncrypt{
+		Plaintext:      plaintext,
+		TransitOptions: opts,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var wrapper transitCiphertextWrapper
+	requestPath := fmt.Sprintf("/v1/transit/encrypt/%s", key)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return "", errors.Wrapf(err, "failed to encrypt with transit key %q", key)
+	}
+
+	return wrapper.Data.Ciphertext, nil
+}
+
+type transitDecrypt struct {
+	Ciphertext string `json:"ciphertext"`
+	TransitOptions
+}
+
+type transitPlaintextWrapper struct {
+	Data struct {
+		Plaintext []byte `json:"plaintext"`
+	} `json:"data"`
+}
+
+func (c *client) Decrypt(key, ciphertext string, opts TransitOptions) ([]byte, error) {
+	// the key version is embedded in the ciphertext
+	opts.KeyVersion = 0
+
+	bs, err := json.Marshal(transitDecrypt{
+		Ciphertext:     ciphertext,
+		TransitOptions: opts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapper transitPlaintextWrapper
+	requestPath := fmt.Sprintf("/v1/transit/decrypt/%s", key)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt with transit key %q", key)
+	}
+
+	return wrapper.Data.Plaintext, nil
+}
+
+// Rewrap re-encrypts ciphertext with the given version of the key
+// (the latest version by default), without revealing the plaintext.
+// This is used to upgrade ciphertext after the key has been rotated.
+func (c *client) Rewrap(key, ciphertext string, opts TransitOptions) (string, error) {
+	bs, err := json.Marshal(transitDecrypt{
+		Ciphertext:     ciphertext,
+		TransitOptions: opts,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var wrapper transitCiphertextWrapper
+	requestPath := fmt.Sprintf("/v1/transit/rewrap/%s", key)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return "", errors.Wrapf(err, "failed to rewrap with transit key %q", key)
+	}
+
+	return wrapper.Data.Ciphertext, nil
+}
+
+type transitHMAC struct {
+	Input []byte `json:"input"`
+	HMAC  string `json:"hmac,omitempty"`
+}
+
+type transitHMACWrapper struct {
+	Data struct {
+		HMAC string `json:"hmac"`
+	} `json:"data"`
+}
+
+// transitAlgorithmPath appends the optional hash algorithm to
+// the path of a transit endpoint
+func transitAlgorithmPath(endpoint, key, algorithm string) string {
+	requestPath := fmt.Sprintf("/v1/transit/%s/%s", endpoint, key)
+	if algorithm != "" {
+		requestPath += "/" + algorithm
+	}
+	return requestPath
+}
+
+// HMAC computes the HMAC of input with the named key, using the
+// given hash algorithm (e.g. "sha2-256", the default if empty).
+func (c *client) HMAC(key string, input []byte, algorithm string) (string, error) {
+	bs, err := json.Marshal(transitHMAC{Input: input})
+	if err != nil {
+		return "", err
+	}
+
+	var wrapper transitHMACWrapper
+	requestPath := transitAlgorithmPath("hmac", key, algorithm)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return "", errors.Wrapf(err, "failed to compute hmac with transit key %q", key)
+	}
+
+	return wrapper.Data.HMAC, nil
+}
+
+type transitVerifyWrapper struct {
+	Data struct {
+		Valid bool `json:"valid"`
+	} `json:"data"`
+}
+
+// VerifyHMAC checks that hmac is the HMAC of input with the named key
+// using the given hash algorithm, as computed by HMAC.
+func (c *client) VerifyHMAC(key string, input []byte, hmac, algorithm string) (bool, error) {
+	bs, err := json.Marshal(transitHMAC{Input: input, HMAC: hmac})
+	if err != nil {
+		return false, err
+	}
+
+	var wrapper transitVerifyWrapper
+	requestPath := transitAlgorithmPath("verify", key, algorithm)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return false, errors.Wrapf(err, "failed to verify hmac with transit key %q", key)
+	}
+
+	return wrapper.Data.Valid, nil
+}
+
+const (
+	// DataKeyPlaintext is the type of data key which is returned
+	// both in plaintext and encrypted by the named key.
+	DataKeyPlaintext = "plaintext"
+
+	// DataKeyWrapped is the type of data key which is returned
+	// only encrypted by the named key.
+	DataKeyWrapped = "wrapped"
+)
+
+// DataKeyOptions are optional parameters of data key generation.
+// Context and Nonce are used the same as in TransitOptions. Bits is
+// the length of the data key, and may be 128, 256 (the default), or
+// 512 bits.
+type DataKeyOptions struct {
+	Context []byte `json:"context,omitempty"`
+	Nonce   []byte `json:"nonce,omitempty"`
+	Bits    int    `json:"bits,omitempty"`
+}
+
+// A DataKey is a new high-entropy key generated by vault for use
+// with envelope encryption. The Ciphertext is the key encrypted by
+// the named transit key, to be stored alongside the data encrypted
+// locally with the Plaintext. The Plaintext is only set for data
+// keys of type DataKeyPlaintext.
+type DataKey struct {
+	Plaintext  []byte `json:"plaintext"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+type dataKeyWrapper struct {
+	Data DataKey `json:"data"`
+}
+
+func (c *client) GenerateDataKey(key, dataKeyType string, opts DataKeyOptions) (DataKey, error) {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return DataKey{}, err
+	}
+
+	var wrapper dataKeyWrapper
+	requestPath := fmt.Sprintf("/v1/transit/datakey/%s/%s", dataKeyType, key)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return DataKey{}, errors.Wrapf(err, "failed to generate %s data key with transit key %q", dataKeyType, key)
+	}
+
+	return wrapper.Data, nil
+}
+
+// asking for base64 lets the []byte fields decode directly
+type randomBytesRequest struct {
+	Format string `json:"format"`
+}
+
+type randomBytesWrapper struct {
+	Data struct {
+		RandomBytes []byte `json:"random_bytes"`
+	} `json:"data"`
+}
+
+func (c *client) randomBytes(prefix string, n int) ([]byte, error) {
+	bs, err := json.Marshal(randomBytesRequest{Format: "base64"})
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapper randomBytesWrapper
+	requestPath := fmt.Sprintf("%s/random/%d", prefix, n)
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to generate %d random bytes", n)
+	}
+
+	return wrapper.Data.RandomBytes, nil
+}
+
+type hashRequest struct {
+	Input  []byte `json:"input"`
+	Format string `json:"format"`
+}
+
+type hashWrapper struct {
+	Data struct {
+		Sum []byte `json:"sum"`
+	} `json:"data"`
+}
+
+func (c *client) hash(prefix string, input []byte, algorithm string) ([]byte, error) {
+	bs, err := json.Marshal(hashRequest{Input: input, Format: "base64"})
+	if err != nil {
+		return nil, err
+	}
+
+	requestPath := prefix + "/hash"
+	if algorithm != "" {
+		requestPath += "/" + algorithm
+	}
+
+	var wrapper hashWrapper
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return nil, errors.Wrap(err, "failed to compute hash")
+	}
+
+	return wrapper.Data.Sum, nil
+}
+
+// RandomBytes returns n bytes generated by the CSPRNG of vault.
+func (c *client) RandomBytes(n int) ([]byte, error) {
+	return c.randomBytes("/v1/transit", n)
+}
+
+// Hash computes the hash of input with the given algorithm (e.g.
+// "sha2-256", the default if empty) on the vault server.
+func (c *client) Hash(input []byte, algorithm string) ([]byte, error) {
+	return c.hash("/v1/transit", input, algorithm)
+}
+
+// TransitKeyOptions are used to define properties of a transit
+// key being created. The Type defaults to "aes256-gcm96". Derived
+// keys require a context for every operation. Exportable keys may
+// be exported from vault, which cannot be undone.
+type TransitKeyOptions struct {
+	Name                 string `json:"-"`
+	Type                 string `json:"type,omitempty"`
+	Derived              bool   `json:"derived,omitempty"`
+	ConvergentEncryption bool   `json:"convergent_encryption,omitempty"`
+	Exportable           bool   `json:"exportable,omitempty"`
+	AllowPlaintextBackup bool   `json:"allow_plaintext_backup,omitempty"`
+}
+
+func (c *client) CreateTransitKey(opts TransitKeyOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling transit key data to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/transit/keys/%s", opts.Name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating transit key at %q", requestPath)
+	}
+
+	return nil
+}
+
+type transitKeyWrapper struct {
+	Data TransitKey `json:"data"`
+}
+
+// A TransitKey represents information returned from vault after
+// making a request for information about a particular transit key.
+// The Keys are indexed by key version. For symmetric keys, each
+// is the creation time of that version; for asymmetric keys, each
+// contains the public key of that version.
+type TransitKey struct {
+	Name                 string              `json:"name"`
+	Type                 string              `json:"type"`
+	Derived              bool                `json:"derived"`
+	Exportable           bool                `json:"exportable"`
+	DeletionAllowed      bool                `json:"deletion_allowed"`
+	LatestVersion        int                 `json:"latest_version"`
+	MinDecryptionVersion int                 `json:"min_decryption_version"`
+	MinEncryptionVersion int                 `json:"min_encryption_version"`
+	MinAvailableVersion  int                 `json:"min_available_version"`
+	SupportsEncryption   bool                `json:"supports_encryption"`
+	SupportsDecryption   bool                `json:"supports_decryption"`
+	SupportsDerivation   bool                `json:"supports_derivation"`
+	SupportsSigning      bool                `json:"supports_signing"`
+	Keys                 map[int]interface{} `json:"keys"`
+}
+
+func (c *client) LookupTransitKey(name string) (TransitKey, error) {
+	var wrapper transitKeyWrapper
+	requestPath := fmt.Sprintf("/v1/transit/keys/%s", name)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return TransitKey{}, errors.Wrapf(err, "failed to look up transit key %q", name)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ListTransitKeys() ([]string, error) {
+	var wrapper rolesWrapper
+	requestPath := "/v1/transit/keys"
+	if err := c.list(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list transit keys at %q", requestPath)
+	}
+	sort.Strings(wrapper.Data.Keys)
+	return wrapper.Data.Keys, nil
+}
+
+// DeleteTransitKey deletes the named key, which is only possible
+// once the key has been configured to allow deletion.
+func (c *client) DeleteTransitKey(name string) error {
+	requestPath := fmt.Sprintf("/v1/transit/keys/%s", name)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete transit key %q", name)
+	}
+	return nil
+}
+
+func (c *client) RotateTransitKey(name string) error {
+	requestPath := fmt.Sprintf("/v1/transit/keys/%s/rotate", name)
+	if err := c.post(requestPath, "", nil); err != nil {
+		return errors.Wrapf(err, "failed to rotate transit key %q", name)
+	}
+	return nil
+}
+
+// TransitKeyConfig is the configuration of a transit key. The
+// min versions restrict which versions of the key may be used, and
+// are left unchanged when zero. DeletionAllowed is always set.
+type TransitKeyConfig struct {
+	MinDecryptionVersion int  `json:"min_decryption_version,omitempty"`
+	MinEncryptionVersion int  `json:"min_encryption_version,omitempty"`
+	DeletionAllowed      bool `json:"deletion_allowed"`
+	Exportable           bool `json:"exportable,omitempty"`
+}
+
+func (c *client) ConfigureTransitKey(name string, config TransitKeyConfig) error {
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "marshalling transit key config to JSON request body")
+	}
+
+	requestPath := fmt.Sprintf("/v1/transit/keys/%s/config", name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to configure transit key %q", name)
+	}
+
+	return nil
+}
+
+type transitTrim struct {
+	MinAvailableVersion int `json:"min_available_version"`
+}
+
+// TrimTransitKey permanently removes all versions of the named key
+// older than minAvailableVersion, which must not be greater than the
+// min decryption version of the key.
+func (c *client) TrimTransitKey(name string, minAvailableVersion int) error {
+	bs, err := json.Marshal(transitTrim{MinAvailableVersion: minAvailableVersion})
+	if err != nil {
+		return err
+	}
+
+	requestPath := fmt.Sprintf("/v1/transit/keys/%s/trim", name)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to trim transit key %q", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Transit_EncryptDecrypt(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	// encrypting with a key that does not exist creates it
+	ciphertext, err := client.Encrypt("test-key1", []byte("hello world"), TransitOptions{})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(ciphertext, "vault:v1:"))
+
+	plaintext, err := client.Decrypt("test-key1", ciphertext, TransitOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(plaintext))
+
+	// a client without permission cannot decrypt
+	other := getClient(t, renewableTokener)
+	_, err = other.Decrypt("test-key1", ciphertext, TransitOptions{})
+	require.Error(t, err)
+}
+
+func Test_Transit_HMAC(t *testing.T) {
+	client := getClient(t, rootTokener)
+	require.NoError(t, client.CreateTransitKey(TransitKeyOptions{Name: "test-hmac1"}))
+
+	hmac, err := client.HMAC("test-hmac1", []byte("payload"), "sha2-512")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hmac, "vault:v1:"))
+
+	valid, err := client.VerifyHMAC("test-hmac1", []byte("payload"), hmac, "sha2-512")
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = client.VerifyHMAC("test-hmac1", []byte("tampered"), hmac, "sha2-512")
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func Test_Transit_DataKey(t *testing.T) {
+	client := getClient(t, rootTokener)
+	require.NoError(t, client.CreateTransitKey(TransitKeyOptions{Name: "test-datakey1"}))
+
+	dataKey, err := client.GenerateDataKey("test-datakey1", DataKeyPlaintext, DataKeyOptions{Bits: 128})
+	require.NoError(t, err)
+	require.Len(t, dataKey.Plaintext, 16)
+
+	// the ciphertext decrypts to the plaintext data key
+	plaintext, err := client.Decrypt("test-datakey1", dataKey.Ciphertext, TransitOptions{})
+	require.NoError(t, err)
+	require.Equal(t, dataKey.Plaintext, plaintext)
+
+	wrapped, err := client.GenerateDataKey("test-datakey1", DataKeyWrapped, DataKeyOptions{})
+	require.NoError(t, err)
+	require.Empty(t, wrapped.Plaintext)
+	require.NotEmpty(t, wrapped.Ciphertext)
+}
+
+func Test_Transit_Batch(t *testing.T) {
+	client := getClient(t, rootTokener)
+	require.NoError(t, client.CreateTransitKey(TransitKeyOptions{Name: "test-batch1"}))
+
+	encrypted, err := client.EncryptBatch("test-batch1", []TransitBatchItem{
+		{Plaintext: []byte("one")},
+		{Plaintext: []byte("two")},
+	})
+	require.NoError(t, err)
+	require.Len(t, encrypted, 2)
+
+	decrypted, err := client.DecryptBatch("test-batch1", []TransitBatchItem{
+		{Ciphertext: encrypted[0].Ciphertext},
+		{Ciphertext: "vault:v1:garbage"},
+		{Ciphertext: encrypted[1].Ciphertext},
+	})
+	require.NoError(t, err)
+	require.Len(t, decrypted, 3)
+	require.Equal(t, "one", string(decrypted[0].Plaintext))
+	require.NotEmpty(t, decrypted[1].Error)
+	require.Equal(t, "two", string(decrypted[2].Plaintext))
+
+	// a batch rejected as a whole has no results to return
+	_, err = client.DecryptBatch("test-batch-missing", []TransitBatchItem{
+		{Ciphertext: encrypted[0].Ciphertext},
+	})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func Test_client_transitBatch_rejected(t *testing.T) {
+	opts := devOpts()
+	opts.Transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"errors":["encryption key not found"]}`)),
+		}, nil
+	})
+
+	client, err := New(opts, NewStaticToken("token"))
+	require.NoError(t, err)
+
+	_, err = client.DecryptBatch("missing", []TransitBatchItem{{Ciphertext: "vault:v1:abc"}})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Equal(t, []string{"encryption key not found"}, apiErr.Errors)
+}
+
+func Test_Transit_Keys(t *testing.T) {
+	client := getClient(t, rootTokener)
+	opts := TransitKeyOptions{
+		Name:    "test-key2",
+		Derived: true,
+	}
+
+	require.NoError(t, client.CreateTransitKey(opts))
+
+	keys, err := client.ListTransitKeys()
+	require.NoError(t, err)
+	require.Contains(t, keys, opts.Name)
+
+	key, err := client.LookupTransitKey(opts.Name)
+	require.NoError(t, err)
+	require.True(t, key.Derived)
+	require.False(t, key.DeletionAllowed)
+	require.Equal(t, 1, key.LatestVersion)
+
+	// derived keys require a context
+	_, err = client.Encrypt(opts.Name, []byte("hello"), TransitOptions{})
+	require.Error(t, err)
+	ciphertext, err := client.Encrypt(opts.Name, []byte("hello"), TransitOptions{Context: []byte("ctx")})
+	require.NoError(t, err)
+
+	require.NoError(t, client.RotateTransitKey(opts.Name))
+	key, err = client.LookupTransitKey(opts.Name)
+	require.NoError(t, err)
+	require.Equal(t, 2, key.LatestVersion)
+	require.Len(t, key.Keys, 2)
+
+	// old ciphertext can still be decrypted after rotation
+	plaintext, err := client.Decrypt(opts.Name, ciphertext, TransitOptions{Context: []byte("ctx")})
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(plaintext))
+
+	// old ciphertext can be upgraded to the latest key version
+	rewrapped, err := client.Rewrap(opts.Name, ciphertext, TransitOptions{Context: []byte("ctx")})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(rewrapped, "vault:v2:"))
+	plaintext, err = client.Decrypt(opts.Name, rewrapped, TransitOptions{Context: []byte("ctx")})
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(plaintext))
+
+	// keys cannot be deleted until configured to allow deletion
+	require.Error(t, client.DeleteTransitKey(opts.Name))
+	require.NoError(t, client.ConfigureTransitKey(opts.Name, TransitKeyConfig{DeletionAllowed: true}))
+	require.NoError(t, client.DeleteTransitKey(opts.Name))
+
+	_, err = client.LookupTransitKey(opts.Name)
+	require.Equal(t, ErrPathNotFound, errors.Cause(err))
+}
+
+func Test_Transit_RandomHash(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	random, err := client.RandomBytes(32)
+	require.NoError(t, err)
+	require.Len(t, random, 32)
+
+	sum, err := client.Hash([]byte("hello"), "sha2-256")
+	require.NoError(t, err)
+	expected := sha256.Sum256([]byte("hello"))
+	require.Equal(t, expected[:], sum)
+}
@@ -0,0 +1,109 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// A TransitBatchItem is one item of a batch transit operation. Which
+// of the fields are used depends on the operation:
+//
+//   - EncryptBatch uses Plaintext, Context, Nonce, and KeyVersion
+//   - DecryptBatch uses Ciphertext, Context, and Nonce
+//   - RewrapBatch uses Ciphertext, Context, and Nonce
+//   - SignBatch uses Input and Context
+//   - VerifyBatch uses Input, Context, and Signature
+type TransitBatchItem struct {
+	Plaintext  []byte `json:"plaintext,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+	Input      []byte `json:"input,omitempty"`
+	Signature  string `json:"signature,omitempty"`
+	Context    []byte `json:"context,omitempty"`
+	Nonce      []byte `json:"nonce,omitempty"`
+	KeyVersion int    `json:"key_version,omitempty"`
+}
+
+// A TransitBatchResult is the result of one item of a batch transit
+// operation, in the same order as the items of the batch. If the item
+// failed, Error describes why and the other fields are not set.
+type TransitBatchResult struct {
+	Ciphertext string `json:"ciphertext"`
+	Plaintext  []byte `json:"plaintext"`
+	Signature  string `json:"signature"`
+	Valid      bool   `json:"valid"`
+	KeyVersion int    `json:"key_version"`
+	Error      string `json:"error"`
+}
+
+type transitBatch struct {
+	Items      []TransitBatchItem `json:"batch_input"`
+	KeyVersion int                `json:"key_version,omitempty"`
+}
+
+type transitBatchWrapper struct {
+	Data struct {
+		Results []TransitBatchResult `json:"batch_results"`
+	} `json:"data"`
+}
+
+// vault responds with a bad request status if any item of the batch
+// failed, but the results still describe every item, unless the batch
+// as a whole was rejected
+func (w *transitBatchWrapper) partial() bool {
+	return len(w.Data.Results) > 0
+}
+
+func (c *client) transitBatch(operation, requestPath string, batch transitBatch) ([]TransitBatchResult, error) {
+	bs, err := json.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapper transitBatchWrapper
+	if err := c.post(requestPath, string(bs), &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to %s batch at %q", operation, requestPath)
+	}
+
+	if len(wrapper.Data.Results) != len(batch.Items) {
+		// the request as a whole was rejected
+		return nil, errors.Errorf("failed to %s batch at %q: expected %d results, got %d",
+			operation, requestPath, len(batch.Items), len(wrapper.Data.Results))
+	}
+
+	return wrapper.Data.Results, nil
+}
+
+func (c *client) EncryptBatch(key string, items []TransitBatchItem) ([]TransitBatchResult, error) {
+	requestPath := fmt.Sprintf("/v1/transit/encrypt/%s", key)
+	return c.transitBatch("encrypt", requestPath, transitBatch{Items: items})
+}
+
+func (c *client) DecryptBatch(key string, items []TransitBatchItem) ([]TransitBatchResult, error) {
+	requestPath := fmt.Sprintf("/v1/transit/decrypt/%s", key)
+	return c.transitBatch("decrypt", requestPath, transitBatch{Items: items})
+}
+
+// RewrapBatch re-encrypts each ciphertext with the given version of
+// the key, where a keyVersion of 0 means the latest version.
+func (c *client) RewrapBatch(key string, items []TransitBatchItem, keyVersion int) ([]TransitBatchResult, error) {
+	requestPath := fmt.Sprintf("/v1/transit/rewrap/%s", key)
+	return c.transitBatch("rewrap", requestPath, transitBatch{Items: items, KeyVersion: keyVersion})
+}
+
+// SignBatch signs each input with the named asymmetric key, using the
+// given hash algorithm (e.g. "sha2-256", the default if empty).
+func (c *client) SignBatch(key string, items []TransitBatchItem, algorithm string) ([]TransitBatchResult, error) {
+	requestPath := transitAlgorithmPath("sign", key, algorithm)
+	return c.transitBatch("sign", requestPath, transitBatch{Items: items})
+}
+
+// VerifyBatch verifies the signature of each input with the named
+// asymmetric key, using the hash algorithm used by SignBatch.
+func (c *client) VerifyBatch(key string, items []TransitBatchItem, algorithm string) ([]TransitBatchResult, error) {
+	requestPath := transitAlgorithmPath("verify", key, algorithm)
+	return c.transitBatch("verify", requestPath, transitBatch{Items: items})
+}
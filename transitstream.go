@@ -0,0 +1,261 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Streams are encrypted locally with a data key generated by vault,
+// so that arbitrarily large data never passes through vault itself.
+// A stream is laid out as
+//
+//	magic | uint16 length | wrapped data key | chunk | chunk | ...
+//
+// where each chunk is a uint32 length followed by up to
+// streamChunkSize bytes of plaintext sealed with AES-GCM. The high bit
+// of the chunk length marks the final chunk. The nonce of each chunk
+// is its index and the final flag, so chunks cannot be reordered,
+// dropped, or truncated without failing authentication. The header is
+// used as additional data for every chunk.
+const (
+	streamMagic     = "VTS1"
+	streamChunkSize = 64 * 1024
+	streamFinalBit  = 1 << 31
+)
+
+var (
+	// ErrStreamCorrupt is returned when reading an encrypted stream
+	// which is not well formed, or which fails authentication.
+	ErrStreamCorrupt = errors.New("encrypted stream is corrupt")
+)
+
+func streamNonce(index uint64, final bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce, index)
+	if final {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+func streamCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+type encryptingWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	index  uint64
+	closed bool
+}
+
+// NewEncryptingWriter returns a writer which encrypts everything
+// written to it and writes the result to w. A new data key is
+// generated with the named transit key, and written to w in its
+// wrapped form ahead of the encrypted data. The stream is not complete
+// until Close is called, which does not close w.
+//
+// The stream can be decrypted with NewDecryptingReader.
+func NewEncryptingWriter(transit Transit, key string, w io.Writer) (io.WriteCloser, error) {
+	dataKey, err := transit.GenerateDataKey(key, DataKeyPlaintext, DataKeyOptions{Bits: 256})
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := streamCipher(dataKey.Plaintext)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create stream cipher")
+	}
+
+	if len(dataKey.Ciphertext) > 0xffff {
+		return nil, errors.New("wrapped data key is too long")
+	}
+
+	var header bytes.Buffer
+	header.WriteString(streamMagic)
+	_ = binary.Write(&header, binary.BigEndian, uint16(len(dataKey.Ciphertext)))
+	header.WriteString(dataKey.Ciphertext)
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return nil, errors.Wrap(err, "failed to write stream header")
+	}
+
+	return &encryptingWriter{
+		w:      w,
+		aead:   aead,
+		header: header.Bytes(),
+		buf:    make([]byte, 0, streamChunkSize),
+	}, nil
+}
+
+func (e *encryptingWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed stream")
+	}
+
+	n := 0
+	for len(p) > 0 {
+		// a full chunk is only sealed once more data arrives, since
+		// until then it may turn out to be the final chunk
+		if len(e.buf) == streamChunkSize {
+			if err := e.seal(false); err != nil {
+				return n, err
+			}
+		}
+
+		m := copy(e.buf[len(e.buf):streamChunkSize], p)
+		e.buf = e.buf[:len(e.buf)+m]
+		p = p[m:]
+		n += m
+	}
+
+	return n, nil
+}
+
+func (e *encryptingWriter) seal(final bool) error {
+	sealed := e.aead.Seal(nil, streamNonce(e.index, final), e.buf, e.header)
+
+	length := uint32(len(sealed))
+	if final {
+		length |= streamFinalBit
+	}
+
+	if err := binary.Write(e.w, binary.BigEndian, length); err != nil {
+		return errors.Wrap(err, "failed to write stream chunk")
+	}
+
+	if _, err := e.w.Write(sealed); err != nil {
+		return errors.Wrap(err, "failed to write stream chunk")
+	}
+
+	e.index++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// Close writes the final chunk of the stream.
+func (e *encryptingWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.seal(true)
+}
+
+type decryptingReader struct {
+	r      *bufio.Reader
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	index  uint64
+	final  bool
+}
+
+// NewDecryptingReader returns a reader which decrypts a stream created
+// by NewEncryptingWriter as it is read from r. The wrapped data key in
+// the stream is decrypted with the named transit key, which must be
+// the same key used to encrypt the stream.
+//
+// Reading returns ErrStreamCorrupt if the stream has been modified or
+// truncated. Data is only returned after it has been authenticated,
+// but data returned before the error should still be discarded.
+func NewDecryptingReader(transit Transit, key string, r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+
+	var header bytes.Buffer
+	prefix := make([]byte, len(streamMagic)+2)
+	if _, err := io.ReadFull(br, prefix); err != nil {
+		return nil, ErrStreamCorrupt
+	}
+
+	if string(prefix[:len(streamMagic)]) != streamMagic {
+		return nil, ErrStreamCorrupt
+	}
+	header.Write(prefix)
+
+	wrapped := make([]byte, binary.BigEndian.Uint16(prefix[len(streamMagic):]))
+	if _, err := io.ReadFull(br, wrapped); err != nil {
+		return nil, ErrStreamCorrupt
+	}
+	header.Write(wrapped)
+
+	plaintext, err := transit.Decrypt(key, string(wrapped), TransitOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := streamCipher(plaintext)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create stream cipher")
+	}
+
+	return &decryptingReader{
+		r:      br,
+		aead:   aead,
+		header: header.Bytes(),
+	}, nil
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptingReader) open() error {
+	var length uint32
+	if err := binary.Read(d.r, binary.BigEndian, &length); err != nil {
+		return ErrStreamCorrupt
+	}
+
+	final := length&streamFinalBit != 0
+	length &^= streamFinalBit
+	if length > streamChunkSize+uint32(d.aead.Overhead()) {
+		return ErrStreamCorrupt
+	}
+
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return ErrStreamCorrupt
+	}
+
+	opened, err := d.aead.Open(sealed[:0], streamNonce(d.index, final), sealed, d.header)
+	if err != nil {
+		return ErrStreamCorrupt
+	}
+
+	if final {
+		// nothing may follow the final chunk
+		if _, err := d.r.Peek(1); err != io.EOF {
+			return ErrStreamCorrupt
+		}
+	}
+
+	d.index++
+	d.final = final
+	d.buf = opened
+	return nil
+}
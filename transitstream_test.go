@@ -0,0 +1,96 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// dataKeyer is a Transit which only implements GenerateDataKey and
+// Decrypt, with a single fixed data key
+type dataKeyer struct {
+	Transit
+	key []byte
+}
+
+func (d *dataKeyer) GenerateDataKey(string, string, DataKeyOptions) (DataKey, error) {
+	return DataKey{Plaintext: d.key, Ciphertext: "vault:v1:wrapped"}, nil
+}
+
+func (d *dataKeyer) Decrypt(key, ciphertext string, opts TransitOptions) ([]byte, error) {
+	if ciphertext != "vault:v1:wrapped" {
+		return nil, errors.New("invalid ciphertext")
+	}
+	return d.key, nil
+}
+
+func newDataKeyer(t *testing.T) *dataKeyer {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return &dataKeyer{key: key}
+}
+
+func encryptStream(t *testing.T, transit Transit, plaintext []byte) []byte {
+	var encrypted bytes.Buffer
+	w, err := NewEncryptingWriter(transit, "mykey", &encrypted)
+	require.NoError(t, err)
+
+	_, err = io.Copy(w, bytes.NewReader(plaintext))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return encrypted.Bytes()
+}
+
+func Test_TransitStream_roundtrip(t *testing.T) {
+	transit := newDataKeyer(t)
+
+	for _, size := range []int{0, 1, streamChunkSize - 1, streamChunkSize, streamChunkSize + 1, 3*streamChunkSize + 7} {
+		plaintext := make([]byte, size)
+		_, err := rand.Read(plaintext)
+		require.NoError(t, err)
+
+		encrypted := encryptStream(t, transit, plaintext)
+		require.False(t, bytes.Contains(encrypted, transit.key))
+
+		r, err := NewDecryptingReader(transit, "mykey", bytes.NewReader(encrypted))
+		require.NoError(t, err)
+
+		decrypted, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted, "size %d", size)
+	}
+}
+
+func Test_TransitStream_corrupt(t *testing.T) {
+	transit := newDataKeyer(t)
+
+	plaintext := make([]byte, 2*streamChunkSize+100)
+	encrypted := encryptStream(t, transit, plaintext)
+
+	tests := map[string][]byte{
+		"truncated chunk": encrypted[:len(encrypted)-1],
+		"missing final":   encrypted[:len(streamMagic)+2+16+4+streamChunkSize+16],
+		"trailing data":   append(append([]byte{}, encrypted...), 0),
+		"modified":        func() []byte { bs := append([]byte{}, encrypted...); bs[100]++; return bs }(),
+	}
+
+	for name, stream := range tests {
+		r, err := NewDecryptingReader(transit, "mykey", bytes.NewReader(stream))
+		require.NoError(t, err)
+
+		_, err = ioutil.ReadAll(r)
+		require.Equal(t, ErrStreamCorrupt, err, name)
+	}
+
+	_, err := NewDecryptingReader(transit, "mykey", bytes.NewReader([]byte("not a stream")))
+	require.Equal(t, ErrStreamCorrupt, err)
+}
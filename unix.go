@@ -0,0 +1,51 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const unixScheme = "unix://"
+
+// unixSockets replaces the servers with unix:// addresses, such as the
+// listener of vault agent, with http:// addresses of placeholder hosts,
+// and returns the socket of each placeholder host, which is dialed in
+// place of the host.
+func unixSockets(servers []string) ([]string, map[string]string) {
+	var replaced []string
+	sockets := make(map[string]string)
+
+	for i, server := range servers {
+		if !strings.HasPrefix(server, unixScheme) {
+			replaced = append(replaced, server)
+			continue
+		}
+
+		host := fmt.Sprintf("unix-socket-%d", i)
+		sockets[host+":80"] = strings.TrimPrefix(server, unixScheme)
+		replaced = append(replaced, "http://"+host)
+	}
+
+	return replaced, sockets
+}
+
+// dialer returns a dial function which dials the socket
+// of placeholder hosts, and otherwise dials normally.
+func dialer(sockets map[string]string) func(context.Context, string, string) (net.Conn, error) {
+	d := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if socket, ok := sockets[address]; ok {
+			return d.DialContext(ctx, "unix", socket)
+		}
+		return d.DialContext(ctx, network, address)
+	}
+}
@@ -0,0 +1,116 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// UserpassAuth provides a way for users to authenticate with
+// vault by using a username and password, and to manage the
+// users that are defined in the userpass auth backend.
+//
+// The userpass backend must be enabled at the default path of
+// auth/userpass for this API to work.
+//
+// More information about the userpass auth backend can be found here:
+// https://www.vaultproject.io/docs/auth/userpass.html
+type UserpassAuth interface {
+	LoginUserpass(username, password string) (CreatedToken, error)
+	CreateUserpassUser(opts UserpassUserOptions) error
+	LookupUserpassUser(username string) (LookedUpUserpassUser, error)
+	ChangeUserpassPassword(username, password string) error
+	ListUserpassUsers() ([]string, error)
+	DeleteUserpassUser(username string) error
+}
+
+func (c *client) LoginUserpass(username, password string) (CreatedToken, error) {
+	requestPath := fmt.Sprintf("/v1/auth/userpass/login/%s", username)
+	return c.login("userpass", requestPath, passwordLogin{Password: password})
+}
+
+// UserpassUserOptions are used to define properties of a user
+// being created or updated in the userpass backend. Durations are
+// expressed as strings that vault understands, such as "1h". When
+// updating an existing user, Password may be left empty to keep
+// the current password.
+type UserpassUserOptions struct {
+	Username string   `json:"-"`
+	Password string   `json:"password,omitempty"`
+	Policies []string `json:"policies,omitempty"`
+	TTL      string   `json:"ttl,omitempty"`
+	MaxTTL   string   `json:"max_ttl,omitempty"`
+}
+
+func (c *client) CreateUserpassUser(opts UserpassUserOptions) error {
+	bs, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshalling userpass user data to JSON request body")
+	}
+	// do not log the request, which contains the password
+
+	requestPath := fmt.Sprintf("/v1/auth/userpass/users/%s", opts.Username)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "creating userpass user at %q", requestPath)
+	}
+
+	return nil
+}
+
+type lookedUpUserpassUserWrapper struct {
+	Data LookedUpUserpassUser `json:"data"`
+}
+
+// A LookedUpUserpassUser represents information returned from
+// vault after making a request for information about a particular
+// user of the userpass backend.
+type LookedUpUserpassUser struct {
+	Policies []string `json:"policies"`
+	TTL      int      `json:"ttl"`
+	MaxTTL   int      `json:"max_ttl"`
+}
+
+func (c *client) LookupUserpassUser(username string) (LookedUpUserpassUser, error) {
+	var wrapper lookedUpUserpassUserWrapper
+	requestPath := fmt.Sprintf("/v1/auth/userpass/users/%s", username)
+	if err := c.get(requestPath, &wrapper); err != nil {
+		return LookedUpUserpassUser{}, errors.Wrapf(err, "failed to look up userpass user %q", username)
+	}
+	return wrapper.Data, nil
+}
+
+func (c *client) ChangeUserpassPassword(username, password string) error {
+	bs, err := json.Marshal(passwordLogin{Password: password})
+	if err != nil {
+		return err
+	}
+
+	requestPath := fmt.Sprintf("/v1/auth/userpass/users/%s/password", username)
+	if err := c.post(requestPath, string(bs), nil); err != nil {
+		return errors.Wrapf(err, "failed to change password of userpass user %q", username)
+	}
+
+	return nil
+}
+
+func (c *client) ListUserpassUsers() ([]string, error) {
+	var wrapper rolesWrapper
+	requestPath := "/v1/auth/userpass/users"
+	if err := c.list(requestPath, &wrapper); err != nil {
+		return nil, errors.Wrapf(err, "failed to list userpass users at %q", requestPath)
+	}
+	sort.Strings(wrapper.Data.Keys)
+	return wrapper.Data.Keys, nil
+}
+
+func (c *client) DeleteUserpassUser(username string) error {
+	requestPath := fmt.Sprintf("/v1/auth/userpass/users/%s", username)
+	if err := c.delete(requestPath); err != nil {
+		return errors.Wrapf(err, "failed to delete userpass user %q", username)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Userpass(t *testing.T) {
+	client := getClient(t, rootTokener)
+	opts := UserpassUserOptions{
+		Username: "test-user1",
+		Password: "password1",
+		Policies: []string{"default"},
+		TTL:      "10m",
+	}
+
+	require.NoError(t, client.CreateUserpassUser(opts))
+	defer func() {
+		require.NoError(t, client.DeleteUserpassUser(opts.Username))
+		_, err := client.LookupUserpassUser(opts.Username)
+		require.Equal(t, ErrPathNotFound, errors.Cause(err))
+	}()
+
+	users, err := client.ListUserpassUsers()
+	require.NoError(t, err)
+	require.Contains(t, users, opts.Username)
+
+	user, err := client.LookupUserpassUser(opts.Username)
+	require.NoError(t, err)
+	require.Equal(t, 600, user.TTL)
+
+	token, err := client.LoginUserpass(opts.Username, "password1")
+	require.NoError(t, err)
+	require.NotEmpty(t, token.ID)
+
+	// change the password, after which only the new password works
+	require.NoError(t, client.ChangeUserpassPassword(opts.Username, "password2"))
+
+	_, err = client.LoginUserpass(opts.Username, "password1")
+	require.Error(t, err)
+
+	_, err = client.LoginUserpass(opts.Username, "password2")
+	require.NoError(t, err)
+}
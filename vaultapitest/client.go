@@ -2,6 +2,8 @@
 package vaultapitest
 
 import mock "github.com/stretchr/testify/mock"
+import context "context"
+import http "net/http"
 import time "time"
 import vaultapi "github.com/shoenig/vaultapi"
 
@@ -10,6 +12,22 @@ type Client struct {
 	mock.Mock
 }
 
+// AWSSecretsMount provides a mock function with given fields: mount
+func (_m *Client) AWSSecretsMount(mount string) vaultapi.AWSSecrets {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.AWSSecrets
+	if rf, ok := ret.Get(0).(func(string) vaultapi.AWSSecrets); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.AWSSecrets)
+		}
+	}
+
+	return r0
+}
+
 // AccessorCapabilities provides a mock function with given fields: path, accessor
 func (_m *Client) AccessorCapabilities(path string, accessor string) ([]string, error) {
 	ret := _m.Called(path, accessor)
@@ -33,20 +51,20 @@ func (_m *Client) AccessorCapabilities(path string, accessor string) ([]string,
 	return r0, r1
 }
 
-// CreateToken provides a mock function with given fields: opts
-func (_m *Client) CreateToken(opts vaultapi.TokenOptions) (vaultapi.CreatedToken, error) {
-	ret := _m.Called(opts)
+// Activity provides a mock function with given fields: start, end
+func (_m *Client) Activity(start time.Time, end time.Time) (vaultapi.Activity, error) {
+	ret := _m.Called(start, end)
 
-	var r0 vaultapi.CreatedToken
-	if rf, ok := ret.Get(0).(func(vaultapi.TokenOptions) vaultapi.CreatedToken); ok {
-		r0 = rf(opts)
+	var r0 vaultapi.Activity
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) vaultapi.Activity); ok {
+		r0 = rf(start, end)
 	} else {
-		r0 = ret.Get(0).(vaultapi.CreatedToken)
+		r0 = ret.Get(0).(vaultapi.Activity)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(vaultapi.TokenOptions) error); ok {
-		r1 = rf(opts)
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(start, end)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,13 +72,117 @@ func (_m *Client) CreateToken(opts vaultapi.TokenOptions) (vaultapi.CreatedToken
 	return r0, r1
 }
 
-// CreateTokenRole provides a mock function with given fields: data
-func (_m *Client) CreateTokenRole(data vaultapi.TokenRoleOptions) error {
-	ret := _m.Called(data)
+// AuditHash provides a mock function with given fields: path, input
+func (_m *Client) AuditHash(path string, input string) (string, error) {
+	ret := _m.Called(path, input)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(path, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(path, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthorizeControlGroup provides a mock function with given fields: accessor
+func (_m *Client) AuthorizeControlGroup(accessor string) (bool, error) {
+	ret := _m.Called(accessor)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(accessor)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accessor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AzureSecretsMount provides a mock function with given fields: mount
+func (_m *Client) AzureSecretsMount(mount string) vaultapi.AzureSecrets {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.AzureSecrets
+	if rf, ok := ret.Get(0).(func(string) vaultapi.AzureSecrets); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.AzureSecrets)
+		}
+	}
+
+	return r0
+}
+
+// Capabilities provides a mock function with given fields: token, paths
+func (_m *Client) Capabilities(token string, paths []string) (map[string][]string, error) {
+	ret := _m.Called(token, paths)
+
+	var r0 map[string][]string
+	if rf, ok := ret.Get(0).(func(string, []string) map[string][]string); ok {
+		r0 = rf(token, paths)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(token, paths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CapabilitiesSelf provides a mock function with given fields: paths
+func (_m *Client) CapabilitiesSelf(paths []string) (map[string][]string, error) {
+	ret := _m.Called(paths)
+
+	var r0 map[string][]string
+	if rf, ok := ret.Get(0).(func([]string) map[string][]string); ok {
+		r0 = rf(paths)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(paths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeUserpassPassword provides a mock function with given fields: username, password
+func (_m *Client) ChangeUserpassPassword(username string, password string) error {
+	ret := _m.Called(username, password)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(vaultapi.TokenRoleOptions) error); ok {
-		r0 = rf(data)
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(username, password)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -68,13 +190,13 @@ func (_m *Client) CreateTokenRole(data vaultapi.TokenRoleOptions) error {
 	return r0
 }
 
-// Delete provides a mock function with given fields: path
-func (_m *Client) Delete(path string) error {
-	ret := _m.Called(path)
+// ConfigureAWSClient provides a mock function with given fields: config
+func (_m *Client) ConfigureAWSClient(config vaultapi.AWSClientConfig) error {
+	ret := _m.Called(config)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(path)
+	if rf, ok := ret.Get(0).(func(vaultapi.AWSClientConfig) error); ok {
+		r0 = rf(config)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -82,13 +204,13 @@ func (_m *Client) Delete(path string) error {
 	return r0
 }
 
-// DeletePolicy provides a mock function with given fields: name
-func (_m *Client) DeletePolicy(name string) error {
-	ret := _m.Called(name)
+// ConfigureGitHub provides a mock function with given fields: config
+func (_m *Client) ConfigureGitHub(config vaultapi.GitHubConfig) error {
+	ret := _m.Called(config)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(vaultapi.GitHubConfig) error); ok {
+		r0 = rf(config)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -96,13 +218,13 @@ func (_m *Client) DeletePolicy(name string) error {
 	return r0
 }
 
-// DeleteTokenRole provides a mock function with given fields: name
-func (_m *Client) DeleteTokenRole(name string) error {
-	ret := _m.Called(name)
+// ConfigureIdentityTokens provides a mock function with given fields: config
+func (_m *Client) ConfigureIdentityTokens(config vaultapi.IdentityTokenConfig) error {
+	ret := _m.Called(config)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(vaultapi.IdentityTokenConfig) error); ok {
+		r0 = rf(config)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -110,20 +232,160 @@ func (_m *Client) DeleteTokenRole(name string) error {
 	return r0
 }
 
-// Get provides a mock function with given fields: path
-func (_m *Client) Get(path string) (string, error) {
-	ret := _m.Called(path)
+// ConfigureJWT provides a mock function with given fields: config
+func (_m *Client) ConfigureJWT(config vaultapi.JWTConfig) error {
+	ret := _m.Called(config)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.JWTConfig) error); ok {
+		r0 = rf(config)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfigureKerberos provides a mock function with given fields: config
+func (_m *Client) ConfigureKerberos(config vaultapi.KerberosConfig) error {
+	ret := _m.Called(config)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.KerberosConfig) error); ok {
+		r0 = rf(config)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfigureKerberosLDAP provides a mock function with given fields: config
+func (_m *Client) ConfigureKerberosLDAP(config vaultapi.LDAPConfig) error {
+	ret := _m.Called(config)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.LDAPConfig) error); ok {
+		r0 = rf(config)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfigureKubernetes provides a mock function with given fields: config
+func (_m *Client) ConfigureKubernetes(config vaultapi.KubernetesConfig) error {
+	ret := _m.Called(config)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.KubernetesConfig) error); ok {
+		r0 = rf(config)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfigureLDAP provides a mock function with given fields: config
+func (_m *Client) ConfigureLDAP(config vaultapi.LDAPConfig) error {
+	ret := _m.Called(config)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.LDAPConfig) error); ok {
+		r0 = rf(config)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfigureQuotas provides a mock function with given fields: config
+func (_m *Client) ConfigureQuotas(config vaultapi.QuotaConfig) error {
+	ret := _m.Called(config)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.QuotaConfig) error); ok {
+		r0 = rf(config)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfigureTransitKey provides a mock function with given fields: name, config
+func (_m *Client) ConfigureTransitKey(name string, config vaultapi.TransitKeyConfig) error {
+	ret := _m.Called(name, config)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.TransitKeyConfig) error); ok {
+		r0 = rf(name, config)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAWSRole provides a mock function with given fields: opts
+func (_m *Client) CreateAWSRole(opts vaultapi.AWSRoleOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.AWSRoleOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAppRole provides a mock function with given fields: opts
+func (_m *Client) CreateAppRole(opts vaultapi.AppRoleOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.AppRoleOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAzureRole provides a mock function with given fields: opts
+func (_m *Client) CreateAzureRole(opts vaultapi.AzureRoleOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.AzureRoleOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateEntity provides a mock function with given fields: opts
+func (_m *Client) CreateEntity(opts vaultapi.EntityOptions) (string, error) {
+	ret := _m.Called(opts)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(path)
+	if rf, ok := ret.Get(0).(func(vaultapi.EntityOptions) string); ok {
+		r0 = rf(opts)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(path)
+	if rf, ok := ret.Get(1).(func(vaultapi.EntityOptions) error); ok {
+		r1 = rf(opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -131,20 +393,20 @@ func (_m *Client) Get(path string) (string, error) {
 	return r0, r1
 }
 
-// GetPolicy provides a mock function with given fields: name
-func (_m *Client) GetPolicy(name string) (string, error) {
-	ret := _m.Called(name)
+// CreateEntityAlias provides a mock function with given fields: opts
+func (_m *Client) CreateEntityAlias(opts vaultapi.EntityAliasOptions) (string, error) {
+	ret := _m.Called(opts)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(vaultapi.EntityAliasOptions) string); ok {
+		r0 = rf(opts)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(vaultapi.EntityAliasOptions) error); ok {
+		r1 = rf(opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -152,34 +414,4920 @@ func (_m *Client) GetPolicy(name string) (string, error) {
 	return r0, r1
 }
 
-// Health provides a mock function with given fields:
-func (_m *Client) Health() (vaultapi.Health, error) {
-	ret := _m.Called()
+// CreateIdentityTokenKey provides a mock function with given fields: opts
+func (_m *Client) CreateIdentityTokenKey(opts vaultapi.IdentityTokenKeyOptions) error {
+	ret := _m.Called(opts)
 
-	var r0 vaultapi.Health
-	if rf, ok := ret.Get(0).(func() vaultapi.Health); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.IdentityTokenKeyOptions) error); ok {
+		r0 = rf(opts)
 	} else {
-		r0 = ret.Get(0).(vaultapi.Health)
+		r0 = ret.Error(0)
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	return r0
+}
+
+// CreateIdentityTokenRole provides a mock function with given fields: opts
+func (_m *Client) CreateIdentityTokenRole(opts vaultapi.IdentityTokenRoleOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.IdentityTokenRoleOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateJWTRole provides a mock function with given fields: opts
+func (_m *Client) CreateJWTRole(opts vaultapi.JWTRoleOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.JWTRoleOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateKubernetesRole provides a mock function with given fields: opts
+func (_m *Client) CreateKubernetesRole(opts vaultapi.KubernetesRoleOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.KubernetesRoleOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateLeaseCountQuota provides a mock function with given fields: name, opts
+func (_m *Client) CreateLeaseCountQuota(name string, opts vaultapi.LeaseCountQuotaOptions) error {
+	ret := _m.Called(name, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.LeaseCountQuotaOptions) error); ok {
+		r0 = rf(name, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMFALoginEnforcement provides a mock function with given fields: enforcement
+func (_m *Client) CreateMFALoginEnforcement(enforcement vaultapi.MFALoginEnforcement) error {
+	ret := _m.Called(enforcement)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.MFALoginEnforcement) error); ok {
+		r0 = rf(enforcement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMFAMethod provides a mock function with given fields: opts
+func (_m *Client) CreateMFAMethod(opts vaultapi.MFAMethodOptions) (string, error) {
+	ret := _m.Called(opts)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(vaultapi.MFAMethodOptions) string); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.MFAMethodOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateManagedKey provides a mock function with given fields: keyType, name, params
+func (_m *Client) CreateManagedKey(keyType string, name string, params map[string]interface{}) error {
+	ret := _m.Called(keyType, name, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}) error); ok {
+		r0 = rf(keyType, name, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateNamespace provides a mock function with given fields: path, customMetadata
+func (_m *Client) CreateNamespace(path string, customMetadata map[string]string) (vaultapi.Namespace, error) {
+	ret := _m.Called(path, customMetadata)
+
+	var r0 vaultapi.Namespace
+	if rf, ok := ret.Get(0).(func(string, map[string]string) vaultapi.Namespace); ok {
+		r0 = rf(path, customMetadata)
+	} else {
+		r0 = ret.Get(0).(vaultapi.Namespace)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(path, customMetadata)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOIDCAssignment provides a mock function with given fields: assignment
+func (_m *Client) CreateOIDCAssignment(assignment vaultapi.OIDCAssignment) error {
+	ret := _m.Called(assignment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.OIDCAssignment) error); ok {
+		r0 = rf(assignment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOIDCClient provides a mock function with given fields: opts
+func (_m *Client) CreateOIDCClient(opts vaultapi.OIDCClientOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.OIDCClientOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOIDCProvider provides a mock function with given fields: provider
+func (_m *Client) CreateOIDCProvider(provider vaultapi.OIDCProvider) error {
+	ret := _m.Called(provider)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.OIDCProvider) error); ok {
+		r0 = rf(provider)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOIDCScope provides a mock function with given fields: scope
+func (_m *Client) CreateOIDCScope(scope vaultapi.OIDCScope) error {
+	ret := _m.Called(scope)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.OIDCScope) error); ok {
+		r0 = rf(scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOrphanToken provides a mock function with given fields: opts
+func (_m *Client) CreateOrphanToken(opts vaultapi.TokenOptions) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(opts)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(vaultapi.TokenOptions) vaultapi.CreatedToken); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.TokenOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRateLimitQuota provides a mock function with given fields: name, opts
+func (_m *Client) CreateRateLimitQuota(name string, opts vaultapi.RateLimitQuotaOptions) error {
+	ret := _m.Called(name, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.RateLimitQuotaOptions) error); ok {
+		r0 = rf(name, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateToken provides a mock function with given fields: opts
+func (_m *Client) CreateToken(opts vaultapi.TokenOptions) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(opts)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(vaultapi.TokenOptions) vaultapi.CreatedToken); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.TokenOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTokenRole provides a mock function with given fields: data
+func (_m *Client) CreateTokenRole(data vaultapi.TokenRoleOptions) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.TokenRoleOptions) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTransitKey provides a mock function with given fields: opts
+func (_m *Client) CreateTransitKey(opts vaultapi.TransitKeyOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.TransitKeyOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUserpassUser provides a mock function with given fields: opts
+func (_m *Client) CreateUserpassUser(opts vaultapi.UserpassUserOptions) error {
+	ret := _m.Called(opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(vaultapi.UserpassUserOptions) error); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Cubbyhole provides a mock function with given fields:
+func (_m *Client) Cubbyhole() vaultapi.KV {
+	ret := _m.Called()
+
+	var r0 vaultapi.KV
+	if rf, ok := ret.Get(0).(func() vaultapi.KV); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.KV)
+		}
+	}
+
+	return r0
+}
+
+// DatabaseMount provides a mock function with given fields: mount
+func (_m *Client) DatabaseMount(mount string) vaultapi.Database {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.Database
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Database); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Database)
+		}
+	}
+
+	return r0
+}
+
+// Decrypt provides a mock function with given fields: key, ciphertext, opts
+func (_m *Client) Decrypt(key string, ciphertext string, opts vaultapi.TransitOptions) ([]byte, error) {
+	ret := _m.Called(key, ciphertext, opts)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, string, vaultapi.TransitOptions) []byte); ok {
+		r0 = rf(key, ciphertext, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, vaultapi.TransitOptions) error); ok {
+		r1 = rf(key, ciphertext, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecryptBatch provides a mock function with given fields: key, items
+func (_m *Client) DecryptBatch(key string, items []vaultapi.TransitBatchItem) ([]vaultapi.TransitBatchResult, error) {
+	ret := _m.Called(key, items)
+
+	var r0 []vaultapi.TransitBatchResult
+	if rf, ok := ret.Get(0).(func(string, []vaultapi.TransitBatchItem) []vaultapi.TransitBatchResult); ok {
+		r0 = rf(key, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vaultapi.TransitBatchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []vaultapi.TransitBatchItem) error); ok {
+		r1 = rf(key, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: path
+func (_m *Client) Delete(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAWSClientConfig provides a mock function with given fields:
+func (_m *Client) DeleteAWSClientConfig() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAWSRole provides a mock function with given fields: name
+func (_m *Client) DeleteAWSRole(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAppRole provides a mock function with given fields: name
+func (_m *Client) DeleteAppRole(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAzureRole provides a mock function with given fields: name
+func (_m *Client) DeleteAzureRole(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEntity provides a mock function with given fields: id
+func (_m *Client) DeleteEntity(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEntityAlias provides a mock function with given fields: id
+func (_m *Client) DeleteEntityAlias(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGitHubTeam provides a mock function with given fields: team
+func (_m *Client) DeleteGitHubTeam(team string) error {
+	ret := _m.Called(team)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(team)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGitHubUser provides a mock function with given fields: user
+func (_m *Client) DeleteGitHubUser(user string) error {
+	ret := _m.Called(user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteIdentityTokenKey provides a mock function with given fields: name
+func (_m *Client) DeleteIdentityTokenKey(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteIdentityTokenRole provides a mock function with given fields: name
+func (_m *Client) DeleteIdentityTokenRole(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteJWTRole provides a mock function with given fields: name
+func (_m *Client) DeleteJWTRole(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteKerberosGroup provides a mock function with given fields: name
+func (_m *Client) DeleteKerberosGroup(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteKubernetesRole provides a mock function with given fields: name
+func (_m *Client) DeleteKubernetesRole(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLDAPGroup provides a mock function with given fields: name
+func (_m *Client) DeleteLDAPGroup(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLDAPUser provides a mock function with given fields: name
+func (_m *Client) DeleteLDAPUser(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLeaseCountQuota provides a mock function with given fields: name
+func (_m *Client) DeleteLeaseCountQuota(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMFALoginEnforcement provides a mock function with given fields: name
+func (_m *Client) DeleteMFALoginEnforcement(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMFAMethod provides a mock function with given fields: methodType, id
+func (_m *Client) DeleteMFAMethod(methodType string, id string) error {
+	ret := _m.Called(methodType, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(methodType, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteManagedKey provides a mock function with given fields: keyType, name
+func (_m *Client) DeleteManagedKey(keyType string, name string) error {
+	ret := _m.Called(keyType, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(keyType, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNamespace provides a mock function with given fields: path
+func (_m *Client) DeleteNamespace(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOIDCAssignment provides a mock function with given fields: name
+func (_m *Client) DeleteOIDCAssignment(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOIDCClient provides a mock function with given fields: name
+func (_m *Client) DeleteOIDCClient(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOIDCProvider provides a mock function with given fields: name
+func (_m *Client) DeleteOIDCProvider(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOIDCScope provides a mock function with given fields: name
+func (_m *Client) DeleteOIDCScope(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePolicy provides a mock function with given fields: name
+func (_m *Client) DeletePolicy(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRateLimitQuota provides a mock function with given fields: name
+func (_m *Client) DeleteRateLimitQuota(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTokenRole provides a mock function with given fields: name
+func (_m *Client) DeleteTokenRole(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTransitKey provides a mock function with given fields: name
+func (_m *Client) DeleteTransitKey(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserpassUser provides a mock function with given fields: username
+func (_m *Client) DeleteUserpassUser(username string) error {
+	ret := _m.Called(username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeregisterPlugin provides a mock function with given fields: pluginType, name
+func (_m *Client) DeregisterPlugin(pluginType string, name string) error {
+	ret := _m.Called(pluginType, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(pluginType, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DestroyTOTPSecret provides a mock function with given fields: methodID, entityID
+func (_m *Client) DestroyTOTPSecret(methodID string, entityID string) error {
+	ret := _m.Called(methodID, entityID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(methodID, entityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableAuditDevice provides a mock function with given fields: path
+func (_m *Client) DisableAuditDevice(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableAuthMount provides a mock function with given fields: path
+func (_m *Client) DisableAuthMount(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableMount provides a mock function with given fields: path
+func (_m *Client) DisableMount(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableAuditDevice provides a mock function with given fields: path, opts
+func (_m *Client) EnableAuditDevice(path string, opts vaultapi.AuditDeviceOptions) error {
+	ret := _m.Called(path, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.AuditDeviceOptions) error); ok {
+		r0 = rf(path, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableAuthMount provides a mock function with given fields: path, opts
+func (_m *Client) EnableAuthMount(path string, opts vaultapi.MountOptions) error {
+	ret := _m.Called(path, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.MountOptions) error); ok {
+		r0 = rf(path, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableMount provides a mock function with given fields: path, opts
+func (_m *Client) EnableMount(path string, opts vaultapi.MountOptions) error {
+	ret := _m.Called(path, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.MountOptions) error); ok {
+		r0 = rf(path, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Encrypt provides a mock function with given fields: key, plaintext, opts
+func (_m *Client) Encrypt(key string, plaintext []byte, opts vaultapi.TransitOptions) (string, error) {
+	ret := _m.Called(key, plaintext, opts)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, []byte, vaultapi.TransitOptions) string); ok {
+		r0 = rf(key, plaintext, opts)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, vaultapi.TransitOptions) error); ok {
+		r1 = rf(key, plaintext, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EncryptBatch provides a mock function with given fields: key, items
+func (_m *Client) EncryptBatch(key string, items []vaultapi.TransitBatchItem) ([]vaultapi.TransitBatchResult, error) {
+	ret := _m.Called(key, items)
+
+	var r0 []vaultapi.TransitBatchResult
+	if rf, ok := ret.Get(0).(func(string, []vaultapi.TransitBatchItem) []vaultapi.TransitBatchResult); ok {
+		r0 = rf(key, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vaultapi.TransitBatchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []vaultapi.TransitBatchItem) error); ok {
+		r1 = rf(key, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportActivity provides a mock function with given fields: start, end, format
+func (_m *Client) ExportActivity(start time.Time, end time.Time, format string) ([]byte, error) {
+	ret := _m.Called(start, end, format)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) []byte); ok {
+		r0 = rf(start, end, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string) error); ok {
+		r1 = rf(start, end, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateAppRoleSecretID provides a mock function with given fields: name, opts
+func (_m *Client) GenerateAppRoleSecretID(name string, opts vaultapi.SecretIDOptions) (vaultapi.GeneratedSecretID, error) {
+	ret := _m.Called(name, opts)
+
+	var r0 vaultapi.GeneratedSecretID
+	if rf, ok := ret.Get(0).(func(string, vaultapi.SecretIDOptions) vaultapi.GeneratedSecretID); ok {
+		r0 = rf(name, opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.GeneratedSecretID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, vaultapi.SecretIDOptions) error); ok {
+		r1 = rf(name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateDataKey provides a mock function with given fields: key, dataKeyType, opts
+func (_m *Client) GenerateDataKey(key string, dataKeyType string, opts vaultapi.DataKeyOptions) (vaultapi.DataKey, error) {
+	ret := _m.Called(key, dataKeyType, opts)
+
+	var r0 vaultapi.DataKey
+	if rf, ok := ret.Get(0).(func(string, string, vaultapi.DataKeyOptions) vaultapi.DataKey); ok {
+		r0 = rf(key, dataKeyType, opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.DataKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, vaultapi.DataKeyOptions) error); ok {
+		r1 = rf(key, dataKeyType, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateIdentityToken provides a mock function with given fields: role
+func (_m *Client) GenerateIdentityToken(role string) (vaultapi.IdentityToken, error) {
+	ret := _m.Called(role)
+
+	var r0 vaultapi.IdentityToken
+	if rf, ok := ret.Get(0).(func(string) vaultapi.IdentityToken); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(vaultapi.IdentityToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateRecoveryTokenCancel provides a mock function with given fields:
+func (_m *Client) GenerateRecoveryTokenCancel() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateRecoveryTokenInit provides a mock function with given fields: otp, pgpKey
+func (_m *Client) GenerateRecoveryTokenInit(otp string, pgpKey string) (vaultapi.GenerateRootStatus, error) {
+	ret := _m.Called(otp, pgpKey)
+
+	var r0 vaultapi.GenerateRootStatus
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.GenerateRootStatus); ok {
+		r0 = rf(otp, pgpKey)
+	} else {
+		r0 = ret.Get(0).(vaultapi.GenerateRootStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(otp, pgpKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateRecoveryTokenStatus provides a mock function with given fields:
+func (_m *Client) GenerateRecoveryTokenStatus() (vaultapi.GenerateRootStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.GenerateRootStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.GenerateRootStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.GenerateRootStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateRecoveryTokenUpdate provides a mock function with given fields: key, nonce
+func (_m *Client) GenerateRecoveryTokenUpdate(key string, nonce string) (vaultapi.GenerateRootStatus, error) {
+	ret := _m.Called(key, nonce)
+
+	var r0 vaultapi.GenerateRootStatus
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.GenerateRootStatus); ok {
+		r0 = rf(key, nonce)
+	} else {
+		r0 = ret.Get(0).(vaultapi.GenerateRootStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateRootCancel provides a mock function with given fields:
+func (_m *Client) GenerateRootCancel() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateRootInit provides a mock function with given fields: otp, pgpKey
+func (_m *Client) GenerateRootInit(otp string, pgpKey string) (vaultapi.GenerateRootStatus, error) {
+	ret := _m.Called(otp, pgpKey)
+
+	var r0 vaultapi.GenerateRootStatus
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.GenerateRootStatus); ok {
+		r0 = rf(otp, pgpKey)
+	} else {
+		r0 = ret.Get(0).(vaultapi.GenerateRootStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(otp, pgpKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateRootStatus provides a mock function with given fields:
+func (_m *Client) GenerateRootStatus() (vaultapi.GenerateRootStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.GenerateRootStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.GenerateRootStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.GenerateRootStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateRootUpdate provides a mock function with given fields: key, nonce
+func (_m *Client) GenerateRootUpdate(key string, nonce string) (vaultapi.GenerateRootStatus, error) {
+	ret := _m.Called(key, nonce)
+
+	var r0 vaultapi.GenerateRootStatus
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.GenerateRootStatus); ok {
+		r0 = rf(key, nonce)
+	} else {
+		r0 = ret.Get(0).(vaultapi.GenerateRootStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateTOTPSecret provides a mock function with given fields: methodID, entityID
+func (_m *Client) GenerateTOTPSecret(methodID string, entityID string) (vaultapi.TOTPSecret, error) {
+	ret := _m.Called(methodID, entityID)
+
+	var r0 vaultapi.TOTPSecret
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.TOTPSecret); ok {
+		r0 = rf(methodID, entityID)
+	} else {
+		r0 = ret.Get(0).(vaultapi.TOTPSecret)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(methodID, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: path
+func (_m *Client) Get(path string) (string, error) {
+	ret := _m.Called(path)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPolicy provides a mock function with given fields: name
+func (_m *Client) GetPolicy(name string) (string, error) {
+	ret := _m.Called(name)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HAStatus provides a mock function with given fields:
+func (_m *Client) HAStatus() ([]vaultapi.HANode, error) {
+	ret := _m.Called()
+
+	var r0 []vaultapi.HANode
+	if rf, ok := ret.Get(0).(func() []vaultapi.HANode); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vaultapi.HANode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HMAC provides a mock function with given fields: key, input, algorithm
+func (_m *Client) HMAC(key string, input []byte, algorithm string) (string, error) {
+	ret := _m.Called(key, input, algorithm)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, []byte, string) string); ok {
+		r0 = rf(key, input, algorithm)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, string) error); ok {
+		r1 = rf(key, input, algorithm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Hash provides a mock function with given fields: input, algorithm
+func (_m *Client) Hash(input []byte, algorithm string) ([]byte, error) {
+	ret := _m.Called(input, algorithm)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]byte, string) []byte); ok {
+		r0 = rf(input, algorithm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, string) error); ok {
+		r1 = rf(input, algorithm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Health provides a mock function with given fields:
+func (_m *Client) Health() (vaultapi.Health, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.Health
+	if rf, ok := ret.Get(0).(func() vaultapi.Health); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.Health)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HostInfo provides a mock function with given fields:
+func (_m *Client) HostInfo() (vaultapi.HostInfo, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.HostInfo
+	if rf, ok := ret.Get(0).(func() vaultapi.HostInfo); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.HostInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InFlightRequests provides a mock function with given fields:
+func (_m *Client) InFlightRequests() (map[string]vaultapi.InFlightRequest, error) {
+	ret := _m.Called()
+
+	var r0 map[string]vaultapi.InFlightRequest
+	if rf, ok := ret.Get(0).(func() map[string]vaultapi.InFlightRequest); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]vaultapi.InFlightRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Init provides a mock function with given fields: opts
+func (_m *Client) Init(opts vaultapi.InitOptions) (vaultapi.InitKeys, error) {
+	ret := _m.Called(opts)
+
+	var r0 vaultapi.InitKeys
+	if rf, ok := ret.Get(0).(func(vaultapi.InitOptions) vaultapi.InitKeys); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.InitKeys)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.InitOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InitStatus provides a mock function with given fields:
+func (_m *Client) InitStatus() (bool, error) {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IntrospectIdentityToken provides a mock function with given fields: token, clientID
+func (_m *Client) IntrospectIdentityToken(token string, clientID string) (vaultapi.IntrospectedIdentityToken, error) {
+	ret := _m.Called(token, clientID)
+
+	var r0 vaultapi.IntrospectedIdentityToken
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.IntrospectedIdentityToken); ok {
+		r0 = rf(token, clientID)
+	} else {
+		r0 = ret.Get(0).(vaultapi.IntrospectedIdentityToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(token, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KV2Mount provides a mock function with given fields: mount
+func (_m *Client) KV2Mount(mount string) vaultapi.KV2 {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.KV2
+	if rf, ok := ret.Get(0).(func(string) vaultapi.KV2); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.KV2)
+		}
+	}
+
+	return r0
+}
+
+// KVMount provides a mock function with given fields: mount
+func (_m *Client) KVMount(mount string) vaultapi.KV {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.KV
+	if rf, ok := ret.Get(0).(func(string) vaultapi.KV); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.KV)
+		}
+	}
+
+	return r0
+}
+
+// Keys provides a mock function with given fields: path
+func (_m *Client) Keys(path string) ([]string, error) {
+	ret := _m.Called(path)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LDAPSecretsMount provides a mock function with given fields: mount
+func (_m *Client) LDAPSecretsMount(mount string) vaultapi.LDAPSecrets {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.LDAPSecrets
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LDAPSecrets); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.LDAPSecrets)
+		}
+	}
+
+	return r0
+}
+
+// Leader provides a mock function with given fields:
+func (_m *Client) Leader() (vaultapi.Leader, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.Leader
+	if rf, ok := ret.Get(0).(func() vaultapi.Leader); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.Leader)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LicenseStatus provides a mock function with given fields:
+func (_m *Client) LicenseStatus() (vaultapi.LicenseStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.LicenseStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.LicenseStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.LicenseStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAWSRoles provides a mock function with given fields:
+func (_m *Client) ListAWSRoles() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAppRoles provides a mock function with given fields:
+func (_m *Client) ListAppRoles() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuditDevices provides a mock function with given fields:
+func (_m *Client) ListAuditDevices() (map[string]vaultapi.AuditDevice, error) {
+	ret := _m.Called()
+
+	var r0 map[string]vaultapi.AuditDevice
+	if rf, ok := ret.Get(0).(func() map[string]vaultapi.AuditDevice); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]vaultapi.AuditDevice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuthMounts provides a mock function with given fields:
+func (_m *Client) ListAuthMounts() (vaultapi.Mounts, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.Mounts
+	if rf, ok := ret.Get(0).(func() vaultapi.Mounts); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Mounts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAzureRoles provides a mock function with given fields:
+func (_m *Client) ListAzureRoles() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListEntities provides a mock function with given fields:
+func (_m *Client) ListEntities() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListEntityAliases provides a mock function with given fields:
+func (_m *Client) ListEntityAliases() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGitHubTeams provides a mock function with given fields:
+func (_m *Client) ListGitHubTeams() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGitHubUsers provides a mock function with given fields:
+func (_m *Client) ListGitHubUsers() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIdentityTokenKeys provides a mock function with given fields:
+func (_m *Client) ListIdentityTokenKeys() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIdentityTokenRoles provides a mock function with given fields:
+func (_m *Client) ListIdentityTokenRoles() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJWTRoles provides a mock function with given fields:
+func (_m *Client) ListJWTRoles() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListKerberosGroups provides a mock function with given fields:
+func (_m *Client) ListKerberosGroups() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListKubernetesRoles provides a mock function with given fields:
+func (_m *Client) ListKubernetesRoles() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLDAPGroups provides a mock function with given fields:
+func (_m *Client) ListLDAPGroups() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLDAPUsers provides a mock function with given fields:
+func (_m *Client) ListLDAPUsers() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLeaseCountQuotas provides a mock function with given fields:
+func (_m *Client) ListLeaseCountQuotas() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLeases provides a mock function with given fields: prefix
+func (_m *Client) ListLeases(prefix string) ([]string, error) {
+	ret := _m.Called(prefix)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMFALoginEnforcements provides a mock function with given fields:
+func (_m *Client) ListMFALoginEnforcements() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMFAMethods provides a mock function with given fields:
+func (_m *Client) ListMFAMethods() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListManagedKeys provides a mock function with given fields: keyType
+func (_m *Client) ListManagedKeys(keyType string) ([]string, error) {
+	ret := _m.Called(keyType)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(keyType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(keyType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMounts provides a mock function with given fields:
+func (_m *Client) ListMounts() (vaultapi.Mounts, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.Mounts
+	if rf, ok := ret.Get(0).(func() vaultapi.Mounts); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Mounts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListNamespaces provides a mock function with given fields:
+func (_m *Client) ListNamespaces() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOIDCAssignments provides a mock function with given fields:
+func (_m *Client) ListOIDCAssignments() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOIDCClients provides a mock function with given fields:
+func (_m *Client) ListOIDCClients() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOIDCProviders provides a mock function with given fields:
+func (_m *Client) ListOIDCProviders() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOIDCScopes provides a mock function with given fields:
+func (_m *Client) ListOIDCScopes() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPlugins provides a mock function with given fields: pluginType
+func (_m *Client) ListPlugins(pluginType string) ([]string, error) {
+	ret := _m.Called(pluginType)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(pluginType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pluginType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPolicies provides a mock function with given fields:
+func (_m *Client) ListPolicies() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRateLimitQuotas provides a mock function with given fields:
+func (_m *Client) ListRateLimitQuotas() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTokenRoles provides a mock function with given fields:
+func (_m *Client) ListTokenRoles() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTransitKeys provides a mock function with given fields:
+func (_m *Client) ListTransitKeys() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUIMounts provides a mock function with given fields:
+func (_m *Client) ListUIMounts() (vaultapi.UIMounts, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.UIMounts
+	if rf, ok := ret.Get(0).(func() vaultapi.UIMounts); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.UIMounts)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserpassUsers provides a mock function with given fields:
+func (_m *Client) ListUserpassUsers() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Loggers provides a mock function with given fields:
+func (_m *Client) Loggers() (map[string]string, error) {
+	ret := _m.Called()
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logical provides a mock function with given fields:
+func (_m *Client) Logical() vaultapi.Logical {
+	ret := _m.Called()
+
+	var r0 vaultapi.Logical
+	if rf, ok := ret.Get(0).(func() vaultapi.Logical); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Logical)
+		}
+	}
+
+	return r0
+}
+
+// LoginAWS provides a mock function with given fields: opts
+func (_m *Client) LoginAWS(opts vaultapi.AWSLoginOptions) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(opts)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(vaultapi.AWSLoginOptions) vaultapi.CreatedToken); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.AWSLoginOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAppRole provides a mock function with given fields: roleID, secretID
+func (_m *Client) LoginAppRole(roleID string, secretID string) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(roleID, secretID)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.CreatedToken); ok {
+		r0 = rf(roleID, secretID)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(roleID, secretID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAzure provides a mock function with given fields: role, opts
+func (_m *Client) LoginAzure(role string, opts vaultapi.AzureLoginOptions) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(role, opts)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string, vaultapi.AzureLoginOptions) vaultapi.CreatedToken); ok {
+		r0 = rf(role, opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, vaultapi.AzureLoginOptions) error); ok {
+		r1 = rf(role, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginGitHub provides a mock function with given fields: token
+func (_m *Client) LoginGitHub(token string) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(token)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string) vaultapi.CreatedToken); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginJWT provides a mock function with given fields: role, jwt
+func (_m *Client) LoginJWT(role string, jwt string) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(role, jwt)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.CreatedToken); ok {
+		r0 = rf(role, jwt)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(role, jwt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginKerberos provides a mock function with given fields: spnegoToken
+func (_m *Client) LoginKerberos(spnegoToken []byte) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(spnegoToken)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func([]byte) vaultapi.CreatedToken); ok {
+		r0 = rf(spnegoToken)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(spnegoToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginKubernetes provides a mock function with given fields: role, jwt
+func (_m *Client) LoginKubernetes(role string, jwt string) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(role, jwt)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.CreatedToken); ok {
+		r0 = rf(role, jwt)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(role, jwt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginLDAP provides a mock function with given fields: username, password
+func (_m *Client) LoginLDAP(username string, password string) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(username, password)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.CreatedToken); ok {
+		r0 = rf(username, password)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginOIDC provides a mock function with given fields: role, opts
+func (_m *Client) LoginOIDC(role string, opts vaultapi.OIDCLoginOptions) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(role, opts)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string, vaultapi.OIDCLoginOptions) vaultapi.CreatedToken); ok {
+		r0 = rf(role, opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, vaultapi.OIDCLoginOptions) error); ok {
+		r1 = rf(role, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginUserpass provides a mock function with given fields: username, password
+func (_m *Client) LoginUserpass(username string, password string) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(username, password)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.CreatedToken); ok {
+		r0 = rf(username, password)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupAWSClientConfig provides a mock function with given fields:
+func (_m *Client) LookupAWSClientConfig() (vaultapi.AWSClientConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.AWSClientConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.AWSClientConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.AWSClientConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupAWSRole provides a mock function with given fields: name
+func (_m *Client) LookupAWSRole(name string) (vaultapi.LookedUpAWSRole, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpAWSRole
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpAWSRole); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpAWSRole)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupAppRole provides a mock function with given fields: name
+func (_m *Client) LookupAppRole(name string) (vaultapi.LookedUpAppRole, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpAppRole
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpAppRole); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpAppRole)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupAppRoleID provides a mock function with given fields: name
+func (_m *Client) LookupAppRoleID(name string) (string, error) {
+	ret := _m.Called(name)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupAuthMountConfig provides a mock function with given fields: path
+func (_m *Client) LookupAuthMountConfig(path string) (vaultapi.MountConfig, error) {
+	ret := _m.Called(path)
+
+	var r0 vaultapi.MountConfig
+	if rf, ok := ret.Get(0).(func(string) vaultapi.MountConfig); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(vaultapi.MountConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupAzureRole provides a mock function with given fields: name
+func (_m *Client) LookupAzureRole(name string) (vaultapi.LookedUpAzureRole, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpAzureRole
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpAzureRole); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpAzureRole)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupControlGroupRequest provides a mock function with given fields: accessor
+func (_m *Client) LookupControlGroupRequest(accessor string) (vaultapi.ControlGroupRequest, error) {
+	ret := _m.Called(accessor)
+
+	var r0 vaultapi.ControlGroupRequest
+	if rf, ok := ret.Get(0).(func(string) vaultapi.ControlGroupRequest); ok {
+		r0 = rf(accessor)
+	} else {
+		r0 = ret.Get(0).(vaultapi.ControlGroupRequest)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accessor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupEntity provides a mock function with given fields: id
+func (_m *Client) LookupEntity(id string) (vaultapi.Entity, error) {
+	ret := _m.Called(id)
+
+	var r0 vaultapi.Entity
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Entity); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(vaultapi.Entity)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupEntityAlias provides a mock function with given fields: id
+func (_m *Client) LookupEntityAlias(id string) (vaultapi.EntityAlias, error) {
+	ret := _m.Called(id)
+
+	var r0 vaultapi.EntityAlias
+	if rf, ok := ret.Get(0).(func(string) vaultapi.EntityAlias); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(vaultapi.EntityAlias)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupEntityByName provides a mock function with given fields: name
+func (_m *Client) LookupEntityByName(name string) (vaultapi.Entity, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.Entity
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Entity); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.Entity)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupGitHubConfig provides a mock function with given fields:
+func (_m *Client) LookupGitHubConfig() (vaultapi.GitHubConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.GitHubConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.GitHubConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.GitHubConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupGitHubTeam provides a mock function with given fields: team
+func (_m *Client) LookupGitHubTeam(team string) ([]string, error) {
+	ret := _m.Called(team)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(team)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(team)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupGitHubUser provides a mock function with given fields: user
+func (_m *Client) LookupGitHubUser(user string) ([]string, error) {
+	ret := _m.Called(user)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupIdentityTokenConfig provides a mock function with given fields:
+func (_m *Client) LookupIdentityTokenConfig() (vaultapi.IdentityTokenConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.IdentityTokenConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.IdentityTokenConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.IdentityTokenConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupIdentityTokenKey provides a mock function with given fields: name
+func (_m *Client) LookupIdentityTokenKey(name string) (vaultapi.LookedUpIdentityTokenKey, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpIdentityTokenKey
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpIdentityTokenKey); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpIdentityTokenKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupIdentityTokenRole provides a mock function with given fields: name
+func (_m *Client) LookupIdentityTokenRole(name string) (vaultapi.LookedUpIdentityTokenRole, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpIdentityTokenRole
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpIdentityTokenRole); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpIdentityTokenRole)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupJWTConfig provides a mock function with given fields:
+func (_m *Client) LookupJWTConfig() (vaultapi.JWTConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.JWTConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.JWTConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.JWTConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupJWTRole provides a mock function with given fields: name
+func (_m *Client) LookupJWTRole(name string) (vaultapi.LookedUpJWTRole, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpJWTRole
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpJWTRole); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpJWTRole)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupKerberosConfig provides a mock function with given fields:
+func (_m *Client) LookupKerberosConfig() (vaultapi.KerberosConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.KerberosConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.KerberosConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.KerberosConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupKerberosGroup provides a mock function with given fields: name
+func (_m *Client) LookupKerberosGroup(name string) (vaultapi.LDAPGroup, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LDAPGroup
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LDAPGroup); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LDAPGroup)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupKerberosLDAPConfig provides a mock function with given fields:
+func (_m *Client) LookupKerberosLDAPConfig() (vaultapi.LDAPConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.LDAPConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.LDAPConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.LDAPConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupKubernetesConfig provides a mock function with given fields:
+func (_m *Client) LookupKubernetesConfig() (vaultapi.KubernetesConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.KubernetesConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.KubernetesConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.KubernetesConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupKubernetesRole provides a mock function with given fields: name
+func (_m *Client) LookupKubernetesRole(name string) (vaultapi.LookedUpKubernetesRole, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpKubernetesRole
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpKubernetesRole); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpKubernetesRole)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupLDAPConfig provides a mock function with given fields:
+func (_m *Client) LookupLDAPConfig() (vaultapi.LDAPConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.LDAPConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.LDAPConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.LDAPConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupLDAPGroup provides a mock function with given fields: name
+func (_m *Client) LookupLDAPGroup(name string) (vaultapi.LDAPGroup, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LDAPGroup
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LDAPGroup); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LDAPGroup)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupLDAPUser provides a mock function with given fields: name
+func (_m *Client) LookupLDAPUser(name string) (vaultapi.LDAPUser, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LDAPUser
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LDAPUser); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LDAPUser)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupLease provides a mock function with given fields: id
+func (_m *Client) LookupLease(id string) (vaultapi.Lease, error) {
+	ret := _m.Called(id)
+
+	var r0 vaultapi.Lease
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Lease); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(vaultapi.Lease)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupLeaseCountQuota provides a mock function with given fields: name
+func (_m *Client) LookupLeaseCountQuota(name string) (vaultapi.LeaseCountQuota, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LeaseCountQuota
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LeaseCountQuota); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LeaseCountQuota)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupLogger provides a mock function with given fields: name
+func (_m *Client) LookupLogger(name string) (string, error) {
+	ret := _m.Called(name)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupMFALoginEnforcement provides a mock function with given fields: name
+func (_m *Client) LookupMFALoginEnforcement(name string) (vaultapi.MFALoginEnforcement, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.MFALoginEnforcement
+	if rf, ok := ret.Get(0).(func(string) vaultapi.MFALoginEnforcement); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.MFALoginEnforcement)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupMFAMethod provides a mock function with given fields: id
+func (_m *Client) LookupMFAMethod(id string) (vaultapi.MFAMethod, error) {
+	ret := _m.Called(id)
+
+	var r0 vaultapi.MFAMethod
+	if rf, ok := ret.Get(0).(func(string) vaultapi.MFAMethod); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(vaultapi.MFAMethod)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupManagedKey provides a mock function with given fields: keyType, name
+func (_m *Client) LookupManagedKey(keyType string, name string) (map[string]interface{}, error) {
+	ret := _m.Called(keyType, name)
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func(string, string) map[string]interface{}); ok {
+		r0 = rf(keyType, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(keyType, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupMountConfig provides a mock function with given fields: path
+func (_m *Client) LookupMountConfig(path string) (vaultapi.MountConfig, error) {
+	ret := _m.Called(path)
+
+	var r0 vaultapi.MountConfig
+	if rf, ok := ret.Get(0).(func(string) vaultapi.MountConfig); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(vaultapi.MountConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupNamespace provides a mock function with given fields: path
+func (_m *Client) LookupNamespace(path string) (vaultapi.Namespace, error) {
+	ret := _m.Called(path)
+
+	var r0 vaultapi.Namespace
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Namespace); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(vaultapi.Namespace)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupOIDCAssignment provides a mock function with given fields: name
+func (_m *Client) LookupOIDCAssignment(name string) (vaultapi.OIDCAssignment, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.OIDCAssignment
+	if rf, ok := ret.Get(0).(func(string) vaultapi.OIDCAssignment); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.OIDCAssignment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupOIDCClient provides a mock function with given fields: name
+func (_m *Client) LookupOIDCClient(name string) (vaultapi.LookedUpOIDCClient, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpOIDCClient
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpOIDCClient); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpOIDCClient)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupOIDCProvider provides a mock function with given fields: name
+func (_m *Client) LookupOIDCProvider(name string) (vaultapi.OIDCProvider, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.OIDCProvider
+	if rf, ok := ret.Get(0).(func(string) vaultapi.OIDCProvider); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.OIDCProvider)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupOIDCScope provides a mock function with given fields: name
+func (_m *Client) LookupOIDCScope(name string) (vaultapi.OIDCScope, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.OIDCScope
+	if rf, ok := ret.Get(0).(func(string) vaultapi.OIDCScope); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.OIDCScope)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupPlugin provides a mock function with given fields: pluginType, name
+func (_m *Client) LookupPlugin(pluginType string, name string) (vaultapi.Plugin, error) {
+	ret := _m.Called(pluginType, name)
+
+	var r0 vaultapi.Plugin
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.Plugin); ok {
+		r0 = rf(pluginType, name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.Plugin)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(pluginType, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupQuotaConfig provides a mock function with given fields:
+func (_m *Client) LookupQuotaConfig() (vaultapi.QuotaConfig, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.QuotaConfig
+	if rf, ok := ret.Get(0).(func() vaultapi.QuotaConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.QuotaConfig)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupRateLimitQuota provides a mock function with given fields: name
+func (_m *Client) LookupRateLimitQuota(name string) (vaultapi.RateLimitQuota, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.RateLimitQuota
+	if rf, ok := ret.Get(0).(func(string) vaultapi.RateLimitQuota); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RateLimitQuota)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupSelfToken provides a mock function with given fields:
+func (_m *Client) LookupSelfToken() (vaultapi.LookedUpToken, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.LookedUpToken
+	if rf, ok := ret.Get(0).(func() vaultapi.LookedUpToken); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupToken provides a mock function with given fields: id
+func (_m *Client) LookupToken(id string) (vaultapi.LookedUpToken, error) {
+	ret := _m.Called(id)
+
+	var r0 vaultapi.LookedUpToken
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpToken); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupTokenRole provides a mock function with given fields: name
+func (_m *Client) LookupTokenRole(name string) (vaultapi.LookedUpTokenRole, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.LookedUpTokenRole
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpTokenRole); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpTokenRole)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupTransitKey provides a mock function with given fields: name
+func (_m *Client) LookupTransitKey(name string) (vaultapi.TransitKey, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.TransitKey
+	if rf, ok := ret.Get(0).(func(string) vaultapi.TransitKey); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.TransitKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupUIMount provides a mock function with given fields: path
+func (_m *Client) LookupUIMount(path string) (vaultapi.UIMount, error) {
+	ret := _m.Called(path)
+
+	var r0 vaultapi.UIMount
+	if rf, ok := ret.Get(0).(func(string) vaultapi.UIMount); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(vaultapi.UIMount)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupUserpassUser provides a mock function with given fields: username
+func (_m *Client) LookupUserpassUser(username string) (vaultapi.LookedUpUserpassUser, error) {
+	ret := _m.Called(username)
+
+	var r0 vaultapi.LookedUpUserpassUser
+	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpUserpassUser); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Get(0).(vaultapi.LookedUpUserpassUser)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupWrapping provides a mock function with given fields: token
+func (_m *Client) LookupWrapping(token string) (vaultapi.WrappingLookup, error) {
+	ret := _m.Called(token)
+
+	var r0 vaultapi.WrappingLookup
+	if rf, ok := ret.Get(0).(func(string) vaultapi.WrappingLookup); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(vaultapi.WrappingLookup)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MapGitHubTeam provides a mock function with given fields: team, policies
+func (_m *Client) MapGitHubTeam(team string, policies []string) error {
+	ret := _m.Called(team, policies)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(team, policies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MapGitHubUser provides a mock function with given fields: user, policies
+func (_m *Client) MapGitHubUser(user string, policies []string) error {
+	ret := _m.Called(user, policies)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(user, policies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeEntities provides a mock function with given fields: to, from, force
+func (_m *Client) MergeEntities(to string, from []string, force bool) error {
+	ret := _m.Called(to, from, force)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string, bool) error); ok {
+		r0 = rf(to, from, force)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Metrics provides a mock function with given fields: format
+func (_m *Client) Metrics(format string) (vaultapi.Metrics, error) {
+	ret := _m.Called(format)
+
+	var r0 vaultapi.Metrics
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Metrics); ok {
+		r0 = rf(format)
+	} else {
+		r0 = ret.Get(0).(vaultapi.Metrics)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Monitor provides a mock function with given fields: ctx, opts
+func (_m *Client) Monitor(ctx context.Context, opts vaultapi.MonitorOptions) (<-chan vaultapi.LogEntry, error) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 <-chan vaultapi.LogEntry
+	if rf, ok := ret.Get(0).(func(context.Context, vaultapi.MonitorOptions) <-chan vaultapi.LogEntry); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan vaultapi.LogEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, vaultapi.MonitorOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MonthlyActivity provides a mock function with given fields:
+func (_m *Client) MonthlyActivity() (vaultapi.Activity, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.Activity
+	if rf, ok := ret.Get(0).(func() vaultapi.Activity); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.Activity)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NomadSecretsMount provides a mock function with given fields: mount
+func (_m *Client) NomadSecretsMount(mount string) vaultapi.NomadSecrets {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.NomadSecrets
+	if rf, ok := ret.Get(0).(func(string) vaultapi.NomadSecrets); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.NomadSecrets)
+		}
+	}
+
+	return r0
+}
+
+// OIDCProviderDiscovery provides a mock function with given fields: name
+func (_m *Client) OIDCProviderDiscovery(name string) (vaultapi.OIDCDiscovery, error) {
+	ret := _m.Called(name)
+
+	var r0 vaultapi.OIDCDiscovery
+	if rf, ok := ret.Get(0).(func(string) vaultapi.OIDCDiscovery); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(vaultapi.OIDCDiscovery)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenAPI provides a mock function with given fields: genericMountPaths
+func (_m *Client) OpenAPI(genericMountPaths bool) ([]byte, error) {
+	ret := _m.Called(genericMountPaths)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(bool) []byte); ok {
+		r0 = rf(genericMountPaths)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(genericMountPaths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PKIMount provides a mock function with given fields: mount
+func (_m *Client) PKIMount(mount string) vaultapi.PKI {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.PKI
+	if rf, ok := ret.Get(0).(func(string) vaultapi.PKI); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.PKI)
+		}
+	}
+
+	return r0
+}
+
+// Put provides a mock function with given fields: path, value
+func (_m *Client) Put(path string, value string) error {
+	ret := _m.Called(path, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(path, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RabbitMQSecretsMount provides a mock function with given fields: mount
+func (_m *Client) RabbitMQSecretsMount(mount string) vaultapi.RabbitMQSecrets {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.RabbitMQSecrets
+	if rf, ok := ret.Get(0).(func(string) vaultapi.RabbitMQSecrets); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.RabbitMQSecrets)
+		}
+	}
+
+	return r0
+}
+
+// RaftBootstrapAnswer provides a mock function with given fields: opts
+func (_m *Client) RaftBootstrapAnswer(opts vaultapi.RaftBootstrapAnswerOptions) (vaultapi.RaftBootstrapAnswer, error) {
+	ret := _m.Called(opts)
+
+	var r0 vaultapi.RaftBootstrapAnswer
+	if rf, ok := ret.Get(0).(func(vaultapi.RaftBootstrapAnswerOptions) vaultapi.RaftBootstrapAnswer); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RaftBootstrapAnswer)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.RaftBootstrapAnswerOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RaftBootstrapChallenge provides a mock function with given fields: nodeID
+func (_m *Client) RaftBootstrapChallenge(nodeID string) (vaultapi.RaftBootstrapChallenge, error) {
+	ret := _m.Called(nodeID)
+
+	var r0 vaultapi.RaftBootstrapChallenge
+	if rf, ok := ret.Get(0).(func(string) vaultapi.RaftBootstrapChallenge); ok {
+		r0 = rf(nodeID)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RaftBootstrapChallenge)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(nodeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RaftConfiguration provides a mock function with given fields:
+func (_m *Client) RaftConfiguration() (vaultapi.RaftConfiguration, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.RaftConfiguration
+	if rf, ok := ret.Get(0).(func() vaultapi.RaftConfiguration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.RaftConfiguration)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RaftJoin provides a mock function with given fields: opts
+func (_m *Client) RaftJoin(opts vaultapi.RaftJoinOptions) (bool, error) {
+	ret := _m.Called(opts)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(vaultapi.RaftJoinOptions) bool); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.RaftJoinOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RaftRemovePeer provides a mock function with given fields: nodeID
+func (_m *Client) RaftRemovePeer(nodeID string) error {
+	ret := _m.Called(nodeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(nodeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RandomBytes provides a mock function with given fields: n
+func (_m *Client) RandomBytes(n int) ([]byte, error) {
+	ret := _m.Called(n)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(int) []byte); ok {
+		r0 = rf(n)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(n)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RawRequest provides a mock function with given fields: method, path, opts
+func (_m *Client) RawRequest(method string, path string, opts vaultapi.RawRequestOptions) (*http.Response, error) {
+	ret := _m.Called(method, path, opts)
+
+	var r0 *http.Response
+	if rf, ok := ret.Get(0).(func(string, string, vaultapi.RawRequestOptions) *http.Response); ok {
+		r0 = rf(method, path, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, vaultapi.RawRequestOptions) error); ok {
+		r1 = rf(method, path, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegisterPlugin provides a mock function with given fields: pluginType, name, opts
+func (_m *Client) RegisterPlugin(pluginType string, name string, opts vaultapi.PluginOptions) error {
+	ret := _m.Called(pluginType, name, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, vaultapi.PluginOptions) error); ok {
+		r0 = rf(pluginType, name, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RekeyBackup provides a mock function with given fields:
+func (_m *Client) RekeyBackup() (vaultapi.RekeyBackup, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.RekeyBackup
+	if rf, ok := ret.Get(0).(func() vaultapi.RekeyBackup); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyBackup)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyCancel provides a mock function with given fields:
+func (_m *Client) RekeyCancel() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RekeyCancelVerification provides a mock function with given fields:
+func (_m *Client) RekeyCancelVerification() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RekeyDeleteBackup provides a mock function with given fields:
+func (_m *Client) RekeyDeleteBackup() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RekeyInit provides a mock function with given fields: opts
+func (_m *Client) RekeyInit(opts vaultapi.RekeyOptions) (vaultapi.RekeyStatus, error) {
+	ret := _m.Called(opts)
+
+	var r0 vaultapi.RekeyStatus
+	if rf, ok := ret.Get(0).(func(vaultapi.RekeyOptions) vaultapi.RekeyStatus); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.RekeyOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyRecoveryKeyBackup provides a mock function with given fields:
+func (_m *Client) RekeyRecoveryKeyBackup() (vaultapi.RekeyBackup, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.RekeyBackup
+	if rf, ok := ret.Get(0).(func() vaultapi.RekeyBackup); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyBackup)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyRecoveryKeyCancel provides a mock function with given fields:
+func (_m *Client) RekeyRecoveryKeyCancel() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RekeyRecoveryKeyCancelVerification provides a mock function with given fields:
+func (_m *Client) RekeyRecoveryKeyCancelVerification() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RekeyRecoveryKeyDeleteBackup provides a mock function with given fields:
+func (_m *Client) RekeyRecoveryKeyDeleteBackup() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RekeyRecoveryKeyInit provides a mock function with given fields: opts
+func (_m *Client) RekeyRecoveryKeyInit(opts vaultapi.RekeyOptions) (vaultapi.RekeyStatus, error) {
+	ret := _m.Called(opts)
+
+	var r0 vaultapi.RekeyStatus
+	if rf, ok := ret.Get(0).(func(vaultapi.RekeyOptions) vaultapi.RekeyStatus); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.RekeyOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyRecoveryKeyStatus provides a mock function with given fields:
+func (_m *Client) RekeyRecoveryKeyStatus() (vaultapi.RekeyStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.RekeyStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.RekeyStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyRecoveryKeyUpdate provides a mock function with given fields: key, nonce
+func (_m *Client) RekeyRecoveryKeyUpdate(key string, nonce string) (vaultapi.RekeyUpdate, error) {
+	ret := _m.Called(key, nonce)
+
+	var r0 vaultapi.RekeyUpdate
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.RekeyUpdate); ok {
+		r0 = rf(key, nonce)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyUpdate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyRecoveryKeyVerificationStatus provides a mock function with given fields:
+func (_m *Client) RekeyRecoveryKeyVerificationStatus() (vaultapi.RekeyVerificationStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.RekeyVerificationStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.RekeyVerificationStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyVerificationStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyRecoveryKeyVerify provides a mock function with given fields: key, nonce
+func (_m *Client) RekeyRecoveryKeyVerify(key string, nonce string) (vaultapi.RekeyVerification, error) {
+	ret := _m.Called(key, nonce)
+
+	var r0 vaultapi.RekeyVerification
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.RekeyVerification); ok {
+		r0 = rf(key, nonce)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyVerification)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyStatus provides a mock function with given fields:
+func (_m *Client) RekeyStatus() (vaultapi.RekeyStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.RekeyStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.RekeyStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyUpdate provides a mock function with given fields: key, nonce
+func (_m *Client) RekeyUpdate(key string, nonce string) (vaultapi.RekeyUpdate, error) {
+	ret := _m.Called(key, nonce)
+
+	var r0 vaultapi.RekeyUpdate
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.RekeyUpdate); ok {
+		r0 = rf(key, nonce)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyUpdate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyVerificationStatus provides a mock function with given fields:
+func (_m *Client) RekeyVerificationStatus() (vaultapi.RekeyVerificationStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.RekeyVerificationStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.RekeyVerificationStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyVerificationStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RekeyVerify provides a mock function with given fields: key, nonce
+func (_m *Client) RekeyVerify(key string, nonce string) (vaultapi.RekeyVerification, error) {
+	ret := _m.Called(key, nonce)
+
+	var r0 vaultapi.RekeyVerification
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.RekeyVerification); ok {
+		r0 = rf(key, nonce)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RekeyVerification)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReloadPlugin provides a mock function with given fields: opts
+func (_m *Client) ReloadPlugin(opts vaultapi.PluginReloadOptions) (string, error) {
+	ret := _m.Called(opts)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(vaultapi.PluginReloadOptions) string); ok {
+		r0 = rf(opts)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vaultapi.PluginReloadOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remount provides a mock function with given fields: from, to
+func (_m *Client) Remount(from string, to string) (string, error) {
+	ret := _m.Called(from, to)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(from, to)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemountStatus provides a mock function with given fields: migrationID
+func (_m *Client) RemountStatus(migrationID string) (vaultapi.RemountStatus, error) {
+	ret := _m.Called(migrationID)
+
+	var r0 vaultapi.RemountStatus
+	if rf, ok := ret.Get(0).(func(string) vaultapi.RemountStatus); ok {
+		r0 = rf(migrationID)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RemountStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(migrationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenewLease provides a mock function with given fields: id, increment
+func (_m *Client) RenewLease(id string, increment time.Duration) (vaultapi.RenewedLease, error) {
+	ret := _m.Called(id, increment)
+
+	var r0 vaultapi.RenewedLease
+	if rf, ok := ret.Get(0).(func(string, time.Duration) vaultapi.RenewedLease); ok {
+		r0 = rf(id, increment)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RenewedLease)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(id, increment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenewSelfToken provides a mock function with given fields: increment
+func (_m *Client) RenewSelfToken(increment time.Duration) (vaultapi.RenewedToken, error) {
+	ret := _m.Called(increment)
+
+	var r0 vaultapi.RenewedToken
+	if rf, ok := ret.Get(0).(func(time.Duration) vaultapi.RenewedToken); ok {
+		r0 = rf(increment)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RenewedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Duration) error); ok {
+		r1 = rf(increment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenewToken provides a mock function with given fields: id, increment
+func (_m *Client) RenewToken(id string, increment time.Duration) (vaultapi.RenewedToken, error) {
+	ret := _m.Called(id, increment)
+
+	var r0 vaultapi.RenewedToken
+	if rf, ok := ret.Get(0).(func(string, time.Duration) vaultapi.RenewedToken); ok {
+		r0 = rf(id, increment)
+	} else {
+		r0 = ret.Get(0).(vaultapi.RenewedToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(id, increment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetLogLevels provides a mock function with given fields:
+func (_m *Client) ResetLogLevels() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetLoggerLevel provides a mock function with given fields: name
+func (_m *Client) ResetLoggerLevel(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResultantACL provides a mock function with given fields:
+func (_m *Client) ResultantACL() (vaultapi.ResultantACL, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.ResultantACL
+	if rf, ok := ret.Get(0).(func() vaultapi.ResultantACL); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.ResultantACL)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeForce provides a mock function with given fields: prefix
+func (_m *Client) RevokeForce(prefix string) error {
+	ret := _m.Called(prefix)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(prefix)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeLease provides a mock function with given fields: id
+func (_m *Client) RevokeLease(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokePrefix provides a mock function with given fields: prefix
+func (_m *Client) RevokePrefix(prefix string) error {
+	ret := _m.Called(prefix)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(prefix)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Rewrap provides a mock function with given fields: key, ciphertext, opts
+func (_m *Client) Rewrap(key string, ciphertext string, opts vaultapi.TransitOptions) (string, error) {
+	ret := _m.Called(key, ciphertext, opts)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, vaultapi.TransitOptions) string); ok {
+		r0 = rf(key, ciphertext, opts)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, vaultapi.TransitOptions) error); ok {
+		r1 = rf(key, ciphertext, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RewrapBatch provides a mock function with given fields: key, items, keyVersion
+func (_m *Client) RewrapBatch(key string, items []vaultapi.TransitBatchItem, keyVersion int) ([]vaultapi.TransitBatchResult, error) {
+	ret := _m.Called(key, items, keyVersion)
+
+	var r0 []vaultapi.TransitBatchResult
+	if rf, ok := ret.Get(0).(func(string, []vaultapi.TransitBatchItem, int) []vaultapi.TransitBatchResult); ok {
+		r0 = rf(key, items, keyVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vaultapi.TransitBatchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []vaultapi.TransitBatchItem, int) error); ok {
+		r1 = rf(key, items, keyVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RewrapWrapping provides a mock function with given fields: token
+func (_m *Client) RewrapWrapping(token string) (vaultapi.WrapInfo, error) {
+	ret := _m.Called(token)
+
+	var r0 vaultapi.WrapInfo
+	if rf, ok := ret.Get(0).(func(string) vaultapi.WrapInfo); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(vaultapi.WrapInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateIdentityTokenKey provides a mock function with given fields: name, verificationTTL
+func (_m *Client) RotateIdentityTokenKey(name string, verificationTTL string) error {
+	ret := _m.Called(name, verificationTTL)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, verificationTTL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateTransitKey provides a mock function with given fields: name
+func (_m *Client) RotateTransitKey(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SSHMount provides a mock function with given fields: mount
+func (_m *Client) SSHMount(mount string) vaultapi.SSH {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.SSH
+	if rf, ok := ret.Get(0).(func(string) vaultapi.SSH); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.SSH)
+		}
+	}
+
+	return r0
+}
+
+// SanitizedConfig provides a mock function with given fields:
+func (_m *Client) SanitizedConfig() (map[string]interface{}, error) {
+	ret := _m.Called()
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func() map[string]interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SealStatus provides a mock function with given fields:
+func (_m *Client) SealStatus() (vaultapi.SealStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.SealStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.SealStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.SealStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SealWrapRewrap provides a mock function with given fields:
+func (_m *Client) SealWrapRewrap() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SealWrapRewrapStatus provides a mock function with given fields:
+func (_m *Client) SealWrapRewrapStatus() (vaultapi.SealWrapRewrapStatus, error) {
+	ret := _m.Called()
+
+	var r0 vaultapi.SealWrapRewrapStatus
+	if rf, ok := ret.Get(0).(func() vaultapi.SealWrapRewrapStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vaultapi.SealWrapRewrapStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelfCapabilities provides a mock function with given fields: path
+func (_m *Client) SelfCapabilities(path string) ([]string, error) {
+	ret := _m.Called(path)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAuth provides a mock function with given fields: method
+func (_m *Client) SetAuth(method vaultapi.AuthMethod) {
+	_m.Called(method)
+}
+
+// SetKerberosGroup provides a mock function with given fields: name, policies
+func (_m *Client) SetKerberosGroup(name string, policies []string) error {
+	ret := _m.Called(name, policies)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(name, policies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLDAPGroup provides a mock function with given fields: name, policies
+func (_m *Client) SetLDAPGroup(name string, policies []string) error {
+	ret := _m.Called(name, policies)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(name, policies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLDAPUser provides a mock function with given fields: name, groups, policies
+func (_m *Client) SetLDAPUser(name string, groups []string, policies []string) error {
+	ret := _m.Called(name, groups, policies)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string, []string) error); ok {
+		r0 = rf(name, groups, policies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLogLevel provides a mock function with given fields: level
+func (_m *Client) SetLogLevel(level string) error {
+	ret := _m.Called(level)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLoggerLevel provides a mock function with given fields: name, level
+func (_m *Client) SetLoggerLevel(name string, level string) error {
+	ret := _m.Called(name, level)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPolicy provides a mock function with given fields: name, content
+func (_m *Client) SetPolicy(name string, content string) error {
+	ret := _m.Called(name, content)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SignBatch provides a mock function with given fields: key, items, algorithm
+func (_m *Client) SignBatch(key string, items []vaultapi.TransitBatchItem, algorithm string) ([]vaultapi.TransitBatchResult, error) {
+	ret := _m.Called(key, items, algorithm)
+
+	var r0 []vaultapi.TransitBatchResult
+	if rf, ok := ret.Get(0).(func(string, []vaultapi.TransitBatchItem, string) []vaultapi.TransitBatchResult); ok {
+		r0 = rf(key, items, algorithm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vaultapi.TransitBatchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []vaultapi.TransitBatchItem, string) error); ok {
+		r1 = rf(key, items, algorithm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StepDown provides a mock function with given fields:
+func (_m *Client) StepDown() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TerraformSecretsMount provides a mock function with given fields: mount
+func (_m *Client) TerraformSecretsMount(mount string) vaultapi.TerraformSecrets {
+	ret := _m.Called(mount)
+
+	var r0 vaultapi.TerraformSecrets
+	if rf, ok := ret.Get(0).(func(string) vaultapi.TerraformSecrets); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.TerraformSecrets)
+		}
+	}
+
+	return r0
+}
+
+// TestManagedKeySign provides a mock function with given fields: keyType, name, hashAlgorithm
+func (_m *Client) TestManagedKeySign(keyType string, name string, hashAlgorithm string) error {
+	ret := _m.Called(keyType, name, hashAlgorithm)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(keyType, name, hashAlgorithm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TidyLeases provides a mock function with given fields:
+func (_m *Client) TidyLeases() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Keys provides a mock function with given fields: path
-func (_m *Client) Keys(path string) ([]string, error) {
-	ret := _m.Called(path)
+// TokenCapabilities provides a mock function with given fields: path, token
+func (_m *Client) TokenCapabilities(path string, token string) ([]string, error) {
+	ret := _m.Called(path, token)
 
 	var r0 []string
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
-		r0 = rf(path)
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(path, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
@@ -187,8 +5335,8 @@ func (_m *Client) Keys(path string) ([]string, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(path)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(path, token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -196,20 +5344,22 @@ func (_m *Client) Keys(path string) ([]string, error) {
 	return r0, r1
 }
 
-// Leader provides a mock function with given fields:
-func (_m *Client) Leader() (vaultapi.Leader, error) {
-	ret := _m.Called()
+// ToolsHash provides a mock function with given fields: input, algorithm
+func (_m *Client) ToolsHash(input []byte, algorithm string) ([]byte, error) {
+	ret := _m.Called(input, algorithm)
 
-	var r0 vaultapi.Leader
-	if rf, ok := ret.Get(0).(func() vaultapi.Leader); ok {
-		r0 = rf()
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]byte, string) []byte); ok {
+		r0 = rf(input, algorithm)
 	} else {
-		r0 = ret.Get(0).(vaultapi.Leader)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func([]byte, string) error); ok {
+		r1 = rf(input, algorithm)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -217,22 +5367,22 @@ func (_m *Client) Leader() (vaultapi.Leader, error) {
 	return r0, r1
 }
 
-// ListMounts provides a mock function with given fields:
-func (_m *Client) ListMounts() (vaultapi.Mounts, error) {
-	ret := _m.Called()
+// ToolsRandomBytes provides a mock function with given fields: n
+func (_m *Client) ToolsRandomBytes(n int) ([]byte, error) {
+	ret := _m.Called(n)
 
-	var r0 vaultapi.Mounts
-	if rf, ok := ret.Get(0).(func() vaultapi.Mounts); ok {
-		r0 = rf()
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(int) []byte); ok {
+		r0 = rf(n)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(vaultapi.Mounts)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(n)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -240,45 +5390,62 @@ func (_m *Client) ListMounts() (vaultapi.Mounts, error) {
 	return r0, r1
 }
 
-// ListPolicies provides a mock function with given fields:
-func (_m *Client) ListPolicies() ([]string, error) {
-	ret := _m.Called()
+// TrimTransitKey provides a mock function with given fields: name, minAvailableVersion
+func (_m *Client) TrimTransitKey(name string, minAvailableVersion int) error {
+	ret := _m.Called(name, minAvailableVersion)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func() []string); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(name, minAvailableVersion)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Error(0)
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	return r0
+}
+
+// TuneAuthMount provides a mock function with given fields: path, opts
+func (_m *Client) TuneAuthMount(path string, opts vaultapi.MountConfigOptions) error {
+	ret := _m.Called(path, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.MountConfigOptions) error); ok {
+		r0 = rf(path, opts)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListTokenRoles provides a mock function with given fields:
-func (_m *Client) ListTokenRoles() ([]string, error) {
-	ret := _m.Called()
+// TuneMount provides a mock function with given fields: path, opts
+func (_m *Client) TuneMount(path string, opts vaultapi.MountConfigOptions) error {
+	ret := _m.Called(path, opts)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func() []string); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.MountConfigOptions) error); ok {
+		r0 = rf(path, opts)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unwrap provides a mock function with given fields: token
+func (_m *Client) Unwrap(token string) (vaultapi.Unwrapped, error) {
+	ret := _m.Called(token)
+
+	var r0 vaultapi.Unwrapped
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Unwrapped); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(vaultapi.Unwrapped)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -286,41 +5453,62 @@ func (_m *Client) ListTokenRoles() ([]string, error) {
 	return r0, r1
 }
 
-// LookupLease provides a mock function with given fields: id
-func (_m *Client) LookupLease(id string) (vaultapi.Lease, error) {
-	ret := _m.Called(id)
+// UpdateEntity provides a mock function with given fields: id, opts
+func (_m *Client) UpdateEntity(id string, opts vaultapi.EntityOptions) error {
+	ret := _m.Called(id, opts)
 
-	var r0 vaultapi.Lease
-	if rf, ok := ret.Get(0).(func(string) vaultapi.Lease); ok {
-		r0 = rf(id)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.EntityOptions) error); ok {
+		r0 = rf(id, opts)
 	} else {
-		r0 = ret.Get(0).(vaultapi.Lease)
+		r0 = ret.Error(0)
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	return r0
+}
+
+// UpdateEntityAlias provides a mock function with given fields: id, opts
+func (_m *Client) UpdateEntityAlias(id string, opts vaultapi.EntityAliasOptions) error {
+	ret := _m.Called(id, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.EntityAliasOptions) error); ok {
+		r0 = rf(id, opts)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// LookupSelfToken provides a mock function with given fields:
-func (_m *Client) LookupSelfToken() (vaultapi.LookedUpToken, error) {
-	ret := _m.Called()
+// UpdateMFAMethod provides a mock function with given fields: id, opts
+func (_m *Client) UpdateMFAMethod(id string, opts vaultapi.MFAMethodOptions) error {
+	ret := _m.Called(id, opts)
 
-	var r0 vaultapi.LookedUpToken
-	if rf, ok := ret.Get(0).(func() vaultapi.LookedUpToken); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, vaultapi.MFAMethodOptions) error); ok {
+		r0 = rf(id, opts)
 	} else {
-		r0 = ret.Get(0).(vaultapi.LookedUpToken)
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateMFA provides a mock function with given fields: requestID, payload
+func (_m *Client) ValidateMFA(requestID string, payload map[string][]string) (vaultapi.CreatedToken, error) {
+	ret := _m.Called(requestID, payload)
+
+	var r0 vaultapi.CreatedToken
+	if rf, ok := ret.Get(0).(func(string, map[string][]string) vaultapi.CreatedToken); ok {
+		r0 = rf(requestID, payload)
+	} else {
+		r0 = ret.Get(0).(vaultapi.CreatedToken)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, map[string][]string) error); ok {
+		r1 = rf(requestID, payload)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -328,20 +5516,22 @@ func (_m *Client) LookupSelfToken() (vaultapi.LookedUpToken, error) {
 	return r0, r1
 }
 
-// LookupToken provides a mock function with given fields: id
-func (_m *Client) LookupToken(id string) (vaultapi.LookedUpToken, error) {
-	ret := _m.Called(id)
+// VerifyBatch provides a mock function with given fields: key, items, algorithm
+func (_m *Client) VerifyBatch(key string, items []vaultapi.TransitBatchItem, algorithm string) ([]vaultapi.TransitBatchResult, error) {
+	ret := _m.Called(key, items, algorithm)
 
-	var r0 vaultapi.LookedUpToken
-	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpToken); ok {
-		r0 = rf(id)
+	var r0 []vaultapi.TransitBatchResult
+	if rf, ok := ret.Get(0).(func(string, []vaultapi.TransitBatchItem, string) []vaultapi.TransitBatchResult); ok {
+		r0 = rf(key, items, algorithm)
 	} else {
-		r0 = ret.Get(0).(vaultapi.LookedUpToken)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vaultapi.TransitBatchResult)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string, []vaultapi.TransitBatchItem, string) error); ok {
+		r1 = rf(key, items, algorithm)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -349,20 +5539,20 @@ func (_m *Client) LookupToken(id string) (vaultapi.LookedUpToken, error) {
 	return r0, r1
 }
 
-// LookupTokenRole provides a mock function with given fields: name
-func (_m *Client) LookupTokenRole(name string) (vaultapi.LookedUpTokenRole, error) {
-	ret := _m.Called(name)
+// VerifyHMAC provides a mock function with given fields: key, input, hmac, algorithm
+func (_m *Client) VerifyHMAC(key string, input []byte, hmac string, algorithm string) (bool, error) {
+	ret := _m.Called(key, input, hmac, algorithm)
 
-	var r0 vaultapi.LookedUpTokenRole
-	if rf, ok := ret.Get(0).(func(string) vaultapi.LookedUpTokenRole); ok {
-		r0 = rf(name)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, []byte, string, string) bool); ok {
+		r0 = rf(key, input, hmac, algorithm)
 	} else {
-		r0 = ret.Get(0).(vaultapi.LookedUpTokenRole)
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(string, []byte, string, string) error); ok {
+		r1 = rf(key, input, hmac, algorithm)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -370,150 +5560,170 @@ func (_m *Client) LookupTokenRole(name string) (vaultapi.LookedUpTokenRole, erro
 	return r0, r1
 }
 
-// Put provides a mock function with given fields: path, value
-func (_m *Client) Put(path string, value string) error {
-	ret := _m.Called(path, value)
+// WithContext provides a mock function with given fields: ctx
+func (_m *Client) WithContext(ctx context.Context) vaultapi.Client {
+	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(path, value)
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(context.Context) vaultapi.Client); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Client)
+		}
 	}
 
 	return r0
 }
 
-// RenewSelfToken provides a mock function with given fields: increment
-func (_m *Client) RenewSelfToken(increment time.Duration) (vaultapi.RenewedToken, error) {
-	ret := _m.Called(increment)
-
-	var r0 vaultapi.RenewedToken
-	if rf, ok := ret.Get(0).(func(time.Duration) vaultapi.RenewedToken); ok {
-		r0 = rf(increment)
-	} else {
-		r0 = ret.Get(0).(vaultapi.RenewedToken)
-	}
+// WithHeader provides a mock function with given fields: key, value
+func (_m *Client) WithHeader(key string, value string) vaultapi.Client {
+	ret := _m.Called(key, value)
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(time.Duration) error); ok {
-		r1 = rf(increment)
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(string, string) vaultapi.Client); ok {
+		r0 = rf(key, value)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Client)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// RenewToken provides a mock function with given fields: id, increment
-func (_m *Client) RenewToken(id string, increment time.Duration) (vaultapi.RenewedToken, error) {
-	ret := _m.Called(id, increment)
-
-	var r0 vaultapi.RenewedToken
-	if rf, ok := ret.Get(0).(func(string, time.Duration) vaultapi.RenewedToken); ok {
-		r0 = rf(id, increment)
-	} else {
-		r0 = ret.Get(0).(vaultapi.RenewedToken)
+// WithMFA provides a mock function with given fields: credentials
+func (_m *Client) WithMFA(credentials ...string) vaultapi.Client {
+	_va := make([]interface{}, len(credentials))
+	for _i := range credentials {
+		_va[_i] = credentials[_i]
 	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
-		r1 = rf(id, increment)
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(...string) vaultapi.Client); ok {
+		r0 = rf(credentials...)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Client)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// SealStatus provides a mock function with given fields:
-func (_m *Client) SealStatus() (vaultapi.SealStatus, error) {
-	ret := _m.Called()
+// WithNamespace provides a mock function with given fields: namespace
+func (_m *Client) WithNamespace(namespace string) vaultapi.Client {
+	ret := _m.Called(namespace)
 
-	var r0 vaultapi.SealStatus
-	if rf, ok := ret.Get(0).(func() vaultapi.SealStatus); ok {
-		r0 = rf()
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Client); ok {
+		r0 = rf(namespace)
 	} else {
-		r0 = ret.Get(0).(vaultapi.SealStatus)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Client)
+		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	return r0
+}
+
+// WithNoRequestForwarding provides a mock function with given fields: noForwarding
+func (_m *Client) WithNoRequestForwarding(noForwarding bool) vaultapi.Client {
+	ret := _m.Called(noForwarding)
+
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(bool) vaultapi.Client); ok {
+		r0 = rf(noForwarding)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Client)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// SelfCapabilities provides a mock function with given fields: path
-func (_m *Client) SelfCapabilities(path string) ([]string, error) {
-	ret := _m.Called(path)
+// WithResponseInfo provides a mock function with given fields: info
+func (_m *Client) WithResponseInfo(info *vaultapi.ResponseInfo) vaultapi.Client {
+	ret := _m.Called(info)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
-		r0 = rf(path)
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(*vaultapi.ResponseInfo) vaultapi.Client); ok {
+		r0 = rf(info)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).(vaultapi.Client)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(path)
+	return r0
+}
+
+// WithTimeout provides a mock function with given fields: timeout
+func (_m *Client) WithTimeout(timeout time.Duration) vaultapi.Client {
+	ret := _m.Called(timeout)
+
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(time.Duration) vaultapi.Client); ok {
+		r0 = rf(timeout)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Client)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// SetPolicy provides a mock function with given fields: name, content
-func (_m *Client) SetPolicy(name string, content string) error {
-	ret := _m.Called(name, content)
+// WithToken provides a mock function with given fields: token
+func (_m *Client) WithToken(token string) vaultapi.Client {
+	ret := _m.Called(token)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(name, content)
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(string) vaultapi.Client); ok {
+		r0 = rf(token)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Client)
+		}
 	}
 
 	return r0
 }
 
-// StepDown provides a mock function with given fields:
-func (_m *Client) StepDown() error {
-	ret := _m.Called()
+// WithWrapTTL provides a mock function with given fields: ttl, info
+func (_m *Client) WithWrapTTL(ttl time.Duration, info *vaultapi.WrapInfo) vaultapi.Client {
+	ret := _m.Called(ttl, info)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	var r0 vaultapi.Client
+	if rf, ok := ret.Get(0).(func(time.Duration, *vaultapi.WrapInfo) vaultapi.Client); ok {
+		r0 = rf(ttl, info)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vaultapi.Client)
+		}
 	}
 
 	return r0
 }
 
-// TokenCapabilities provides a mock function with given fields: path, token
-func (_m *Client) TokenCapabilities(path string, token string) ([]string, error) {
-	ret := _m.Called(path, token)
+// Wrap provides a mock function with given fields: data, ttl
+func (_m *Client) Wrap(data map[string]interface{}, ttl time.Duration) (vaultapi.WrapInfo, error) {
+	ret := _m.Called(data, ttl)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
-		r0 = rf(path, token)
+	var r0 vaultapi.WrapInfo
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, time.Duration) vaultapi.WrapInfo); ok {
+		r0 = rf(data, ttl)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Get(0).(vaultapi.WrapInfo)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(path, token)
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, time.Duration) error); ok {
+		r1 = rf(data, ttl)
 	} else {
 		r1 = ret.Error(1)
 	}
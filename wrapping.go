@@ -0,0 +1,197 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A WrapInfo describes a response wrapped by vault, which is stored in
+// the cubbyhole of a single use wrapping Token until the TTL expires.
+// The WrappedAccessor is the accessor of the token being wrapped, if
+// the wrapped response is a token.
+//
+// More information about response wrapping can be found here:
+// https://www.vaultproject.io/docs/concepts/response-wrapping.html
+type WrapInfo struct {
+	Token           string `json:"token"`
+	Accessor        string `json:"accessor"`
+	TTL             int    `json:"ttl"`
+	CreationTime    string `json:"creation_time"`
+	CreationPath    string `json:"creation_path"`
+	WrappedAccessor string `json:"wrapped_accessor"`
+}
+
+type wrapInfoWrapper struct {
+	WrapInfo WrapInfo `json:"wrap_info"`
+}
+
+// Wrap wraps the data with the given TTL, so that it can be passed
+// along by a wrapping token and retrieved with Unwrap.
+func (c *client) Wrap(data map[string]interface{}, ttl time.Duration) (WrapInfo, error) {
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return WrapInfo{}, errors.Wrap(err, "marshalling wrap data to JSON request body")
+	}
+
+	// do not log the request, which is presumably secret
+	header := http.Header{headerWrapTTL: []string{durationString(ttl)}}
+	var wrapper wrapInfoWrapper
+	if err := c.postHeaders("/v1/sys/wrapping/wrap", string(bs), header, &wrapper); err != nil {
+		return WrapInfo{}, errors.Wrap(err, "failed to wrap data")
+	}
+
+	return wrapper.WrapInfo, nil
+}
+
+// An Unwrapped is the response which was wrapped by a wrapping token.
+// The Data is set for wrapped secrets, and the Auth for wrapped
+// tokens, e.g. from a wrapped login.
+type Unwrapped struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *CreatedToken          `json:"auth"`
+}
+
+// Unwrap returns the response wrapped by the wrapping token, which can
+// be unwrapped only once. If token is empty, the token of the Client is
+// unwrapped instead, so that a workload given only a wrapping token can
+// create a Client with NewStaticToken(token) and unwrap with it.
+func (c *client) Unwrap(token string) (Unwrapped, error) {
+	bs, err := json.Marshal(struct {
+		Token string `json:"token,omitempty"`
+	}{Token: token})
+	if err != nil {
+		return Unwrapped{}, err
+	}
+
+	var unwrapped Unwrapped
+	if err := c.post("/v1/sys/wrapping/unwrap", string(bs), &unwrapped); err != nil {
+		return Unwrapped{}, errors.Wrap(err, "failed to unwrap response")
+	}
+
+	return unwrapped, nil
+}
+
+// A WrappingLookup describes a wrapping token, without unwrapping it.
+// The CreationTTL is in seconds.
+type WrappingLookup struct {
+	CreationPath string `json:"creation_path"`
+	CreationTime string `json:"creation_time"`
+	CreationTTL  int    `json:"creation_ttl"`
+}
+
+func (c *client) LookupWrapping(token string) (WrappingLookup, error) {
+	bs, err := json.Marshal(struct {
+		Token string `json:"token"`
+	}{Token: token})
+	if err != nil {
+		return WrappingLookup{}, err
+	}
+
+	var wrapper struct {
+		Data WrappingLookup `json:"data"`
+	}
+	if err := c.post("/v1/sys/wrapping/lookup", string(bs), &wrapper); err != nil {
+		return WrappingLookup{}, errors.Wrap(err, "failed to look up wrapping token")
+	}
+
+	return wrapper.Data, nil
+}
+
+// RewrapWrapping moves the response wrapped by the wrapping token into
+// a new wrapping token, with the original TTL, and revokes the old
+// wrapping token. This is useful for refreshing long lived wrapping
+// tokens.
+func (c *client) RewrapWrapping(token string) (WrapInfo, error) {
+	bs, err := json.Marshal(struct {
+		Token string `json:"token"`
+	}{Token: token})
+	if err != nil {
+		return WrapInfo{}, err
+	}
+
+	var wrapper wrapInfoWrapper
+	if err := c.post("/v1/sys/wrapping/rewrap", string(bs), &wrapper); err != nil {
+		return WrapInfo{}, errors.Wrap(err, "failed to rewrap wrapping token")
+	}
+
+	return wrapper.WrapInfo, nil
+}
+
+// ErrWrappingMismatch indicates that a wrapping token did not match
+// the expectations of its receiver, in which case it may have been
+// substituted with a token wrapping something else, or already been
+// intercepted and rewrapped.
+var ErrWrappingMismatch = errors.New("wrapping token does not match expectations")
+
+// WrappingExpectations describe the wrapping token a workload expects
+// to receive. The CreationPath is the path of the request that was
+// wrapped, e.g. "auth/approle/role/app/secret-id", and may end in a *
+// to match any suffix. If set, the creation TTL must not exceed the
+// MaxTTL, and the token must have been created within MaxAge.
+type WrappingExpectations struct {
+	CreationPath string
+	MaxTTL       time.Duration
+	MaxAge       time.Duration
+}
+
+// ValidateWrapping checks that the lookup of a wrapping token matches
+// expect, returning an error caused by ErrWrappingMismatch if not.
+func ValidateWrapping(lookup WrappingLookup, expect WrappingExpectations) error {
+	return validateWrapping(lookup, expect, time.Now())
+}
+
+func validateWrapping(lookup WrappingLookup, expect WrappingExpectations, now time.Time) error {
+	if !matchCreationPath(expect.CreationPath, lookup.CreationPath) {
+		return errors.Wrapf(ErrWrappingMismatch, "creation path %q is not %q", lookup.CreationPath, expect.CreationPath)
+	}
+
+	ttl := time.Duration(lookup.CreationTTL) * time.Second
+	if expect.MaxTTL > 0 && ttl > expect.MaxTTL {
+		return errors.Wrapf(ErrWrappingMismatch, "creation ttl %s exceeds %s", ttl, expect.MaxTTL)
+	}
+
+	if expect.MaxAge > 0 {
+		created, err := time.Parse(time.RFC3339Nano, lookup.CreationTime)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse creation time %q", lookup.CreationTime)
+		}
+		if age := now.Sub(created); age > expect.MaxAge {
+			return errors.Wrapf(ErrWrappingMismatch, "created %s ago, more than %s", age, expect.MaxAge)
+		}
+	}
+
+	return nil
+}
+
+func matchCreationPath(pattern, path string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(path, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == path
+}
+
+// UnwrapValidated looks up the wrapping token, and unwraps it only if
+// it matches expect. This is the secure introduction of a secret to a
+// workload, which should alert if ErrWrappingMismatch is the cause of
+// the error, since the token may have been tampered with.
+func UnwrapValidated(client Client, token string, expect WrappingExpectations) (Unwrapped, error) {
+	lookup, err := client.LookupWrapping(token)
+	if err != nil {
+		return Unwrapped{}, err
+	}
+
+	if err := ValidateWrapping(lookup, expect); err != nil {
+		return Unwrapped{}, err
+	}
+
+	return client.Unwrap(token)
+}
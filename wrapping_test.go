@@ -0,0 +1,87 @@
+// Author hoenig
+
+package vaultapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_Wrap(t *testing.T) {
+	client := getClient(t, rootTokener)
+
+	info, err := client.Wrap(map[string]interface{}{
+		"password": "hunter2",
+	}, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, info.Token)
+	require.Equal(t, 60, info.TTL)
+
+	lookup, err := client.LookupWrapping(info.Token)
+	require.NoError(t, err)
+	require.Equal(t, 60, lookup.CreationTTL)
+
+	rewrapped, err := client.RewrapWrapping(info.Token)
+	require.NoError(t, err)
+	require.NotEqual(t, info.Token, rewrapped.Token)
+
+	// the old wrapping token was revoked by the rewrap
+	_, err = client.Unwrap(info.Token)
+	require.Error(t, err)
+
+	unwrapped, err := client.Unwrap(rewrapped.Token)
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", unwrapped.Data["password"])
+
+	// wrapping tokens can be unwrapped only once
+	_, err = client.Unwrap(rewrapped.Token)
+	require.Error(t, err)
+}
+
+func Test_Client_WithWrapTTL(t *testing.T) {
+	client := getClient(t, rootTokener)
+	defer cleanup(t, client)
+
+	err := client.Put("/wrapped/value", "abc123")
+	require.NoError(t, err)
+
+	// the value is wrapped rather than returned
+	var info WrapInfo
+	_, err = client.WithWrapTTL(time.Minute, &info).Get("/wrapped/value")
+	require.Equal(t, ErrNoValue, err)
+	require.NotEmpty(t, info.Token)
+
+	unwrapped, err := client.Unwrap(info.Token)
+	require.NoError(t, err)
+	require.Equal(t, "abc123", unwrapped.Data["value"])
+}
+
+func Test_validateWrapping(t *testing.T) {
+	now := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	lookup := WrappingLookup{
+		CreationPath: "auth/approle/role/app/secret-id",
+		CreationTime: "2019-03-01T11:58:00.123456Z",
+		CreationTTL:  300,
+	}
+
+	try := func(expect WrappingExpectations, valid bool) {
+		err := validateWrapping(lookup, expect, now)
+		if valid {
+			require.NoError(t, err)
+		} else {
+			require.Equal(t, ErrWrappingMismatch, errors.Cause(err))
+		}
+	}
+
+	try(WrappingExpectations{CreationPath: "auth/approle/role/app/secret-id"}, true)
+	try(WrappingExpectations{CreationPath: "auth/approle/role/*"}, true)
+	try(WrappingExpectations{CreationPath: "auth/approle/role/admin/secret-id"}, false)
+	try(WrappingExpectations{CreationPath: "sys/wrapping/wrap"}, false)
+	try(WrappingExpectations{CreationPath: "auth/approle/*", MaxTTL: 5 * time.Minute}, true)
+	try(WrappingExpectations{CreationPath: "auth/approle/*", MaxTTL: time.Minute}, false)
+	try(WrappingExpectations{CreationPath: "auth/approle/*", MaxAge: 5 * time.Minute}, true)
+	try(WrappingExpectations{CreationPath: "auth/approle/*", MaxAge: time.Minute}, false)
+}